@@ -0,0 +1,525 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/sprintertech/sprinter-signing/api/types"
+)
+
+const (
+	DEFAULT_RETRIES        = 3
+	DEFAULT_RETRY_WAIT_MIN = 500 * time.Millisecond
+	DEFAULT_RETRY_WAIT_MAX = 10 * time.Second
+)
+
+type Option func(c *Client)
+
+// WithHTTPClient sets the underlying http client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.retryClient.HTTPClient = httpClient
+	}
+}
+
+// WithRetries sets how many times failed requests are retried and the
+// bounds of the exponential backoff between retries
+func WithRetries(retries int, waitMin time.Duration, waitMax time.Duration) Option {
+	return func(c *Client) {
+		c.retryClient.RetryMax = retries
+		c.retryClient.RetryWaitMin = waitMin
+		c.retryClient.RetryWaitMax = waitMax
+	}
+}
+
+// WithHmacKey authenticates requests with the HMAC key of the client
+func WithHmacKey(clientID string, key string) Option {
+	return func(c *Client) {
+		c.clientID = clientID
+		c.sign = func(payload []byte) ([]byte, error) {
+			mac := hmac.New(sha256.New, []byte(key))
+			mac.Write(payload)
+			return mac.Sum(nil), nil
+		}
+	}
+}
+
+// WithPrivateKey authenticates requests with EIP-191 signatures of the client solver key
+func WithPrivateKey(clientID string, key *ecdsa.PrivateKey) Option {
+	return func(c *Client) {
+		c.clientID = clientID
+		c.sign = func(payload []byte) ([]byte, error) {
+			return crypto.Sign(accounts.TextHash(payload), key)
+		}
+	}
+}
+
+// Client is a client of the signing API of a single relayer
+type Client struct {
+	url         string
+	retryClient *retryablehttp.Client
+
+	clientID string
+	sign     func(payload []byte) ([]byte, error)
+}
+
+func NewClient(url string, opts ...Option) *Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = DEFAULT_RETRIES
+	retryClient.RetryWaitMin = DEFAULT_RETRY_WAIT_MIN
+	retryClient.RetryWaitMax = DEFAULT_RETRY_WAIT_MAX
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	retryClient.Logger = nil
+
+	c := &Client{
+		url:         strings.TrimSuffix(url, "/"),
+		retryClient: retryClient,
+	}
+	retryClient.PrepareRetry = c.reauthenticate
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Sign requests a signature for the deposit and returns the request ID
+// that can be used to track the signing status
+func (c *Client) Sign(ctx context.Context, chainID uint64, req *SigningRequest) (string, error) {
+	resp := &SigningResponse{}
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/v1/chains/%d/signatures", chainID),
+		req,
+		http.StatusAccepted,
+		resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.ID, nil
+}
+
+// Preview verifies the signing request without signing it and returns
+// the data the committee would sign for the deposit
+func (c *Client) Preview(ctx context.Context, chainID uint64, req *SigningRequest) (*PreviewResponse, error) {
+	resp := &PreviewResponse{}
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/v1/chains/%d/signatures/preview", chainID),
+		req,
+		http.StatusOK,
+		resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SignBatch requests signatures for all requests in a single MPC process and returns
+// the request ID or validation error of each request in the order of the requests
+func (c *Client) SignBatch(ctx context.Context, req *BatchSigningRequest) ([]*BatchSigningResult, error) {
+	resp := &BatchSigningResponse{}
+	err := c.do(ctx, http.MethodPost, "/v1/signatures/batch", req, http.StatusAccepted, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
+
+// Unlock requests an unlock signature for the order
+func (c *Client) Unlock(ctx context.Context, chainID uint64, req *UnlockRequest) (*UnlockResponse, error) {
+	resp := &UnlockResponse{}
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/v1/chains/%d/unlocks", chainID),
+		req,
+		http.StatusOK,
+		resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Verify recovers the signer of the signature and reports if it was
+// signed by the current or a historical MPC address
+func (c *Client) Verify(ctx context.Context, req *VerifyRequest) (*VerifyResponse, error) {
+	resp := &VerifyResponse{}
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		"/v1/signatures/verify",
+		req,
+		http.StatusOK,
+		resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Status returns the latest status of the signing request
+func (c *Client) Status(ctx context.Context, chainID uint64, depositID string) (*StatusResponse, error) {
+	resp := &StatusResponse{}
+	err := c.do(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("/v1/chains/%d/signatures/%s/status", chainID, url.PathEscape(depositID)),
+		nil,
+		http.StatusOK,
+		resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Signatures returns persisted signatures matching the query ordered by signing time
+func (c *Client) Signatures(ctx context.Context, query SignatureQuery) ([]SignatureRecord, error) {
+	values := url.Values{}
+	if query.Caller != "" {
+		values.Set("caller", query.Caller)
+	}
+	if !query.From.IsZero() {
+		values.Set("from", query.From.Format(time.RFC3339))
+	}
+	if !query.To.IsZero() {
+		values.Set("to", query.To.Format(time.RFC3339))
+	}
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	resp := &SignatureHistoryResponse{}
+	err := c.do(
+		ctx,
+		http.MethodGet,
+		"/v1/signatures?"+values.Encode(),
+		nil,
+		http.StatusOK,
+		resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Signatures, nil
+}
+
+// Confirmations returns the block confirmations required by deposit value for the chain
+func (c *Client) Confirmations(ctx context.Context, chainID uint64) (Confirmations, error) {
+	resp := Confirmations{}
+	err := c.do(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("/v1/chains/%d/confirmations", chainID),
+		nil,
+		http.StatusOK,
+		&resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// StreamStatus sends status transitions of the signing request to the event channel
+// until the request is signed or the server closes the stream of the failed request.
+// Failed requests can still be signed by other relayers or retried, so the stream is
+// kept open after a failure. Dropped connections are resumed from the last received
+// status with exponential backoff.
+func (c *Client) StreamStatus(ctx context.Context, chainID uint64, depositID string, events chan<- StatusEvent) error {
+	path := fmt.Sprintf("/v1/chains/%d/signatures/%s", chainID, url.PathEscape(depositID))
+	lastEventID := uint64(0)
+	attempt := 0
+	for {
+		done, err := c.stream(ctx, path, &lastEventID, events)
+		if done {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if attempt >= c.retryClient.RetryMax {
+			if err == nil {
+				err = fmt.Errorf("status stream closed")
+			}
+			return err
+		}
+		select {
+		case <-time.After(c.retryClient.Backoff(c.retryClient.RetryWaitMin, c.retryClient.RetryWaitMax, attempt, nil)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		attempt++
+	}
+}
+
+// WaitForSignature waits until the signing request is signed and returns the signature
+func (c *Client) WaitForSignature(ctx context.Context, chainID uint64, depositID string) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan StatusEvent, types.STATUS_BUFFER_SIZE)
+	errChn := make(chan error, 1)
+	go func() {
+		errChn <- c.StreamStatus(ctx, chainID, depositID, events)
+	}()
+
+	var failure error
+	handle := func(event StatusEvent) {
+		if event.Status == nil {
+			return
+		}
+		if event.Status.State == types.FailedState {
+			failure = fmt.Errorf("signing failed: %s", event.Status.Reason)
+		} else {
+			failure = nil
+		}
+	}
+	for {
+		select {
+		case event := <-events:
+			{
+				if event.Signature != nil {
+					return event.Signature, nil
+				}
+				handle(event)
+			}
+		case err := <-errChn:
+			{
+				if err != nil {
+					return nil, err
+				}
+
+				// the stream is finished so all remaining events are already buffered
+				for len(events) > 0 {
+					event := <-events
+					if event.Signature != nil {
+						return event.Signature, nil
+					}
+					handle(event)
+				}
+				if failure != nil {
+					return nil, failure
+				}
+				return nil, fmt.Errorf("status stream closed")
+			}
+		}
+	}
+}
+
+// stream reads the status event stream and returns true if the request was signed,
+// the stream of the failed request was closed by the server or the stream can not be resumed
+func (c *Client) stream(ctx context.Context, path string, lastEventID *uint64, events chan<- StatusEvent) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return true, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastEventID != 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(*lastEventID, 10))
+	}
+
+	resp, err := c.retryClient.HTTPClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := apiError(resp)
+		return resp.StatusCode < http.StatusInternalServerError, err
+	}
+
+	var event, data, id string
+	failed := false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			{
+				if data == "" {
+					continue
+				}
+
+				statusEvent, err := parseEvent(event, data)
+				if err != nil {
+					return true, err
+				}
+				if id != "" {
+					*lastEventID, _ = strconv.ParseUint(id, 10, 64)
+				}
+
+				select {
+				case events <- statusEvent:
+				case <-ctx.Done():
+					return true, ctx.Err()
+				}
+				if statusEvent.Signature != nil {
+					return true, nil
+				}
+				failed = statusEvent.Status.State == types.FailedState
+				event, data, id = "", "", ""
+			}
+		case strings.HasPrefix(line, ":"):
+			continue
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+	}
+
+	if scanner.Err() == nil && failed {
+		return true, nil
+	}
+	return false, scanner.Err()
+}
+
+func parseEvent(event string, data string) (StatusEvent, error) {
+	switch event {
+	case "status":
+		{
+			status := &types.RequestStatus{}
+			err := json.Unmarshal([]byte(data), status)
+			if err != nil {
+				return StatusEvent{}, err
+			}
+			return StatusEvent{Status: status}, nil
+		}
+	case "":
+		{
+			signature, err := hex.DecodeString(data)
+			if err != nil {
+				return StatusEvent{}, err
+			}
+			return StatusEvent{Signature: signature}, nil
+		}
+	default:
+		return StatusEvent{}, fmt.Errorf("unknown event %s", event)
+	}
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body any, expectedStatus int, result any) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, c.url+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	err = c.authenticate(req.Request, reqBody, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.retryClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		return apiError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// authenticate signs the request with the timestamp. Relayers accept each
+// signature only once, so retries are signed again with a later timestamp.
+func (c *Client) authenticate(req *http.Request, body []byte, timestamp int64) error {
+	if c.sign == nil {
+		return nil
+	}
+
+	payload := append([]byte(fmt.Sprintf("%d\n%s\n%s\n", timestamp, req.Method, req.URL.RequestURI())), body...)
+	signature, err := c.sign(payload)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(types.CLIENT_HEADER, c.clientID)
+	req.Header.Set(types.TIMESTAMP_HEADER, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(types.SIGNATURE_HEADER, hexutil.Encode(signature))
+	return nil
+}
+
+func (c *Client) reauthenticate(req *http.Request) error {
+	if c.sign == nil {
+		return nil
+	}
+
+	var body []byte
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		body, err = io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	timestamp := time.Now().Unix()
+	previous, err := strconv.ParseInt(req.Header.Get(types.TIMESTAMP_HEADER), 10, 64)
+	if err == nil && timestamp <= previous {
+		timestamp = previous + 1
+	}
+	return c.authenticate(req, body, timestamp)
+}
+
+func apiError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	apiErr := &APIError{}
+	err = json.Unmarshal(body, apiErr)
+	if err != nil || apiErr.Reason == "" {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Reason:     string(bytes.TrimSpace(body)),
+		}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	return apiErr
+}
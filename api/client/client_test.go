@@ -0,0 +1,267 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sprintertech/sprinter-signing/api/client"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	"github.com/sprintertech/sprinter-signing/cache"
+	evmMessage "github.com/sprintertech/sprinter-signing/chains/evm/message"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+
+	msgChn chan []*message.Message
+	server *httptest.Server
+	client *client.Client
+}
+
+func TestRunClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	chains := map[uint64]struct{}{1: {}}
+	s.msgChn = make(chan []*message.Message, 1)
+	authenticator := handlers.NewAuthenticator(map[string]relayer.ApiCredential{
+		"solver": {
+			HmacKey: "secret",
+		},
+	})
+
+	r := mux.NewRouter()
+	r.Handle(
+		"/v1/chains/{chainId:[0-9]+}/signatures",
+		authenticator.Middleware(http.HandlerFunc(handlers.NewSigningHandler(s.msgChn, chains, nil, cache.NewStatusCache()).HandleSigning)),
+	).Methods("POST")
+	r.HandleFunc(
+		"/v1/chains/{chainId:[0-9]+}/confirmations",
+		handlers.NewConfirmationsHandler(map[uint64]map[uint64]uint64{1: {1000: 2, 10000: 5}}).HandleRequest,
+	).Methods("GET")
+	s.server = httptest.NewServer(r)
+
+	s.client = client.NewClient(
+		s.server.URL,
+		client.WithHmacKey("solver", "secret"),
+		client.WithRetries(2, time.Millisecond, time.Millisecond*10),
+	)
+}
+
+func (s *ClientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ClientTestSuite) signingRequest() *client.SigningRequest {
+	return &client.SigningRequest{
+		DepositID:     "1000",
+		Nonce:         big.NewInt(1001),
+		Protocol:      client.AcrossProtocol,
+		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		BorrowAmount:  big.NewInt(2000),
+		Deadline:      100,
+	}
+}
+
+func (s *ClientTestSuite) Test_Sign_ValidRequest() {
+	id, err := s.client.Sign(context.Background(), 1, s.signingRequest())
+
+	s.Nil(err)
+	s.Equal("1-1000", id)
+
+	msg := <-s.msgChn
+	data := msg[0].Data.(*evmMessage.AcrossData)
+	s.Equal(big.NewInt(1000), data.DepositId)
+	s.Equal(big.NewInt(1001), data.Nonce)
+	s.Equal(big.NewInt(2000), data.BorrowAmount)
+	s.Equal(uint64(100), data.Deadline)
+	s.Equal("solver", data.ClientID)
+}
+
+func (s *ClientTestSuite) Test_Sign_InvalidRequest() {
+	req := s.signingRequest()
+	req.Caller = ""
+
+	_, err := s.client.Sign(context.Background(), 1, req)
+
+	apiErr := &client.APIError{}
+	s.True(errors.As(err, &apiErr))
+	s.Equal(http.StatusBadRequest, apiErr.StatusCode)
+	s.Equal("invalid request body: missing field 'caller'", apiErr.Reason)
+	s.Equal(errcode.InvalidRequest, apiErr.ErrorCode)
+	s.Equal([]errcode.FieldError{{Field: "caller", Reason: "missing"}}, apiErr.Fields)
+}
+
+func (s *ClientTestSuite) Test_Sign_InvalidCredentials() {
+	c := client.NewClient(s.server.URL, client.WithHmacKey("solver", "invalid"))
+
+	_, err := c.Sign(context.Background(), 1, s.signingRequest())
+
+	apiErr := &client.APIError{}
+	s.True(errors.As(err, &apiErr))
+	s.Equal(http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+func (s *ClientTestSuite) Test_Sign_RetriesUnavailableServer() {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			handlers.JSONError(w, fmt.Errorf("unavailable"), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id":"1-1000"}`))
+	}))
+	defer server.Close()
+	c := client.NewClient(server.URL, client.WithRetries(2, time.Millisecond, time.Millisecond*10))
+
+	id, err := c.Sign(context.Background(), 1, s.signingRequest())
+
+	s.Nil(err)
+	s.Equal("1-1000", id)
+	s.Equal(3, attempts)
+}
+
+func (s *ClientTestSuite) Test_Sign_RetriesSignedAgain() {
+	attempts := 0
+	authenticator := handlers.NewAuthenticator(map[string]relayer.ApiCredential{
+		"solver": {
+			HmacKey: "secret",
+		},
+	})
+	server := httptest.NewServer(authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			handlers.JSONError(w, fmt.Errorf("unavailable"), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id":"1-1000"}`))
+	})))
+	defer server.Close()
+	c := client.NewClient(
+		server.URL,
+		client.WithHmacKey("solver", "secret"),
+		client.WithRetries(2, time.Millisecond, time.Millisecond*10),
+	)
+
+	id, err := c.Sign(context.Background(), 1, s.signingRequest())
+
+	s.Nil(err)
+	s.Equal("1-1000", id)
+	s.Equal(2, attempts)
+}
+
+func (s *ClientTestSuite) Test_Confirmations() {
+	confirmations, err := s.client.Confirmations(context.Background(), 1)
+
+	s.Nil(err)
+	s.Equal(client.Confirmations{1000: 2, 10000: 5}, confirmations)
+}
+
+func (s *ClientTestSuite) Test_Signatures() {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"signatures":[{"id":"1-1000","caller":"0xabc","signature":"0102","signedAt":"2025-01-01T12:00:00Z"}]}`)
+	}))
+	defer server.Close()
+	c := client.NewClient(server.URL)
+
+	signatures, err := c.Signatures(context.Background(), client.SignatureQuery{
+		Caller: "0xabc",
+		From:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:  10,
+	})
+
+	s.Nil(err)
+	s.Equal(url.Values{
+		"caller": {"0xabc"},
+		"from":   {"2025-01-01T00:00:00Z"},
+		"limit":  {"10"},
+	}, query)
+	s.Equal([]client.SignatureRecord{
+		{
+			ID:        "1-1000",
+			Caller:    "0xabc",
+			Signature: "0102",
+			SignedAt:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}, signatures)
+}
+
+func (s *ClientTestSuite) Test_StreamStatus_ResumesDroppedStream() {
+	lastEventIDs := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		if len(lastEventIDs) == 1 {
+			fmt.Fprint(w, ": heartbeat\n\n")
+			fmt.Fprint(w, "id: 1\nevent: status\ndata: {\"sequence\":1,\"id\":\"1-1000\",\"state\":\"received\"}\n\n")
+			return
+		}
+		fmt.Fprint(w, "id: 2\nevent: status\ndata: {\"sequence\":2,\"id\":\"1-1000\",\"state\":\"signing\"}\n\n")
+		fmt.Fprint(w, "data: 0102\n\n")
+	}))
+	defer server.Close()
+	c := client.NewClient(server.URL, client.WithRetries(2, time.Millisecond, time.Millisecond*10))
+
+	events := make(chan client.StatusEvent, 3)
+	err := c.StreamStatus(context.Background(), 1, "1000", events)
+
+	s.Nil(err)
+	s.Equal([]string{"", "1"}, lastEventIDs)
+	s.Equal(cache.ReceivedState, (<-events).Status.State)
+	s.Equal(cache.SigningState, (<-events).Status.State)
+	s.Equal([]byte{1, 2}, (<-events).Signature)
+}
+
+func (s *ClientTestSuite) Test_WaitForSignature_SignedAfterFailure() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "id: 1\nevent: status\ndata: {\"sequence\":1,\"id\":\"1-1000\",\"state\":\"failed\",\"reason\":\"tss timeout\"}\n\n")
+		fmt.Fprint(w, "id: 2\nevent: status\ndata: {\"sequence\":2,\"id\":\"1-1000\",\"state\":\"signed\"}\n\n")
+		fmt.Fprint(w, "data: 0102\n\n")
+	}))
+	defer server.Close()
+	c := client.NewClient(server.URL)
+
+	sig, err := c.WaitForSignature(context.Background(), 1, "1000")
+
+	s.Nil(err)
+	s.Equal([]byte{1, 2}, sig)
+}
+
+func (s *ClientTestSuite) Test_WaitForSignature_Failed() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "id: 1\nevent: status\ndata: {\"sequence\":1,\"id\":\"1-1000\",\"state\":\"failed\",\"reason\":\"invalid deposit\"}\n\n")
+	}))
+	defer server.Close()
+	c := client.NewClient(server.URL)
+
+	_, err := c.WaitForSignature(context.Background(), 1, "1000")
+
+	s.NotNil(err)
+	s.Equal("signing failed: invalid deposit", err.Error())
+}
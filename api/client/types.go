@@ -0,0 +1,158 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
+	"github.com/sprintertech/sprinter-signing/errcode"
+)
+
+type Protocol string
+
+const (
+	AcrossProtocol         Protocol = "across"
+	LifiEscrowProtocol     Protocol = "lifi-escrow"
+	LighterProtocol        Protocol = "lighter"
+	SprinterCreditProtocol Protocol = "sprinter-credit"
+)
+
+type SigningRequest struct {
+	ChainID          uint64   `json:"chainId,omitempty"`
+	DepositID        string   `json:"depositId"`
+	Nonce            *big.Int `json:"nonce"`
+	Protocol         Protocol `json:"protocol"`
+	LiquidityPool    string   `json:"liquidityPool"`
+	Caller           string   `json:"caller"`
+	Calldata         string   `json:"calldata,omitempty"`
+	DepositTxHash    string   `json:"depositTxHash,omitempty"`
+	BorrowAmount     *big.Int `json:"borrowAmount,omitempty"`
+	RepaymentChainID uint64   `json:"repaymentChainId,omitempty"`
+	Deadline         uint64   `json:"deadline"`
+	TokenOut         string   `json:"tokenOut,omitempty"`
+	CallbackURL      string   `json:"callbackUrl,omitempty"`
+}
+
+type SigningResponse struct {
+	ID string `json:"id"`
+}
+
+// PreviewResponse contains the calldata, unlock hash and typed data
+// the committee would sign for the signing request
+type PreviewResponse struct {
+	ID string `json:"id"`
+	signature.Preview
+}
+
+type BatchSigningRequest struct {
+	Items []*SigningRequest `json:"items"`
+}
+
+type BatchSigningResult struct {
+	ID        string               `json:"id"`
+	Error     string               `json:"error,omitempty"`
+	ErrorCode errcode.Code         `json:"errorCode,omitempty"`
+	Details   map[string]any       `json:"details,omitempty"`
+	Fields    []errcode.FieldError `json:"fields,omitempty"`
+}
+
+type BatchSigningResponse struct {
+	Results []*BatchSigningResult `json:"results"`
+}
+
+type UnlockRequest struct {
+	Protocol Protocol `json:"protocol"`
+	OrderID  string   `json:"orderId"`
+	Settler  string   `json:"settler"`
+}
+
+type UnlockResponse struct {
+	Signature string `json:"signature"`
+	ID        string `json:"id"`
+}
+
+type BorrowParams struct {
+	ChainID       uint64   `json:"chainId"`
+	LiquidityPool string   `json:"liquidityPool"`
+	Caller        string   `json:"caller"`
+	Token         string   `json:"token"`
+	Amount        *big.Int `json:"amount"`
+	Target        string   `json:"target"`
+	Calldata      string   `json:"calldata,omitempty"`
+	Nonce         *big.Int `json:"nonce"`
+	Deadline      uint64   `json:"deadline"`
+}
+
+type AllowOpenParams struct {
+	ChainID     uint64 `json:"chainId"`
+	OrderID     string `json:"orderId"`
+	Settler     string `json:"settler"`
+	Destination string `json:"destination"`
+}
+
+// VerifyRequest contains the signature and either the raw digest or
+// the parameters of exactly one of the signed messages
+type VerifyRequest struct {
+	Signature string           `json:"signature"`
+	Digest    string           `json:"digest,omitempty"`
+	Borrow    *BorrowParams    `json:"borrow,omitempty"`
+	AllowOpen *AllowOpenParams `json:"allowOpen,omitempty"`
+}
+
+type VerifyResponse struct {
+	Digest  string `json:"digest"`
+	Signer  string `json:"signer"`
+	Valid   bool   `json:"valid"`
+	Current bool   `json:"current"`
+}
+
+type StatusResponse = types.StatusResponse
+
+// SignatureQuery filters persisted signatures. Empty fields are not filtered on.
+type SignatureQuery struct {
+	Caller string
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
+
+type SignatureRecord struct {
+	ID         string    `json:"id"`
+	Caller     string    `json:"caller,omitempty"`
+	ParamsHash string    `json:"paramsHash,omitempty"`
+	Signature  string    `json:"signature"`
+	SignedAt   time.Time `json:"signedAt"`
+}
+
+type SignatureHistoryResponse struct {
+	Signatures []SignatureRecord `json:"signatures"`
+}
+
+// Confirmations maps the maximum deposit value in USD to the number of
+// block confirmations required before the deposit is signed
+type Confirmations map[uint64]uint64
+
+// StatusEvent is a single event of the signing request status stream.
+// The signature is only set on the final event of a signed request.
+type StatusEvent struct {
+	Status    *types.RequestStatus
+	Signature []byte
+}
+
+// APIError is returned for requests rejected by the signing API
+type APIError struct {
+	StatusCode int                  `json:"code"`
+	ErrorCode  errcode.Code         `json:"errorCode"`
+	Reason     string               `json:"reason"`
+	Details    map[string]any       `json:"details,omitempty"`
+	Fields     []errcode.FieldError `json:"fields,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("signing api error: code %d (%s), reason: %s", e.StatusCode, e.ErrorCode, e.Reason)
+	}
+	return fmt.Sprintf("signing api error: code %d, reason: %s", e.StatusCode, e.Reason)
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ethereumCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/sprintertech/sprinter-signing/topology"
+	"github.com/sprintertech/sprinter-signing/tss"
+)
+
+type SessionManager interface {
+	PendingProcesses() []tss.PendingProcess
+	CancelProcess(sessionID string) error
+}
+
+type BlameFetcher interface {
+	SessionBlames() []tss.SessionBlame
+	PeerBlames() []tss.PeerBlame
+}
+
+type TopologyFetcher interface {
+	Topology() (*topology.NetworkTopology, error)
+}
+
+type KeyshareFetcher interface {
+	GetKeyshare() (keyshare.ECDSAKeyshare, error)
+}
+
+type PeerNetwork interface {
+	Connectedness(peer.ID) network.Connectedness
+}
+
+type SessionResponse struct {
+	tss.PendingProcess
+	Age string `json:"age"`
+}
+
+type PeerResponse struct {
+	ID            string   `json:"id"`
+	Addrs         []string `json:"addrs"`
+	Connectedness string   `json:"connectedness"`
+}
+
+type PeersResponse struct {
+	Self      string          `json:"self"`
+	Threshold int             `json:"threshold"`
+	Peers     []*PeerResponse `json:"peers"`
+}
+
+type KeyshareResponse struct {
+	MpcAddress string   `json:"mpcAddress"`
+	Threshold  int      `json:"threshold"`
+	Peers      []string `json:"peers"`
+}
+
+type BlameResponse struct {
+	Sessions []tss.SessionBlame `json:"sessions"`
+	Peers    []tss.PeerBlame    `json:"peers"`
+}
+
+type LogLevelBody struct {
+	Level string `json:"level"`
+}
+
+// AdminHandler exposes the internal state of the relayer to operators and
+// allows intervening with stuck sessions. It should only be served on a private address.
+type AdminHandler struct {
+	self      peer.ID
+	sessions  SessionManager
+	blame     BlameFetcher
+	topology  TopologyFetcher
+	keyshares KeyshareFetcher
+	network   PeerNetwork
+}
+
+func NewAdminHandler(
+	self peer.ID,
+	sessions SessionManager,
+	blame BlameFetcher,
+	topology TopologyFetcher,
+	keyshares KeyshareFetcher,
+	network PeerNetwork,
+) *AdminHandler {
+	return &AdminHandler{
+		self:      self,
+		sessions:  sessions,
+		blame:     blame,
+		topology:  topology,
+		keyshares: keyshares,
+		network:   network,
+	}
+}
+
+// HandleSessions lists pending tss sessions with their age and coordinator
+func (h *AdminHandler) HandleSessions(w http.ResponseWriter, r *http.Request) {
+	processes := h.sessions.PendingProcesses()
+	resp := make([]*SessionResponse, len(processes))
+	for i, process := range processes {
+		resp[i] = &SessionResponse{
+			PendingProcess: process,
+			Age:            time.Since(process.StartedAt).Round(time.Second).String(),
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+// HandleCancelSession stops the pending tss session
+func (h *AdminHandler) HandleCancelSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := mux.Vars(r)["sessionId"]
+	err := h.sessions.CancelProcess(sessionID)
+	if err != nil {
+		JSONError(w, err, http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleBlame returns peers blamed for the latest failed tss sessions and
+// the failures each peer was blamed for in the cool-down period
+func (h *AdminHandler) HandleBlame(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, BlameResponse{
+		Sessions: h.blame.SessionBlames(),
+		Peers:    h.blame.PeerBlames(),
+	})
+}
+
+// HandlePeers returns the current topology and the connection state to each peer
+func (h *AdminHandler) HandlePeers(w http.ResponseWriter, r *http.Request) {
+	t, err := h.topology.Topology()
+	if err != nil {
+		JSONError(w, fmt.Errorf("failed reading topology: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	resp := PeersResponse{
+		Self:      h.self.String(),
+		Threshold: t.Threshold,
+		Peers:     make([]*PeerResponse, len(t.Peers)),
+	}
+	for i, p := range t.Peers {
+		addrs := make([]string, len(p.Addrs))
+		for j, addr := range p.Addrs {
+			addrs[j] = addr.String()
+		}
+
+		resp.Peers[i] = &PeerResponse{
+			ID:            p.ID.String(),
+			Addrs:         addrs,
+			Connectedness: h.network.Connectedness(p.ID).String(),
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+// HandleKeyshare returns the public metadata of the current keyshare
+func (h *AdminHandler) HandleKeyshare(w http.ResponseWriter, r *http.Request) {
+	k, err := h.keyshares.GetKeyshare()
+	if err != nil {
+		JSONError(w, err, http.StatusNotFound)
+		return
+	}
+	if k.Key.ECDSAPub == nil {
+		JSONError(w, fmt.Errorf("keyshare missing public key"), http.StatusNotFound)
+		return
+	}
+
+	peers := make([]string, len(k.Peers))
+	for i, p := range k.Peers {
+		peers[i] = p.String()
+	}
+	writeJSON(w, KeyshareResponse{
+		MpcAddress: ethereumCrypto.PubkeyToAddress(*k.Key.ECDSAPub.ToBtcecPubKey().ToECDSA()).Hex(),
+		Threshold:  k.Threshold,
+		Peers:      peers,
+	})
+}
+
+// HandleLogLevel returns the current global log level
+func (h *AdminHandler) HandleLogLevel(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, LogLevelBody{
+		Level: zerolog.GlobalLevel().String(),
+	})
+}
+
+// HandleSetLogLevel changes the global log level until the relayer is restarted
+func (h *AdminHandler) HandleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	body := LogLevelBody{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid request body: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	level, err := zerolog.ParseLevel(body.Level)
+	if err != nil || body.Level == "" {
+		JSONError(w, fmt.Errorf("unknown log level: %s", body.Level), http.StatusBadRequest)
+		return
+	}
+
+	log.Info().Msgf("Changing log level to %s", level)
+	zerolog.SetGlobalLevel(level)
+	writeJSON(w, LogLevelBody{
+		Level: level.String(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, resp any) {
+	data, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
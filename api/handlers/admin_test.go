@@ -0,0 +1,228 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ethereumCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/rs/zerolog"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/sprintertech/sprinter-signing/topology"
+	"github.com/sprintertech/sprinter-signing/tss"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AdminHandlerTestSuite struct {
+	suite.Suite
+
+	mockSessions  *mock_handlers.MockSessionManager
+	mockBlame     *mock_handlers.MockBlameFetcher
+	mockTopology  *mock_handlers.MockTopologyFetcher
+	mockKeyshares *mock_handlers.MockKeyshareFetcher
+	mockNetwork   *mock_handlers.MockPeerNetwork
+
+	handler *handlers.AdminHandler
+}
+
+func TestRunAdminHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminHandlerTestSuite))
+}
+
+func (s *AdminHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+
+	s.mockSessions = mock_handlers.NewMockSessionManager(ctrl)
+	s.mockBlame = mock_handlers.NewMockBlameFetcher(ctrl)
+	s.mockTopology = mock_handlers.NewMockTopologyFetcher(ctrl)
+	s.mockKeyshares = mock_handlers.NewMockKeyshareFetcher(ctrl)
+	s.mockNetwork = mock_handlers.NewMockPeerNetwork(ctrl)
+	self, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	s.handler = handlers.NewAdminHandler(
+		self,
+		s.mockSessions,
+		s.mockBlame,
+		s.mockTopology,
+		s.mockKeyshares,
+		s.mockNetwork,
+	)
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleSessions() {
+	coordinator, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	s.mockSessions.EXPECT().PendingProcesses().Return([]tss.PendingProcess{
+		{
+			SessionID:   "1-1",
+			Coordinator: coordinator,
+			StartedAt:   time.Now().Add(-time.Minute),
+		},
+	})
+	req := httptest.NewRequest(http.MethodGet, "/v1/sessions", nil)
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleSessions(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	resp := []*handlers.SessionResponse{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Nil(err)
+	s.Len(resp, 1)
+	s.Equal("1-1", resp[0].SessionID)
+	s.Equal(coordinator, resp[0].Coordinator)
+	s.Equal("1m0s", resp[0].Age)
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleCancelSession_NotPending() {
+	s.mockSessions.EXPECT().CancelProcess("1-1").Return(fmt.Errorf("process 1-1 not pending"))
+	req := httptest.NewRequest(http.MethodDelete, "/v1/sessions/1-1", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"sessionId": "1-1",
+	})
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleCancelSession(recorder, req)
+
+	s.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleCancelSession_Cancelled() {
+	s.mockSessions.EXPECT().CancelProcess("1-1").Return(nil)
+	req := httptest.NewRequest(http.MethodDelete, "/v1/sessions/1-1", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"sessionId": "1-1",
+	})
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleCancelSession(recorder, req)
+
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleBlame() {
+	peerID, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	blamedAt := time.Now().Add(-time.Minute).UTC()
+	s.mockBlame.EXPECT().SessionBlames().Return([]tss.SessionBlame{
+		{
+			SessionID:   "1-1",
+			ProcessType: common.SigningProcess,
+			Reason:      tss.StallBlame,
+			Peers:       []peer.ID{peerID},
+			BlamedAt:    blamedAt,
+		},
+	})
+	s.mockBlame.EXPECT().PeerBlames().Return([]tss.PeerBlame{
+		{
+			Peer:          peerID,
+			Failures:      map[tss.BlameReason]int{tss.StallBlame: 1},
+			LastBlamedAt:  blamedAt,
+			Deprioritized: false,
+		},
+	})
+	req := httptest.NewRequest(http.MethodGet, "/v1/blame", nil)
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleBlame(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	resp := handlers.BlameResponse{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Nil(err)
+	s.Len(resp.Sessions, 1)
+	s.Equal("1-1", resp.Sessions[0].SessionID)
+	s.Equal([]peer.ID{peerID}, resp.Sessions[0].Peers)
+	s.Len(resp.Peers, 1)
+	s.Equal(peerID, resp.Peers[0].Peer)
+	s.Equal(1, resp.Peers[0].Failures[tss.StallBlame])
+}
+
+func (s *AdminHandlerTestSuite) Test_HandlePeers() {
+	peerID, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	addr, _ := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/9000")
+	s.mockTopology.EXPECT().Topology().Return(&topology.NetworkTopology{
+		Peers: []*peer.AddrInfo{
+			{
+				ID:    peerID,
+				Addrs: []multiaddr.Multiaddr{addr},
+			},
+		},
+		Threshold: 1,
+	}, nil)
+	s.mockNetwork.EXPECT().Connectedness(peerID).Return(network.Connected)
+	req := httptest.NewRequest(http.MethodGet, "/v1/peers", nil)
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandlePeers(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	resp := handlers.PeersResponse{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Nil(err)
+	s.Equal(1, resp.Threshold)
+	s.Equal(&handlers.PeerResponse{
+		ID:            peerID.String(),
+		Addrs:         []string{"/ip4/127.0.0.1/tcp/9000"},
+		Connectedness: "Connected",
+	}, resp.Peers[0])
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleKeyshare_MissingKeyshare() {
+	s.mockKeyshares.EXPECT().GetKeyshare().Return(keyshare.ECDSAKeyshare{}, fmt.Errorf("error on reading keyshare file"))
+	req := httptest.NewRequest(http.MethodGet, "/v1/keyshare", nil)
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleKeyshare(recorder, req)
+
+	s.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleKeyshare_ValidKeyshare() {
+	k, err := keyshare.NewECDSAKeyshareStore("../../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	s.mockKeyshares.EXPECT().GetKeyshare().Return(k, nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/keyshare", nil)
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleKeyshare(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	resp := handlers.KeyshareResponse{}
+	err = json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Nil(err)
+	s.Equal(ethereumCrypto.PubkeyToAddress(*k.Key.ECDSAPub.ToBtcecPubKey().ToECDSA()).Hex(), resp.MpcAddress)
+	s.Equal(k.Threshold, resp.Threshold)
+	s.Len(resp.Peers, len(k.Peers))
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleSetLogLevel_InvalidLevel() {
+	body, _ := json.Marshal(handlers.LogLevelBody{Level: "invalid"})
+	req := httptest.NewRequest(http.MethodPut, "/v1/log-level", bytes.NewReader(body))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleSetLogLevel(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *AdminHandlerTestSuite) Test_HandleSetLogLevel_ValidLevel() {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	body, _ := json.Marshal(handlers.LogLevelBody{Level: "warn"})
+	req := httptest.NewRequest(http.MethodPut, "/v1/log-level", bytes.NewReader(body))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleSetLogLevel(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal(zerolog.WarnLevel, zerolog.GlobalLevel())
+}
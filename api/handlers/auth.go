@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+)
+
+const (
+	AUTH_TIMESTAMP_TOLERANCE = time.Minute * 5
+)
+
+type clientKey struct{}
+type callerKey struct{}
+
+// ClientID returns the authenticated client identity of the request
+// or an empty string if the request was not authenticated
+func ClientID(ctx context.Context) string {
+	client, _ := ctx.Value(clientKey{}).(string)
+	return client
+}
+
+// ClientCaller returns the solver address registered for the authenticated client
+// of the request or an empty string if the client has no registered address
+func ClientCaller(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+type Authenticator struct {
+	credentials map[string]relayer.ApiCredential
+	commonNames map[string]string
+	seen        *ttlcache.Cache[string, struct{}]
+}
+
+func NewAuthenticator(credentials map[string]relayer.ApiCredential) *Authenticator {
+	commonNames := make(map[string]string)
+	for client, credential := range credentials {
+		if credential.CommonName != "" {
+			commonNames[credential.CommonName] = client
+		}
+	}
+
+	// signatures are kept for the whole period their timestamp is accepted
+	seen := ttlcache.New(
+		ttlcache.WithTTL[string, struct{}](AUTH_TIMESTAMP_TOLERANCE*2),
+		ttlcache.WithDisableTouchOnHit[string, struct{}](),
+	)
+	go seen.Start()
+	return &Authenticator{
+		credentials: credentials,
+		commonNames: commonNames,
+		seen:        seen,
+	}
+}
+
+// Middleware authenticates requests signed by registered API clients and attaches
+// the client identity to the request context. Authentication is disabled if
+// no credentials are configured.
+//
+// Clients sign "<timestamp>\n<method>\n<path and query>\n<body>" either with HMAC-SHA256 using
+// their key or with an EIP-191 personal signature from their registered solver address. Each
+// signature is accepted only once, so identical requests need a different timestamp. Clients
+// with a verified TLS client certificate issued for their common name don't sign requests.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	if len(a.credentials) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, err := a.authenticate(w, r)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			JSONError(w, fmt.Errorf("invalid request body: %w", err), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			log.Debug().Msgf("Unauthorized request to %s: %s", r.URL.Path, err)
+			JSONError(w, fmt.Errorf("unauthorized: %s", err), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), clientKey{}, client)
+		ctx = context.WithValue(ctx, callerKey{}, a.credentials[client].Address)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authenticate returns the client that signed the request. Request bodies
+// are read up to MAX_BODY_SIZE before the signature is verified.
+func (a *Authenticator) authenticate(w http.ResponseWriter, r *http.Request) (string, error) {
+	client, ok := a.certificateClient(r)
+	if ok {
+		return client, nil
+	}
+
+	client = r.Header.Get(types.CLIENT_HEADER)
+	credential, ok := a.credentials[client]
+	if !ok {
+		return "", fmt.Errorf("unknown client '%s'", client)
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(types.TIMESTAMP_HEADER), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid timestamp")
+	}
+	if time.Since(time.Unix(timestamp, 0)).Abs() > AUTH_TIMESTAMP_TOLERANCE {
+		return "", fmt.Errorf("timestamp expired")
+	}
+
+	signature, err := hexutil.Decode(r.Header.Get(types.SIGNATURE_HEADER))
+	if err != nil {
+		return "", fmt.Errorf("invalid signature")
+	}
+
+	body, err := readBody(w, r)
+	if err != nil {
+		return "", err
+	}
+
+	payload := SigningPayload(timestamp, r.Method, r.URL.RequestURI(), body)
+	switch {
+	case len(signature) == sha256.Size && credential.HmacKey != "":
+		{
+			mac := hmac.New(sha256.New, []byte(credential.HmacKey))
+			mac.Write(payload)
+			if !hmac.Equal(mac.Sum(nil), signature) {
+				return "", fmt.Errorf("invalid signature")
+			}
+		}
+	case len(signature) == crypto.SignatureLength && credential.Address != "":
+		{
+			signer, err := recoverSigner(payload, signature)
+			if err != nil {
+				return "", err
+			}
+			if signer != common.HexToAddress(credential.Address) {
+				return "", fmt.Errorf("invalid signature")
+			}
+		}
+	default:
+		return "", fmt.Errorf("invalid signature")
+	}
+
+	_, replayed := a.seen.GetOrSet(fmt.Sprintf("%s-%d-%x", client, timestamp, signature), struct{}{})
+	if replayed {
+		return "", fmt.Errorf("replayed request")
+	}
+	return client, nil
+}
+
+// certificateClient returns the client registered for the common name
+// of the verified TLS client certificate
+func (a *Authenticator) certificateClient(r *http.Request) (string, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	client, ok := a.commonNames[r.TLS.VerifiedChains[0][0].Subject.CommonName]
+	return client, ok
+}
+
+// SigningPayload returns the payload clients need to sign to authenticate the request.
+// The request URI contains the path and the query of the request.
+func SigningPayload(timestamp int64, method string, requestURI string, body []byte) []byte {
+	return append([]byte(fmt.Sprintf("%d\n%s\n%s\n", timestamp, method, requestURI)), body...)
+}
+
+func recoverSigner(payload []byte, signature []byte) (common.Address, error) {
+	sig := bytes.Clone(signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash(payload), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature")
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,237 @@
+package handlers_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	authHmacKey = "secret"
+	authBody    = `{"depositId":"1"}`
+	authPath    = "/v1/chains/1/signatures"
+)
+
+type AuthenticatorTestSuite struct {
+	suite.Suite
+
+	handler  http.Handler
+	clientID string
+}
+
+func TestRunAuthenticatorTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthenticatorTestSuite))
+}
+
+func (s *AuthenticatorTestSuite) SetupTest() {
+	s.clientID = ""
+	s.handler = s.authenticatedHandler(map[string]relayer.ApiCredential{
+		"solver": {
+			HmacKey: authHmacKey,
+		},
+		"signer": {
+			Address: "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b",
+		},
+		"certified": {
+			CommonName: "certified-solver",
+		},
+	})
+}
+
+func (s *AuthenticatorTestSuite) authenticatedHandler(credentials map[string]relayer.ApiCredential) http.Handler {
+	authenticator := handlers.NewAuthenticator(credentials)
+	return authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.clientID = handlers.ClientID(r.Context())
+		w.WriteHeader(http.StatusAccepted)
+	}))
+}
+
+func (s *AuthenticatorTestSuite) request(client string, timestamp int64, signature []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, authPath, bytes.NewReader([]byte(authBody)))
+	req.Header.Set(types.CLIENT_HEADER, client)
+	req.Header.Set(types.TIMESTAMP_HEADER, fmt.Sprint(timestamp))
+	req.Header.Set(types.SIGNATURE_HEADER, hexutil.Encode(signature))
+	return req
+}
+
+func (s *AuthenticatorTestSuite) hmacSignature(key string, timestamp int64) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(handlers.SigningPayload(timestamp, http.MethodPost, authPath, []byte(authBody)))
+	return mac.Sum(nil)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_NoCredentials() {
+	handler := s.authenticatedHandler(map[string]relayer.ApiCredential{})
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, authPath, nil))
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal("", s.clientID)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_UnknownClient() {
+	timestamp := time.Now().Unix()
+	recorder := httptest.NewRecorder()
+
+	s.handler.ServeHTTP(recorder, s.request("invalid", timestamp, s.hmacSignature(authHmacKey, timestamp)))
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_InvalidHmacSignature() {
+	timestamp := time.Now().Unix()
+	recorder := httptest.NewRecorder()
+
+	s.handler.ServeHTTP(recorder, s.request("solver", timestamp, s.hmacSignature("invalid", timestamp)))
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_ExpiredTimestamp() {
+	timestamp := time.Now().Add(-time.Hour).Unix()
+	recorder := httptest.NewRecorder()
+
+	s.handler.ServeHTTP(recorder, s.request("solver", timestamp, s.hmacSignature(authHmacKey, timestamp)))
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_BodyTooLarge() {
+	timestamp := time.Now().Unix()
+	recorder := httptest.NewRecorder()
+	req := s.request("solver", timestamp, s.hmacSignature(authHmacKey, timestamp))
+	req.Body = io.NopCloser(bytes.NewReader(make([]byte, handlers.MAX_BODY_SIZE+1)))
+
+	s.handler.ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusRequestEntityTooLarge, recorder.Code)
+	s.Equal("", s.clientID)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_ValidHmacSignature() {
+	timestamp := time.Now().Unix()
+	recorder := httptest.NewRecorder()
+
+	s.handler.ServeHTTP(recorder, s.request("solver", timestamp, s.hmacSignature(authHmacKey, timestamp)))
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal("solver", s.clientID)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_ValidEIP191Signature() {
+	key, _ := crypto.HexToECDSA("e8d18d4a9bf1e5d23c7ea3d2ee7e8d8b4c2c0b9e0f5f1e2d3c4b5a6978695a4b")
+	handler := s.authenticatedHandler(map[string]relayer.ApiCredential{
+		"signer": {
+			Address: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		},
+	})
+	timestamp := time.Now().Unix()
+	signature, _ := crypto.Sign(
+		accounts.TextHash(handlers.SigningPayload(timestamp, http.MethodPost, authPath, []byte(authBody))),
+		key,
+	)
+	signature[crypto.RecoveryIDOffset] += 27
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, s.request("signer", timestamp, signature))
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal("signer", s.clientID)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_InvalidEIP191Signer() {
+	key, _ := crypto.GenerateKey()
+	timestamp := time.Now().Unix()
+	signature, _ := crypto.Sign(
+		accounts.TextHash(handlers.SigningPayload(timestamp, http.MethodPost, authPath, []byte(authBody))),
+		key,
+	)
+	recorder := httptest.NewRecorder()
+
+	s.handler.ServeHTTP(recorder, s.request("signer", timestamp, signature))
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_ClientCertificate() {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, authPath, bytes.NewReader([]byte(authBody)))
+	req.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{
+			{Subject: pkix.Name{CommonName: "certified-solver"}},
+		}},
+	}
+
+	s.handler.ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal("certified", s.clientID)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_UnknownClientCertificate() {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, authPath, bytes.NewReader([]byte(authBody)))
+	req.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{
+			{Subject: pkix.Name{CommonName: "unknown"}},
+		}},
+	}
+
+	s.handler.ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_ReplayedRequest() {
+	timestamp := time.Now().Unix()
+	signature := s.hmacSignature(authHmacKey, timestamp)
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, s.request("solver", timestamp, signature))
+	s.Equal(http.StatusAccepted, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, s.request("solver", timestamp, signature))
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *AuthenticatorTestSuite) Test_Middleware_QuerySigned() {
+	timestamp := time.Now().Unix()
+	mac := hmac.New(sha256.New, []byte(authHmacKey))
+	mac.Write(handlers.SigningPayload(timestamp, http.MethodGet, "/v1/signatures?caller=0x1", nil))
+	signature := mac.Sum(nil)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/signatures?caller=0x2", nil)
+	req.Header.Set(types.CLIENT_HEADER, "solver")
+	req.Header.Set(types.TIMESTAMP_HEADER, fmt.Sprint(timestamp))
+	req.Header.Set(types.SIGNATURE_HEADER, hexutil.Encode(signature))
+	s.handler.ServeHTTP(recorder, req)
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v1/signatures?caller=0x1", nil)
+	req.Header.Set(types.CLIENT_HEADER, "solver")
+	req.Header.Set(types.TIMESTAMP_HEADER, fmt.Sprint(timestamp))
+	req.Header.Set(types.SIGNATURE_HEADER, hexutil.Encode(signature))
+	s.handler.ServeHTTP(recorder, req)
+	s.Equal(http.StatusAccepted, recorder.Code)
+}
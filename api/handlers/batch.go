@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sprintertech/sprinter-signing/chains/batch"
+	batchMessage "github.com/sprintertech/sprinter-signing/chains/batch/message"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+const MAX_BATCH_SIZE = 50
+
+type BatchSigningBody struct {
+	Items []*SigningBody `json:"items"`
+}
+
+type BatchSigningResult struct {
+	ID        string               `json:"id"`
+	Error     string               `json:"error,omitempty"`
+	ErrorCode errcode.Code         `json:"errorCode,omitempty"`
+	Details   map[string]any       `json:"details,omitempty"`
+	Fields    []errcode.FieldError `json:"fields,omitempty"`
+}
+
+// fail sets the error of the batch request and its machine-readable code
+func (r *BatchSigningResult) fail(err error) {
+	r.Error = err.Error()
+	r.ErrorCode = errcode.CodeOf(err, errcode.Internal)
+	if e := errcode.As(err); e != nil {
+		r.Details = e.Details
+		r.Fields = e.Fields
+	}
+}
+
+type BatchSigningResponse struct {
+	Results []*BatchSigningResult `json:"results"`
+}
+
+type BatchSigningHandler struct {
+	msgChan   chan []*message.Message
+	chains    map[uint64]struct{}
+	callbacks CallbackRegistrar
+	requests  RequestDeduplicator
+}
+
+func NewBatchSigningHandler(
+	msgChan chan []*message.Message,
+	chains map[uint64]struct{},
+	callbacks CallbackRegistrar,
+	requests RequestDeduplicator,
+) *BatchSigningHandler {
+	return &BatchSigningHandler{
+		msgChan:   msgChan,
+		chains:    chains,
+		callbacks: callbacks,
+		requests:  requests,
+	}
+}
+
+// HandleBatchSigning validates each signing request of the batch on its own and
+// sends all valid requests to be signed in a single coordinated process. The response
+// contains the request ID or the validation error for each request in the order they
+// were sent. Signatures and verification errors are read from the status routes.
+func (h *BatchSigningHandler) HandleBatchSigning(w http.ResponseWriter, r *http.Request) {
+	b := &BatchSigningBody{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(b)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	if len(b.Items) == 0 {
+		JSONError(w, errcode.Fields([]errcode.FieldError{{Field: "items", Reason: "missing"}}), http.StatusBadRequest)
+		return
+	}
+	if len(b.Items) > MAX_BATCH_SIZE {
+		JSONError(w, errcode.New(errcode.InvalidRequest, "invalid request body: batch exceeds %d items", MAX_BATCH_SIZE).
+			WithDetail("maxItems", MAX_BATCH_SIZE), http.StatusBadRequest)
+		return
+	}
+
+	results := make([]*BatchSigningResult, len(b.Items))
+	items := make([]batchMessage.BatchItem, 0)
+	for i, item := range b.Items {
+		results[i] = &BatchSigningResult{}
+		if item == nil {
+			results[i].fail(errcode.New(errcode.InvalidRequest, "missing item"))
+			continue
+		}
+
+		results[i].ID = fmt.Sprintf("%d-%s", item.ChainId, item.DepositId)
+		item.ClientID = ClientID(r.Context())
+		batchItem, attach, err := h.batchItem(results[i].ID, item)
+		if err != nil {
+			results[i].fail(err)
+			continue
+		}
+
+		if item.CallbackURL != "" {
+			h.callbacks.Register(results[i].ID, item.CallbackURL)
+		}
+		if attach {
+			continue
+		}
+		items = append(items, batchItem)
+	}
+
+	if len(items) != 0 {
+		h.msgChan <- []*message.Message{
+			batchMessage.NewBatchMessage(0, batch.BATCH_DOMAIN_ID, &batchMessage.BatchData{
+				ErrChn:      make(chan []error, 1),
+				Items:       items,
+				Source:      0,
+				Destination: batch.BATCH_DOMAIN_ID,
+			}),
+		}
+	}
+
+	data, _ := json.Marshal(BatchSigningResponse{
+		Results: results,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write(data)
+}
+
+// batchItem converts the signing request into a batch item and returns true
+// if the request should attach to an existing request instead
+func (h *BatchSigningHandler) batchItem(id string, b *SigningBody) (batchMessage.BatchItem, bool, error) {
+	err := validateSigningBody(b, h.chains)
+	if err != nil {
+		return batchMessage.BatchItem{}, false, err
+	}
+	err = validateCallback(b, h.callbacks)
+	if err != nil {
+		return batchMessage.BatchItem{}, false, err
+	}
+
+	m, err := signingMessage(b, make(chan error, 1))
+	if err != nil {
+		return batchMessage.BatchItem{}, false, err
+	}
+
+	attach, err := h.requests.Attach(id, m.Data.(paramsHasher).ParamsHash())
+	if err != nil || attach {
+		return batchMessage.BatchItem{}, attach, err
+	}
+
+	data, err := json.Marshal(m.Data)
+	if err != nil {
+		return batchMessage.BatchItem{}, false, err
+	}
+
+	return batchMessage.BatchItem{
+		Type:        m.Type,
+		Destination: m.Destination,
+		Data:        data,
+	}, false, nil
+}
@@ -0,0 +1,156 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/chains/batch"
+	batchMessage "github.com/sprintertech/sprinter-signing/chains/batch/message"
+	across "github.com/sprintertech/sprinter-signing/chains/evm/message"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"go.uber.org/mock/gomock"
+)
+
+type BatchSigningHandlerTestSuite struct {
+	suite.Suite
+
+	mockCallbacks *mock_handlers.MockCallbackRegistrar
+
+	statuses *cache.StatusCache
+	msgChn   chan []*message.Message
+	handler  *handlers.BatchSigningHandler
+}
+
+func TestRunBatchSigningHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchSigningHandlerTestSuite))
+}
+
+func (s *BatchSigningHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+
+	chains := make(map[uint64]struct{})
+	chains[1] = struct{}{}
+
+	s.mockCallbacks = mock_handlers.NewMockCallbackRegistrar(ctrl)
+	s.statuses = cache.NewStatusCache()
+	s.msgChn = make(chan []*message.Message)
+	s.handler = handlers.NewBatchSigningHandler(s.msgChn, chains, s.mockCallbacks, s.statuses)
+}
+
+func (s *BatchSigningHandlerTestSuite) signingBody(depositID string) *handlers.SigningBody {
+	return &handlers.SigningBody{
+		ChainId:       1,
+		DepositId:     depositID,
+		Protocol:      "across",
+		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Calldata:      "0xbe5",
+		Nonce:         &handlers.BigInt{big.NewInt(1001)},
+		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
+		Deadline:      uint64(1000),
+	}
+}
+
+func (s *BatchSigningHandlerTestSuite) Test_HandleBatchSigning_EmptyBatch() {
+	body, _ := json.Marshal(handlers.BatchSigningBody{})
+	req := httptest.NewRequest(http.MethodPost, "/v1/signatures/batch", bytes.NewReader(body))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleBatchSigning(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *BatchSigningHandlerTestSuite) Test_HandleBatchSigning_BatchTooLarge() {
+	items := make([]*handlers.SigningBody, handlers.MAX_BATCH_SIZE+1)
+	for i := range items {
+		items[i] = s.signingBody(fmt.Sprint(i))
+	}
+	body, _ := json.Marshal(handlers.BatchSigningBody{Items: items})
+	req := httptest.NewRequest(http.MethodPost, "/v1/signatures/batch", bytes.NewReader(body))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleBatchSigning(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *BatchSigningHandlerTestSuite) Test_HandleBatchSigning_PartialFailure() {
+	invalidChain := s.signingBody("2")
+	invalidChain.ChainId = 2
+	body, _ := json.Marshal(handlers.BatchSigningBody{
+		Items: []*handlers.SigningBody{
+			s.signingBody("1"),
+			invalidChain,
+			s.signingBody("3"),
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/signatures/batch", bytes.NewReader(body))
+	recorder := httptest.NewRecorder()
+
+	msgChn := make(chan []*message.Message, 1)
+	go func() {
+		msgChn <- <-s.msgChn
+	}()
+
+	s.handler.HandleBatchSigning(recorder, req)
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	msg := <-msgChn
+	s.Equal(batch.BATCH_DOMAIN_ID, msg[0].Destination)
+	s.Len(msg[0].Data.(*batchMessage.BatchData).Items, 2)
+
+	response := handlers.BatchSigningResponse{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.Nil(err)
+	s.Len(response.Results, 3)
+	s.Equal(&handlers.BatchSigningResult{ID: "1-1"}, response.Results[0])
+	s.Equal("2-2", response.Results[1].ID)
+	s.Equal("chain '2' not supported", response.Results[1].Error)
+	s.Equal(errcode.ChainNotSupported, response.Results[1].ErrorCode)
+	s.Equal(&handlers.BatchSigningResult{ID: "1-3"}, response.Results[2])
+}
+
+func (s *BatchSigningHandlerTestSuite) Test_HandleBatchSigning_RetriesAttachOrConflict() {
+	attached := &across.AcrossData{
+		DepositId:     big.NewInt(1),
+		Nonce:         big.NewInt(1001),
+		BorrowAmount:  big.NewInt(1000),
+		LiquidityPool: common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		Caller:        common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		Deadline:      uint64(1000),
+	}
+	err := s.statuses.Receive("1-1", attached.Caller.Hex(), attached.ParamsHash())
+	s.Nil(err)
+	err = s.statuses.Receive("1-2", attached.Caller.Hex(), "other-hash")
+	s.Nil(err)
+	body, _ := json.Marshal(handlers.BatchSigningBody{
+		Items: []*handlers.SigningBody{
+			s.signingBody("1"),
+			s.signingBody("2"),
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/signatures/batch", bytes.NewReader(body))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleBatchSigning(recorder, req)
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	response := handlers.BatchSigningResponse{}
+	err = json.Unmarshal(recorder.Body.Bytes(), &response)
+	s.Nil(err)
+	s.Equal(&handlers.BatchSigningResult{ID: "1-1"}, response.Results[0])
+	s.Equal("1-2", response.Results[1].ID)
+	s.Contains(response.Results[1].Error, cache.ErrParamsConflict.Error())
+}
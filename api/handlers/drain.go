@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type Drainer interface {
+	Draining() bool
+}
+
+// DrainGuard rejects new signing requests while the relayer is draining so they
+// are not started on a relayer that is going offline. Read routes are not guarded
+// and stay available until the drain completes.
+type DrainGuard struct {
+	drainer Drainer
+}
+
+func NewDrainGuard(drainer Drainer) *DrainGuard {
+	return &DrainGuard{
+		drainer: drainer,
+	}
+}
+
+// Middleware rejects requests with status code 503 while the relayer is draining
+func (g *DrainGuard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.drainer.Draining() {
+			JSONError(w, fmt.Errorf("relayer is draining"), http.StatusServiceUnavailable)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type DrainGuardTestSuite struct {
+	suite.Suite
+
+	mockDrainer *mock_handlers.MockDrainer
+	handler     http.Handler
+	handled     int
+}
+
+func TestRunDrainGuardTestSuite(t *testing.T) {
+	suite.Run(t, new(DrainGuardTestSuite))
+}
+
+func (s *DrainGuardTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockDrainer = mock_handlers.NewMockDrainer(ctrl)
+	s.handled = 0
+	s.handler = handlers.NewDrainGuard(s.mockDrainer).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.handled++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+}
+
+func (s *DrainGuardTestSuite) Test_Middleware_Draining() {
+	s.mockDrainer.EXPECT().Draining().Return(true)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader([]byte(`{}`)))
+	s.handler.ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusServiceUnavailable, recorder.Code)
+	s.Contains(recorder.Body.String(), string(errcode.Unavailable))
+	s.Equal(0, s.handled)
+}
+
+func (s *DrainGuardTestSuite) Test_Middleware_NotDraining() {
+	s.mockDrainer.EXPECT().Draining().Return(false)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader([]byte(`{}`)))
+	s.handler.ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal(1, s.handled)
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/errcode"
+)
+
+const (
+	FIREHOSE_WRITE_TIMEOUT = time.Second * 10
+	FIREHOSE_PONG_TIMEOUT  = HEARTBEAT_INTERVAL * 2
+	// FIREHOSE_CLOSE_REPLAY_GAP is the close code sent if events after the requested
+	// sequence are no longer retained or the sequence is from before a relayer restart
+	FIREHOSE_CLOSE_REPLAY_GAP = 4000
+)
+
+type FinalStatusSubscriber interface {
+	SubscribeFinal(ctx context.Context, after uint64, statusChn chan types.RequestStatus) error
+}
+
+// FirehoseEvent is a signed or failed signing request sent to firehose subscribers
+type FirehoseEvent struct {
+	types.RequestStatus
+	ChainID   uint64 `json:"chainId"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type firehoseFilter struct {
+	chains  map[uint64]struct{}
+	callers map[common.Address]struct{}
+}
+
+func (f firehoseFilter) matches(chainID uint64, caller string) bool {
+	if len(f.chains) > 0 {
+		if _, ok := f.chains[chainID]; !ok {
+			return false
+		}
+	}
+	if len(f.callers) > 0 {
+		if !common.IsHexAddress(caller) {
+			return false
+		}
+		if _, ok := f.callers[common.HexToAddress(caller)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type FirehoseHandler struct {
+	sigCache SignatureCacher
+	statuses FinalStatusSubscriber
+	chains   map[uint64]struct{}
+	upgrader websocket.Upgrader
+}
+
+func NewFirehoseHandler(sigCache SignatureCacher, statuses FinalStatusSubscriber, chains map[uint64]struct{}) *FirehoseHandler {
+	return &FirehoseHandler{
+		sigCache: sigCache,
+		statuses: statuses,
+		chains:   chains,
+		// the default origin check rejects browser connections from other
+		// origins while clients that do not send an origin are accepted
+		upgrader: websocket.Upgrader{},
+	}
+}
+
+// HandleFirehose is a websocket handler that streams signatures and failures of all
+// signing requests matching the optional 'chainId' and 'caller' query filters.
+// Events are replayed from the sequence in the 'after' query parameter. Subscribers
+// that do not keep up are disconnected with a close message containing the
+// sequence to resume after. Sequences are kept in memory, so subscribers requesting
+// events that are no longer retained are disconnected with FIREHOSE_CLOSE_REPLAY_GAP
+// and have to resubscribe without the sequence. Authenticated clients only receive
+// events of the caller address registered for them.
+func (h *FirehoseHandler) HandleFirehose(w http.ResponseWriter, r *http.Request) {
+	filter, after, err := h.parseQuery(r)
+	if err != nil {
+		JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+	if ClientID(r.Context()) != "" {
+		bound := ClientCaller(r.Context())
+		if bound == "" {
+			JSONError(w, fmt.Errorf("no caller registered for client"), http.StatusForbidden)
+			return
+		}
+		for caller := range filter.callers {
+			if caller != common.HexToAddress(bound) {
+				JSONError(w, fmt.Errorf("caller '%s' not registered for client", caller.Hex()), http.StatusForbidden)
+				return
+			}
+		}
+		filter.callers = map[common.Address]struct{}{common.HexToAddress(bound): {}}
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Debug().Msgf("Failed upgrading firehose connection: %s", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go h.read(ctx, cancel, conn)
+
+	statusChn := make(chan types.RequestStatus)
+	errChn := make(chan error, 1)
+	go func() {
+		errChn <- h.statuses.SubscribeFinal(ctx, after, statusChn)
+	}()
+
+	heartbeat := time.NewTicker(HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			{
+				err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(FIREHOSE_WRITE_TIMEOUT))
+				if err != nil {
+					return
+				}
+			}
+		case status := <-statusChn:
+			{
+				after = status.Sequence
+				chainID, err := strconv.ParseUint(strings.SplitN(status.ID, "-", 2)[0], 10, 64)
+				if err != nil || !filter.matches(chainID, status.Caller) {
+					continue
+				}
+
+				event := FirehoseEvent{
+					RequestStatus: status,
+					ChainID:       chainID,
+				}
+				if status.State == types.SignedState {
+					sig, err := h.sigCache.Signature(status.ID)
+					if err == nil {
+						event.Signature = hex.EncodeToString(sig)
+					}
+				}
+
+				_ = conn.SetWriteDeadline(time.Now().Add(FIREHOSE_WRITE_TIMEOUT))
+				err = conn.WriteJSON(event)
+				if err != nil {
+					return
+				}
+			}
+		case err := <-errChn:
+			{
+				var msg []byte
+				switch {
+				case errors.Is(err, cache.ErrSlowSubscriber):
+					{
+						log.Debug().Msgf("Disconnecting slow firehose subscriber after %d", after)
+						msg = websocket.FormatCloseMessage(websocket.CloseTryAgainLater, fmt.Sprintf("slow subscriber, resume after %d", after))
+					}
+				case errors.Is(err, cache.ErrReplayGap):
+					{
+						log.Debug().Msgf("Disconnecting firehose subscriber with replay gap after %d", after)
+						msg = websocket.FormatCloseMessage(FIREHOSE_CLOSE_REPLAY_GAP, fmt.Sprintf("events after %d not retained, resubscribe without after", after))
+					}
+				default:
+					return
+				}
+				_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(FIREHOSE_WRITE_TIMEOUT))
+				return
+			}
+		}
+	}
+}
+
+// read processes control messages and cancels the stream once the connection is closed
+// or the subscriber stops responding to pings
+func (h *FirehoseHandler) read(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn) {
+	defer cancel()
+
+	_ = conn.SetReadDeadline(time.Now().Add(FIREHOSE_PONG_TIMEOUT))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(FIREHOSE_PONG_TIMEOUT))
+	})
+	for ctx.Err() == nil {
+		_, _, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (h *FirehoseHandler) parseQuery(r *http.Request) (firehoseFilter, uint64, error) {
+	query := r.URL.Query()
+	filter := firehoseFilter{
+		chains:  make(map[uint64]struct{}),
+		callers: make(map[common.Address]struct{}),
+	}
+
+	for _, c := range query["chainId"] {
+		chainID, err := strconv.ParseUint(c, 10, 64)
+		if err != nil {
+			return filter, 0, fmt.Errorf("chain id invalid")
+		}
+		if _, ok := h.chains[chainID]; !ok {
+			return filter, 0, errcode.New(errcode.ChainNotSupported, "chain '%d' not supported", chainID).
+				WithDetail("chainId", chainID)
+		}
+		filter.chains[chainID] = struct{}{}
+	}
+
+	for _, caller := range query["caller"] {
+		if !common.IsHexAddress(caller) {
+			return filter, 0, fmt.Errorf("caller '%s' invalid", caller)
+		}
+		filter.callers[common.HexToAddress(caller)] = struct{}{}
+	}
+
+	var after uint64
+	if query.Has("after") {
+		var err error
+		after, err = strconv.ParseUint(query.Get("after"), 10, 64)
+		if err != nil {
+			return filter, 0, fmt.Errorf("after invalid")
+		}
+	}
+
+	return filter, after, nil
+}
@@ -0,0 +1,213 @@
+package handlers_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type FirehoseHandlerTestSuite struct {
+	suite.Suite
+
+	mockSignatureCacher *mock_handlers.MockSignatureCacher
+	statuses            *cache.StatusCache
+	server              *httptest.Server
+}
+
+func TestRunFirehoseHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(FirehoseHandlerTestSuite))
+}
+
+func (s *FirehoseHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+
+	chains := make(map[uint64]struct{})
+	chains[1] = struct{}{}
+	chains[2] = struct{}{}
+
+	s.mockSignatureCacher = mock_handlers.NewMockSignatureCacher(ctrl)
+	s.statuses = cache.NewStatusCache()
+	handler := handlers.NewFirehoseHandler(s.mockSignatureCacher, s.statuses, chains)
+	s.server = httptest.NewServer(http.HandlerFunc(handler.HandleFirehose))
+}
+
+func (s *FirehoseHandlerTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *FirehoseHandlerTestSuite) dial(query string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http") + "?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	s.Nil(err)
+	return conn
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_UnsupportedChain() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/signatures/stream?chainId=3", nil)
+	recorder := httptest.NewRecorder()
+
+	handlers.NewFirehoseHandler(s.mockSignatureCacher, s.statuses, map[uint64]struct{}{1: {}}).HandleFirehose(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_InvalidCaller() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/signatures/stream?caller=invalid", nil)
+	recorder := httptest.NewRecorder()
+
+	handlers.NewFirehoseHandler(s.mockSignatureCacher, s.statuses, map[uint64]struct{}{1: {}}).HandleFirehose(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_CrossOriginRejected() {
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	header := http.Header{}
+	header.Set("Origin", "https://attacker.com")
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, header)
+
+	s.NotNil(err)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_SameOriginAccepted() {
+	url := "ws" + strings.TrimPrefix(s.server.URL, "http")
+	header := http.Header{}
+	header.Set("Origin", s.server.URL)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+
+	s.Nil(err)
+	conn.Close()
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_FiltersAndReplaysEvents() {
+	caller := "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"
+	_ = s.statuses.Receive("1-1", caller, "")
+	s.statuses.Update("1-1", cache.SignedState)
+	_ = s.statuses.Receive("1-2", "0x0000000000000000000000000000000000000001", "")
+	s.statuses.Fail("1-2", fmt.Errorf("invalid deposit"))
+	_ = s.statuses.Receive("2-3", caller, "")
+	s.statuses.Fail("2-3", fmt.Errorf("invalid deposit"))
+	s.mockSignatureCacher.EXPECT().Signature("1-1").Return([]byte{1, 2}, nil)
+
+	conn := s.dial("chainId=1&caller=" + strings.ToLower(caller))
+	defer conn.Close()
+
+	event := handlers.FirehoseEvent{}
+	err := conn.ReadJSON(&event)
+	s.Nil(err)
+	s.Equal("1-1", event.ID)
+	s.Equal(uint64(1), event.ChainID)
+	s.Equal(cache.SignedState, event.State)
+	s.Equal("0102", event.Signature)
+
+	_ = s.statuses.Receive("1-4", caller, "")
+	s.statuses.Fail("1-4", fmt.Errorf("invalid deposit"))
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	event = handlers.FirehoseEvent{}
+	err = conn.ReadJSON(&event)
+	s.Nil(err)
+	s.Equal("1-4", event.ID)
+	s.Equal(cache.FailedState, event.State)
+	s.Equal("invalid deposit", event.Reason)
+	s.Equal("", event.Signature)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_ResumesAfterSequence() {
+	s.statuses.Fail("1-1", fmt.Errorf("invalid deposit"))
+	s.statuses.Fail("1-2", fmt.Errorf("invalid deposit"))
+
+	conn := s.dial("after=1")
+	defer conn.Close()
+
+	event := handlers.FirehoseEvent{}
+	err := conn.ReadJSON(&event)
+	s.Nil(err)
+	s.Equal("1-2", event.ID)
+	s.Equal(uint64(2), event.Sequence)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_ReplayGapClosed() {
+	s.statuses.Fail("1-1", fmt.Errorf("invalid deposit"))
+
+	conn := s.dial("after=2")
+	defer conn.Close()
+
+	_, _, err := conn.ReadMessage()
+	closeErr := &websocket.CloseError{}
+	s.ErrorAs(err, &closeErr)
+	s.Equal(handlers.FIREHOSE_CLOSE_REPLAY_GAP, closeErr.Code)
+}
+
+func (s *FirehoseHandlerTestSuite) authenticatedDial(client string, query string) (*websocket.Conn, *http.Response, error) {
+	authenticator := handlers.NewAuthenticator(map[string]relayer.ApiCredential{
+		"solver": {
+			HmacKey: "secret",
+			Address: "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5",
+		},
+		"unbound": {
+			HmacKey: "secret",
+		},
+	})
+	handler := handlers.NewFirehoseHandler(s.mockSignatureCacher, s.statuses, map[uint64]struct{}{1: {}})
+	server := httptest.NewServer(authenticator.Middleware(http.HandlerFunc(handler.HandleFirehose)))
+	s.T().Cleanup(server.Close)
+
+	uri := "/?" + query
+	timestamp := time.Now().Unix()
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(handlers.SigningPayload(timestamp, http.MethodGet, uri, nil))
+	header := http.Header{}
+	header.Set(types.CLIENT_HEADER, client)
+	header.Set(types.TIMESTAMP_HEADER, fmt.Sprint(timestamp))
+	header.Set(types.SIGNATURE_HEADER, hexutil.Encode(mac.Sum(nil)))
+	return websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+uri, header)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_AuthenticatedRegisteredCallerOnly() {
+	_ = s.statuses.Receive("1-1", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "")
+	s.statuses.Fail("1-1", fmt.Errorf("invalid deposit"))
+	_ = s.statuses.Receive("1-2", "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5", "")
+	s.statuses.Fail("1-2", fmt.Errorf("invalid deposit"))
+
+	conn, _, err := s.authenticatedDial("solver", "")
+	s.Nil(err)
+	defer conn.Close()
+
+	event := handlers.FirehoseEvent{}
+	err = conn.ReadJSON(&event)
+	s.Nil(err)
+	s.Equal("1-2", event.ID)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_AuthenticatedOtherCaller() {
+	_, resp, err := s.authenticatedDial("solver", "caller=0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657")
+
+	s.NotNil(err)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+func (s *FirehoseHandlerTestSuite) Test_HandleFirehose_AuthenticatedWithoutRegisteredCaller() {
+	_, resp, err := s.authenticatedDial("unbound", "")
+
+	s.NotNil(err)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/errcode"
+)
+
+const (
+	HISTORY_DEFAULT_LIMIT = 100
+	HISTORY_MAX_LIMIT     = 1000
+)
+
+type SignatureQuerier interface {
+	Signatures(caller string, from time.Time, to time.Time, limit int) ([]cache.SignatureRecord, error)
+}
+
+// SignatureRecord is a persisted signature returned by the history endpoint
+type SignatureRecord struct {
+	ID         string    `json:"id"`
+	Caller     string    `json:"caller,omitempty"`
+	ParamsHash string    `json:"paramsHash,omitempty"`
+	Signature  string    `json:"signature"`
+	SignedAt   time.Time `json:"signedAt"`
+}
+
+type SignatureHistoryResponse struct {
+	Signatures []SignatureRecord `json:"signatures"`
+}
+
+type SignatureHistoryHandler struct {
+	signatures SignatureQuerier
+}
+
+func NewSignatureHistoryHandler(signatures SignatureQuerier) *SignatureHistoryHandler {
+	return &SignatureHistoryHandler{
+		signatures: signatures,
+	}
+}
+
+// HandleHistory returns persisted signatures ordered by signing time. Results can be
+// filtered by the 'caller' query parameter and the 'from' and 'to' RFC3339 timestamps,
+// and are limited to 'limit' signatures. Authenticated clients can only query signatures
+// of the caller address registered for them.
+func (h *SignatureHistoryHandler) HandleHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	fields := make([]errcode.FieldError, 0)
+	caller := query.Get("caller")
+	if caller != "" && !common.IsHexAddress(caller) {
+		fields = append(fields, errcode.FieldError{Field: "caller", Reason: "invalid"})
+	}
+	if ClientID(r.Context()) != "" {
+		bound := ClientCaller(r.Context())
+		switch {
+		case bound == "":
+			{
+				JSONError(w, fmt.Errorf("no caller registered for client"), http.StatusForbidden)
+				return
+			}
+		case caller == "":
+			caller = bound
+		case common.IsHexAddress(caller) && common.HexToAddress(caller) != common.HexToAddress(bound):
+			{
+				JSONError(w, fmt.Errorf("caller '%s' not registered for client", caller), http.StatusForbidden)
+				return
+			}
+		}
+	}
+	from, err := parseTime(query.Get("from"), time.Time{})
+	if err != nil {
+		fields = append(fields, errcode.FieldError{Field: "from", Reason: "invalid"})
+	}
+	to, err := parseTime(query.Get("to"), time.Now())
+	if err != nil {
+		fields = append(fields, errcode.FieldError{Field: "to", Reason: "invalid"})
+	}
+	if to.Before(from) {
+		fields = append(fields, errcode.FieldError{Field: "to", Reason: "before 'from'"})
+	}
+	limit := HISTORY_DEFAULT_LIMIT
+	if query.Get("limit") != "" {
+		limit, err = strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 || limit > HISTORY_MAX_LIMIT {
+			fields = append(fields, errcode.FieldError{Field: "limit", Reason: "invalid"})
+		}
+	}
+	if len(fields) > 0 {
+		JSONError(w, errcode.Fields(fields), http.StatusBadRequest)
+		return
+	}
+
+	records, err := h.signatures.Signatures(caller, from, to, limit)
+	if err != nil {
+		JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := SignatureHistoryResponse{
+		Signatures: make([]SignatureRecord, len(records)),
+	}
+	for i, record := range records {
+		resp.Signatures[i] = SignatureRecord{
+			ID:         record.ID,
+			Caller:     record.Caller,
+			ParamsHash: record.ParamsHash,
+			Signature:  hex.EncodeToString(record.Signature),
+			SignedAt:   record.SignedAt,
+		}
+	}
+	data, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func parseTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
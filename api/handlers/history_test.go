@@ -0,0 +1,175 @@
+package handlers_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type SignatureHistoryHandlerTestSuite struct {
+	suite.Suite
+
+	mockSignatureQuerier *mock_handlers.MockSignatureQuerier
+	handler              *handlers.SignatureHistoryHandler
+}
+
+func TestRunSignatureHistoryHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SignatureHistoryHandlerTestSuite))
+}
+
+func (s *SignatureHistoryHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+
+	s.mockSignatureQuerier = mock_handlers.NewMockSignatureQuerier(ctrl)
+	s.handler = handlers.NewSignatureHistoryHandler(s.mockSignatureQuerier)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) history(query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/signatures?"+query, nil)
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleHistory(recorder, req)
+	return recorder
+}
+
+func (s *SignatureHistoryHandlerTestSuite) authenticatedHistory(client string, query string) *httptest.ResponseRecorder {
+	authenticator := handlers.NewAuthenticator(map[string]relayer.ApiCredential{
+		"solver": {
+			HmacKey: "secret",
+			Address: "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5",
+		},
+		"unbound": {
+			HmacKey: "secret",
+		},
+	})
+
+	uri := "/v1/signatures?" + query
+	timestamp := time.Now().Unix()
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(handlers.SigningPayload(timestamp, http.MethodGet, uri, nil))
+	req := httptest.NewRequest(http.MethodGet, uri, nil)
+	req.Header.Set(types.CLIENT_HEADER, client)
+	req.Header.Set(types.TIMESTAMP_HEADER, fmt.Sprint(timestamp))
+	req.Header.Set(types.SIGNATURE_HEADER, hexutil.Encode(mac.Sum(nil)))
+	recorder := httptest.NewRecorder()
+
+	authenticator.Middleware(http.HandlerFunc(s.handler.HandleHistory)).ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_InvalidQuery() {
+	recorder := s.history("caller=invalid&from=yesterday&limit=5000")
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	resp := struct {
+		ErrorCode errcode.Code         `json:"errorCode"`
+		Fields    []errcode.FieldError `json:"fields"`
+	}{}
+	s.Nil(json.Unmarshal(recorder.Body.Bytes(), &resp))
+	s.Equal(errcode.InvalidRequest, resp.ErrorCode)
+	s.Equal([]errcode.FieldError{
+		{Field: "caller", Reason: "invalid"},
+		{Field: "from", Reason: "invalid"},
+		{Field: "limit", Reason: "invalid"},
+	}, resp.Fields)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_ToBeforeFrom() {
+	recorder := s.history("from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z")
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_StoreError() {
+	s.mockSignatureQuerier.EXPECT().Signatures("", time.Time{}, gomock.Any(), handlers.HISTORY_DEFAULT_LIMIT).Return(nil, fmt.Errorf("error"))
+
+	recorder := s.history("")
+
+	s.Equal(http.StatusInternalServerError, recorder.Code)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_ValidQuery() {
+	caller := "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5"
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	signedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.mockSignatureQuerier.EXPECT().Signatures(caller, from, to, 10).Return([]cache.SignatureRecord{
+		{
+			ID:         "1-1",
+			Caller:     caller,
+			ParamsHash: "hash",
+			Signature:  []byte{1, 2},
+			SignedAt:   signedAt,
+		},
+	}, nil)
+
+	recorder := s.history(fmt.Sprintf("caller=%s&from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:00Z&limit=10", caller))
+
+	s.Equal(http.StatusOK, recorder.Code)
+	resp := handlers.SignatureHistoryResponse{}
+	s.Nil(json.Unmarshal(recorder.Body.Bytes(), &resp))
+	s.Equal(handlers.SignatureHistoryResponse{
+		Signatures: []handlers.SignatureRecord{
+			{
+				ID:         "1-1",
+				Caller:     caller,
+				ParamsHash: "hash",
+				Signature:  "0102",
+				SignedAt:   signedAt,
+			},
+		},
+	}, resp)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_AuthenticatedDefaultsToRegisteredCaller() {
+	s.mockSignatureQuerier.EXPECT().Signatures(
+		"0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5",
+		time.Time{},
+		gomock.Any(),
+		handlers.HISTORY_DEFAULT_LIMIT,
+	).Return([]cache.SignatureRecord{}, nil)
+
+	recorder := s.authenticatedHistory("solver", "")
+
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_AuthenticatedRegisteredCaller() {
+	s.mockSignatureQuerier.EXPECT().Signatures(
+		"0x5c7bcd6e7de5423a257d81b442095a1a6ced35c5",
+		time.Time{},
+		gomock.Any(),
+		handlers.HISTORY_DEFAULT_LIMIT,
+	).Return([]cache.SignatureRecord{}, nil)
+
+	recorder := s.authenticatedHistory("solver", "caller=0x5c7bcd6e7de5423a257d81b442095a1a6ced35c5")
+
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_AuthenticatedOtherCaller() {
+	recorder := s.authenticatedHistory("solver", "caller=0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657")
+
+	s.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (s *SignatureHistoryHandlerTestSuite) Test_HandleHistory_AuthenticatedWithoutRegisteredCaller() {
+	recorder := s.authenticatedHistory("unbound", "")
+
+	s.Equal(http.StatusForbidden, recorder.Code)
+}
@@ -0,0 +1,241 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/handlers/admin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/handlers/admin.go -destination=./api/handlers/mock/admin.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	reflect "reflect"
+
+	network "github.com/libp2p/go-libp2p/core/network"
+	peer "github.com/libp2p/go-libp2p/core/peer"
+	keyshare "github.com/sprintertech/sprinter-signing/keyshare"
+	topology "github.com/sprintertech/sprinter-signing/topology"
+	tss "github.com/sprintertech/sprinter-signing/tss"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionManager is a mock of SessionManager interface.
+type MockSessionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionManagerMockRecorder is the mock recorder for MockSessionManager.
+type MockSessionManagerMockRecorder struct {
+	mock *MockSessionManager
+}
+
+// NewMockSessionManager creates a new mock instance.
+func NewMockSessionManager(ctrl *gomock.Controller) *MockSessionManager {
+	mock := &MockSessionManager{ctrl: ctrl}
+	mock.recorder = &MockSessionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionManager) EXPECT() *MockSessionManagerMockRecorder {
+	return m.recorder
+}
+
+// CancelProcess mocks base method.
+func (m *MockSessionManager) CancelProcess(sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelProcess", sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelProcess indicates an expected call of CancelProcess.
+func (mr *MockSessionManagerMockRecorder) CancelProcess(sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelProcess", reflect.TypeOf((*MockSessionManager)(nil).CancelProcess), sessionID)
+}
+
+// PendingProcesses mocks base method.
+func (m *MockSessionManager) PendingProcesses() []tss.PendingProcess {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingProcesses")
+	ret0, _ := ret[0].([]tss.PendingProcess)
+	return ret0
+}
+
+// PendingProcesses indicates an expected call of PendingProcesses.
+func (mr *MockSessionManagerMockRecorder) PendingProcesses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingProcesses", reflect.TypeOf((*MockSessionManager)(nil).PendingProcesses))
+}
+
+// MockBlameFetcher is a mock of BlameFetcher interface.
+type MockBlameFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlameFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockBlameFetcherMockRecorder is the mock recorder for MockBlameFetcher.
+type MockBlameFetcherMockRecorder struct {
+	mock *MockBlameFetcher
+}
+
+// NewMockBlameFetcher creates a new mock instance.
+func NewMockBlameFetcher(ctrl *gomock.Controller) *MockBlameFetcher {
+	mock := &MockBlameFetcher{ctrl: ctrl}
+	mock.recorder = &MockBlameFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlameFetcher) EXPECT() *MockBlameFetcherMockRecorder {
+	return m.recorder
+}
+
+// PeerBlames mocks base method.
+func (m *MockBlameFetcher) PeerBlames() []tss.PeerBlame {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerBlames")
+	ret0, _ := ret[0].([]tss.PeerBlame)
+	return ret0
+}
+
+// PeerBlames indicates an expected call of PeerBlames.
+func (mr *MockBlameFetcherMockRecorder) PeerBlames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerBlames", reflect.TypeOf((*MockBlameFetcher)(nil).PeerBlames))
+}
+
+// SessionBlames mocks base method.
+func (m *MockBlameFetcher) SessionBlames() []tss.SessionBlame {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SessionBlames")
+	ret0, _ := ret[0].([]tss.SessionBlame)
+	return ret0
+}
+
+// SessionBlames indicates an expected call of SessionBlames.
+func (mr *MockBlameFetcherMockRecorder) SessionBlames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionBlames", reflect.TypeOf((*MockBlameFetcher)(nil).SessionBlames))
+}
+
+// MockTopologyFetcher is a mock of TopologyFetcher interface.
+type MockTopologyFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopologyFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockTopologyFetcherMockRecorder is the mock recorder for MockTopologyFetcher.
+type MockTopologyFetcherMockRecorder struct {
+	mock *MockTopologyFetcher
+}
+
+// NewMockTopologyFetcher creates a new mock instance.
+func NewMockTopologyFetcher(ctrl *gomock.Controller) *MockTopologyFetcher {
+	mock := &MockTopologyFetcher{ctrl: ctrl}
+	mock.recorder = &MockTopologyFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopologyFetcher) EXPECT() *MockTopologyFetcherMockRecorder {
+	return m.recorder
+}
+
+// Topology mocks base method.
+func (m *MockTopologyFetcher) Topology() (*topology.NetworkTopology, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Topology")
+	ret0, _ := ret[0].(*topology.NetworkTopology)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Topology indicates an expected call of Topology.
+func (mr *MockTopologyFetcherMockRecorder) Topology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Topology", reflect.TypeOf((*MockTopologyFetcher)(nil).Topology))
+}
+
+// MockKeyshareFetcher is a mock of KeyshareFetcher interface.
+type MockKeyshareFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyshareFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyshareFetcherMockRecorder is the mock recorder for MockKeyshareFetcher.
+type MockKeyshareFetcherMockRecorder struct {
+	mock *MockKeyshareFetcher
+}
+
+// NewMockKeyshareFetcher creates a new mock instance.
+func NewMockKeyshareFetcher(ctrl *gomock.Controller) *MockKeyshareFetcher {
+	mock := &MockKeyshareFetcher{ctrl: ctrl}
+	mock.recorder = &MockKeyshareFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyshareFetcher) EXPECT() *MockKeyshareFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetKeyshare mocks base method.
+func (m *MockKeyshareFetcher) GetKeyshare() (keyshare.ECDSAKeyshare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyshare")
+	ret0, _ := ret[0].(keyshare.ECDSAKeyshare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyshare indicates an expected call of GetKeyshare.
+func (mr *MockKeyshareFetcherMockRecorder) GetKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockKeyshareFetcher)(nil).GetKeyshare))
+}
+
+// MockPeerNetwork is a mock of PeerNetwork interface.
+type MockPeerNetwork struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeerNetworkMockRecorder
+	isgomock struct{}
+}
+
+// MockPeerNetworkMockRecorder is the mock recorder for MockPeerNetwork.
+type MockPeerNetworkMockRecorder struct {
+	mock *MockPeerNetwork
+}
+
+// NewMockPeerNetwork creates a new mock instance.
+func NewMockPeerNetwork(ctrl *gomock.Controller) *MockPeerNetwork {
+	mock := &MockPeerNetwork{ctrl: ctrl}
+	mock.recorder = &MockPeerNetworkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeerNetwork) EXPECT() *MockPeerNetworkMockRecorder {
+	return m.recorder
+}
+
+// Connectedness mocks base method.
+func (m *MockPeerNetwork) Connectedness(arg0 peer.ID) network.Connectedness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connectedness", arg0)
+	ret0, _ := ret[0].(network.Connectedness)
+	return ret0
+}
+
+// Connectedness indicates an expected call of Connectedness.
+func (mr *MockPeerNetworkMockRecorder) Connectedness(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connectedness", reflect.TypeOf((*MockPeerNetwork)(nil).Connectedness), arg0)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/handlers/drain.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/handlers/drain.go -destination=./api/handlers/mock/drain.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDrainer is a mock of Drainer interface.
+type MockDrainer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDrainerMockRecorder
+	isgomock struct{}
+}
+
+// MockDrainerMockRecorder is the mock recorder for MockDrainer.
+type MockDrainerMockRecorder struct {
+	mock *MockDrainer
+}
+
+// NewMockDrainer creates a new mock instance.
+func NewMockDrainer(ctrl *gomock.Controller) *MockDrainer {
+	mock := &MockDrainer{ctrl: ctrl}
+	mock.recorder = &MockDrainerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDrainer) EXPECT() *MockDrainerMockRecorder {
+	return m.recorder
+}
+
+// Draining mocks base method.
+func (m *MockDrainer) Draining() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Draining")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Draining indicates an expected call of Draining.
+func (mr *MockDrainerMockRecorder) Draining() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Draining", reflect.TypeOf((*MockDrainer)(nil).Draining))
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/handlers/firehose.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/handlers/firehose.go -destination=./api/handlers/mock/firehose.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/sprintertech/sprinter-signing/api/types"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFinalStatusSubscriber is a mock of FinalStatusSubscriber interface.
+type MockFinalStatusSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockFinalStatusSubscriberMockRecorder
+	isgomock struct{}
+}
+
+// MockFinalStatusSubscriberMockRecorder is the mock recorder for MockFinalStatusSubscriber.
+type MockFinalStatusSubscriberMockRecorder struct {
+	mock *MockFinalStatusSubscriber
+}
+
+// NewMockFinalStatusSubscriber creates a new mock instance.
+func NewMockFinalStatusSubscriber(ctrl *gomock.Controller) *MockFinalStatusSubscriber {
+	mock := &MockFinalStatusSubscriber{ctrl: ctrl}
+	mock.recorder = &MockFinalStatusSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFinalStatusSubscriber) EXPECT() *MockFinalStatusSubscriberMockRecorder {
+	return m.recorder
+}
+
+// SubscribeFinal mocks base method.
+func (m *MockFinalStatusSubscriber) SubscribeFinal(ctx context.Context, after uint64, statusChn chan types.RequestStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeFinal", ctx, after, statusChn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeFinal indicates an expected call of SubscribeFinal.
+func (mr *MockFinalStatusSubscriberMockRecorder) SubscribeFinal(ctx, after, statusChn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeFinal", reflect.TypeOf((*MockFinalStatusSubscriber)(nil).SubscribeFinal), ctx, after, statusChn)
+}
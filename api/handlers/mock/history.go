@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api/handlers/history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api/handlers/history.go -destination=api/handlers/mock/history.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	reflect "reflect"
+	time "time"
+
+	cache "github.com/sprintertech/sprinter-signing/cache"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSignatureQuerier is a mock of SignatureQuerier interface.
+type MockSignatureQuerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignatureQuerierMockRecorder
+	isgomock struct{}
+}
+
+// MockSignatureQuerierMockRecorder is the mock recorder for MockSignatureQuerier.
+type MockSignatureQuerierMockRecorder struct {
+	mock *MockSignatureQuerier
+}
+
+// NewMockSignatureQuerier creates a new mock instance.
+func NewMockSignatureQuerier(ctrl *gomock.Controller) *MockSignatureQuerier {
+	mock := &MockSignatureQuerier{ctrl: ctrl}
+	mock.recorder = &MockSignatureQuerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignatureQuerier) EXPECT() *MockSignatureQuerierMockRecorder {
+	return m.recorder
+}
+
+// Signatures mocks base method.
+func (m *MockSignatureQuerier) Signatures(caller string, from, to time.Time, limit int) ([]cache.SignatureRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Signatures", caller, from, to, limit)
+	ret0, _ := ret[0].([]cache.SignatureRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Signatures indicates an expected call of Signatures.
+func (mr *MockSignatureQuerierMockRecorder) Signatures(caller, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signatures", reflect.TypeOf((*MockSignatureQuerier)(nil).Signatures), caller, from, to, limit)
+}
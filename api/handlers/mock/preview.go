@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/handlers/preview.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/handlers/preview.go -destination=./api/handlers/mock/preview.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	reflect "reflect"
+
+	signature "github.com/sprintertech/sprinter-signing/chains/evm/signature"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreviewer is a mock of Previewer interface.
+type MockPreviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewerMockRecorder
+	isgomock struct{}
+}
+
+// MockPreviewerMockRecorder is the mock recorder for MockPreviewer.
+type MockPreviewerMockRecorder struct {
+	mock *MockPreviewer
+}
+
+// NewMockPreviewer creates a new mock instance.
+func NewMockPreviewer(ctrl *gomock.Controller) *MockPreviewer {
+	mock := &MockPreviewer{ctrl: ctrl}
+	mock.recorder = &MockPreviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreviewer) EXPECT() *MockPreviewerMockRecorder {
+	return m.recorder
+}
+
+// Preview mocks base method.
+func (m *MockPreviewer) Preview(payload []byte) (*signature.Preview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", payload)
+	ret0, _ := ret[0].(*signature.Preview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockPreviewerMockRecorder) Preview(payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockPreviewer)(nil).Preview), payload)
+}
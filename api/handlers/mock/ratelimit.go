@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/handlers/ratelimit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/handlers/ratelimit.go -destination=./api/handlers/mock/ratelimit.go
+//
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRateLimitMetrics is a mock of RateLimitMetrics interface.
+type MockRateLimitMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockRateLimitMetricsMockRecorder is the mock recorder for MockRateLimitMetrics.
+type MockRateLimitMetricsMockRecorder struct {
+	mock *MockRateLimitMetrics
+}
+
+// NewMockRateLimitMetrics creates a new mock instance.
+func NewMockRateLimitMetrics(ctrl *gomock.Controller) *MockRateLimitMetrics {
+	mock := &MockRateLimitMetrics{ctrl: ctrl}
+	mock.recorder = &MockRateLimitMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitMetrics) EXPECT() *MockRateLimitMetricsMockRecorder {
+	return m.recorder
+}
+
+// TrackInFlightRequests mocks base method.
+func (m *MockRateLimitMetrics) TrackInFlightRequests(inFlight, maxInFlight int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackInFlightRequests", inFlight, maxInFlight)
+}
+
+// TrackInFlightRequests indicates an expected call of TrackInFlightRequests.
+func (mr *MockRateLimitMetricsMockRecorder) TrackInFlightRequests(inFlight, maxInFlight any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackInFlightRequests", reflect.TypeOf((*MockRateLimitMetrics)(nil).TrackInFlightRequests), inFlight, maxInFlight)
+}
+
+// TrackRateLimited mocks base method.
+func (m *MockRateLimitMetrics) TrackRateLimited(limit string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackRateLimited", limit)
+}
+
+// TrackRateLimited indicates an expected call of TrackRateLimited.
+func (mr *MockRateLimitMetricsMockRecorder) TrackRateLimited(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackRateLimited", reflect.TypeOf((*MockRateLimitMetrics)(nil).TrackRateLimited), limit)
+}
+
+// MockPendingCounter is a mock of PendingCounter interface.
+type MockPendingCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingCounterMockRecorder
+	isgomock struct{}
+}
+
+// MockPendingCounterMockRecorder is the mock recorder for MockPendingCounter.
+type MockPendingCounterMockRecorder struct {
+	mock *MockPendingCounter
+}
+
+// NewMockPendingCounter creates a new mock instance.
+func NewMockPendingCounter(ctrl *gomock.Controller) *MockPendingCounter {
+	mock := &MockPendingCounter{ctrl: ctrl}
+	mock.recorder = &MockPendingCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingCounter) EXPECT() *MockPendingCounterMockRecorder {
+	return m.recorder
+}
+
+// Pending mocks base method.
+func (m *MockPendingCounter) Pending() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockPendingCounterMockRecorder) Pending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockPendingCounter)(nil).Pending))
+}
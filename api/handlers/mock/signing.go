@@ -13,9 +13,137 @@ import (
 	context "context"
 	reflect "reflect"
 
+	types "github.com/sprintertech/sprinter-signing/api/types"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockCallbackRegistrar is a mock of CallbackRegistrar interface.
+type MockCallbackRegistrar struct {
+	ctrl     *gomock.Controller
+	recorder *MockCallbackRegistrarMockRecorder
+	isgomock struct{}
+}
+
+// MockCallbackRegistrarMockRecorder is the mock recorder for MockCallbackRegistrar.
+type MockCallbackRegistrarMockRecorder struct {
+	mock *MockCallbackRegistrar
+}
+
+// NewMockCallbackRegistrar creates a new mock instance.
+func NewMockCallbackRegistrar(ctrl *gomock.Controller) *MockCallbackRegistrar {
+	mock := &MockCallbackRegistrar{ctrl: ctrl}
+	mock.recorder = &MockCallbackRegistrarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCallbackRegistrar) EXPECT() *MockCallbackRegistrarMockRecorder {
+	return m.recorder
+}
+
+// Register mocks base method.
+func (m *MockCallbackRegistrar) Register(id, url string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", id, url)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockCallbackRegistrarMockRecorder) Register(id, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockCallbackRegistrar)(nil).Register), id, url)
+}
+
+// Validate mocks base method.
+func (m *MockCallbackRegistrar) Validate(url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockCallbackRegistrarMockRecorder) Validate(url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockCallbackRegistrar)(nil).Validate), url)
+}
+
+// MockRequestDeduplicator is a mock of RequestDeduplicator interface.
+type MockRequestDeduplicator struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestDeduplicatorMockRecorder
+	isgomock struct{}
+}
+
+// MockRequestDeduplicatorMockRecorder is the mock recorder for MockRequestDeduplicator.
+type MockRequestDeduplicatorMockRecorder struct {
+	mock *MockRequestDeduplicator
+}
+
+// NewMockRequestDeduplicator creates a new mock instance.
+func NewMockRequestDeduplicator(ctrl *gomock.Controller) *MockRequestDeduplicator {
+	mock := &MockRequestDeduplicator{ctrl: ctrl}
+	mock.recorder = &MockRequestDeduplicatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestDeduplicator) EXPECT() *MockRequestDeduplicatorMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockRequestDeduplicator) Attach(id, paramsHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", id, paramsHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockRequestDeduplicatorMockRecorder) Attach(id, paramsHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockRequestDeduplicator)(nil).Attach), id, paramsHash)
+}
+
+// MockparamsHasher is a mock of paramsHasher interface.
+type MockparamsHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockparamsHasherMockRecorder
+	isgomock struct{}
+}
+
+// MockparamsHasherMockRecorder is the mock recorder for MockparamsHasher.
+type MockparamsHasherMockRecorder struct {
+	mock *MockparamsHasher
+}
+
+// NewMockparamsHasher creates a new mock instance.
+func NewMockparamsHasher(ctrl *gomock.Controller) *MockparamsHasher {
+	mock := &MockparamsHasher{ctrl: ctrl}
+	mock.recorder = &MockparamsHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockparamsHasher) EXPECT() *MockparamsHasherMockRecorder {
+	return m.recorder
+}
+
+// ParamsHash mocks base method.
+func (m *MockparamsHasher) ParamsHash() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParamsHash")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ParamsHash indicates an expected call of ParamsHash.
+func (mr *MockparamsHasherMockRecorder) ParamsHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParamsHash", reflect.TypeOf((*MockparamsHasher)(nil).ParamsHash))
+}
+
 // MockSignatureCacher is a mock of SignatureCacher interface.
 type MockSignatureCacher struct {
 	ctrl     *gomock.Controller
@@ -40,6 +168,33 @@ func (m *MockSignatureCacher) EXPECT() *MockSignatureCacherMockRecorder {
 	return m.recorder
 }
 
+// Fetch mocks base method.
+func (m *MockSignatureCacher) Fetch(id string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fetch", id)
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockSignatureCacherMockRecorder) Fetch(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockSignatureCacher)(nil).Fetch), id)
+}
+
+// Signature mocks base method.
+func (m *MockSignatureCacher) Signature(id string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Signature", id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Signature indicates an expected call of Signature.
+func (mr *MockSignatureCacherMockRecorder) Signature(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signature", reflect.TypeOf((*MockSignatureCacher)(nil).Signature), id)
+}
+
 // Subscribe mocks base method.
 func (m *MockSignatureCacher) Subscribe(ctx context.Context, id string, sigChannel chan []byte) {
 	m.ctrl.T.Helper()
@@ -51,3 +206,56 @@ func (mr *MockSignatureCacherMockRecorder) Subscribe(ctx, id, sigChannel any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSignatureCacher)(nil).Subscribe), ctx, id, sigChannel)
 }
+
+// MockStatusCacher is a mock of StatusCacher interface.
+type MockStatusCacher struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusCacherMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusCacherMockRecorder is the mock recorder for MockStatusCacher.
+type MockStatusCacherMockRecorder struct {
+	mock *MockStatusCacher
+}
+
+// NewMockStatusCacher creates a new mock instance.
+func NewMockStatusCacher(ctrl *gomock.Controller) *MockStatusCacher {
+	mock := &MockStatusCacher{ctrl: ctrl}
+	mock.recorder = &MockStatusCacherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusCacher) EXPECT() *MockStatusCacherMockRecorder {
+	return m.recorder
+}
+
+// Status mocks base method.
+func (m *MockStatusCacher) Status(id string) (types.RequestStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", id)
+	ret0, _ := ret[0].(types.RequestStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockStatusCacherMockRecorder) Status(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockStatusCacher)(nil).Status), id)
+}
+
+// Subscribe mocks base method.
+func (m *MockStatusCacher) Subscribe(ctx context.Context, id string, after uint64, statusChn chan types.RequestStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, id, after, statusChn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockStatusCacherMockRecorder) Subscribe(ctx, id, after, statusChn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStatusCacher)(nil).Subscribe), ctx, id, after, statusChn)
+}
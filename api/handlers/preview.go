@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+// Previewer verifies the JSON encoded message data of a single signing request
+// and returns the data that would be signed for it without starting the signing process.
+type Previewer interface {
+	Preview(payload []byte) (*signature.Preview, error)
+}
+
+type PreviewResponse struct {
+	ID string `json:"id"`
+	*signature.Preview
+}
+
+type PreviewHandler struct {
+	chains     map[uint64]struct{}
+	previewers map[string]Previewer
+}
+
+func NewPreviewHandler(chains map[uint64]struct{}) *PreviewHandler {
+	return &PreviewHandler{
+		chains:     chains,
+		previewers: make(map[string]Previewer),
+	}
+}
+
+// RegisterPreviewer registers the previewer used to verify signing requests
+// of the message type for the given chain
+func (h *PreviewHandler) RegisterPreviewer(chainID uint64, msgType message.MessageType, previewer Previewer) {
+	h.previewers[previewerKey(chainID, msgType)] = previewer
+}
+
+// HandlePreview runs the protocol verification of the signing request without signing it
+// and returns the calldata, unlock hash and typed data the committee would sign
+// along with the current and required deposit confirmations.
+func (h *PreviewHandler) HandlePreview(w http.ResponseWriter, r *http.Request) {
+	b := &SigningBody{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(b)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid request body: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	chainId, ok := new(big.Int).SetString(mux.Vars(r)["chainId"], 10)
+	if !ok {
+		JSONError(w, fmt.Errorf("invalid request body: field 'chainId' invalid"), http.StatusBadRequest)
+		return
+	}
+	b.ChainId = chainId.Uint64()
+	err = validateSigningBody(b, h.chains)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	b.ClientID = ClientID(r.Context())
+	m, err := signingMessage(b, make(chan error, 1))
+	if err != nil {
+		JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	previewer, ok := h.previewers[previewerKey(b.ChainId, m.Type)]
+	if !ok {
+		JSONError(w, errcode.New(errcode.ProtocolNotSupported, "protocol %s not supported on chain %d", b.Protocol, b.ChainId).
+			WithDetail("chainId", b.ChainId).
+			WithDetail("protocol", b.Protocol), http.StatusBadRequest)
+		return
+	}
+
+	payload, err := json.Marshal(m.Data)
+	if err != nil {
+		JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	preview, err := previewer.Preview(payload)
+	if err != nil {
+		JSONError(w, fmt.Errorf("verification failed: %w", err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	data, _ := json.Marshal(PreviewResponse{
+		ID:      fmt.Sprintf("%d-%s", b.ChainId, b.DepositId),
+		Preview: preview,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func previewerKey(chainID uint64, msgType message.MessageType) string {
+	return fmt.Sprintf("%d-%s", chainID, msgType)
+}
@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	evmMessage "github.com/sprintertech/sprinter-signing/chains/evm/message"
+	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
+	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"go.uber.org/mock/gomock"
+)
+
+type PreviewHandlerTestSuite struct {
+	suite.Suite
+
+	mockPreviewer *mock_handlers.MockPreviewer
+
+	handler *handlers.PreviewHandler
+}
+
+func TestRunPreviewHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PreviewHandlerTestSuite))
+}
+
+func (s *PreviewHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+
+	chains := make(map[uint64]struct{})
+	chains[1] = struct{}{}
+
+	s.mockPreviewer = mock_handlers.NewMockPreviewer(ctrl)
+	s.handler = handlers.NewPreviewHandler(chains)
+	s.handler.RegisterPreviewer(1, message.MessageType(comm.AcrossMsg.String()), s.mockPreviewer)
+}
+
+func (s *PreviewHandlerTestSuite) request(protocol handlers.ProtocolType) *http.Request {
+	input := handlers.SigningBody{
+		DepositId:     "1000",
+		Protocol:      protocol,
+		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Calldata:      "0xbe5",
+		Nonce:         &handlers.BigInt{big.NewInt(1001)},
+		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
+		Deadline:      uint64(1000),
+	}
+	body, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures/preview", bytes.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{
+		"chainId": "1",
+	})
+}
+
+func (s *PreviewHandlerTestSuite) Test_HandlePreview_UnsupportedProtocol() {
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandlePreview(recorder, s.request(handlers.LifiEscrowProtocol))
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *PreviewHandlerTestSuite) Test_HandlePreview_VerificationFailed() {
+	s.mockPreviewer.EXPECT().Preview(gomock.Any()).Return(nil, fmt.Errorf("deposit not found"))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandlePreview(recorder, s.request(handlers.AcrossProtocol))
+
+	s.Equal(http.StatusUnprocessableEntity, recorder.Code)
+}
+
+func (s *PreviewHandlerTestSuite) Test_HandlePreview_ValidRequest() {
+	typedData := signature.BorrowTypedData(
+		[]byte{0xbe, 0x05},
+		big.NewInt(1000),
+		common.HexToAddress("0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"),
+		big.NewInt(8453),
+		common.HexToAddress("0x5c7BCd6E7De5423a257D81B442095A1a6ced35C5"),
+		1000,
+		common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		big.NewInt(1001),
+	)
+	unlockHash, err := signature.UnlockHash(typedData)
+	s.Nil(err)
+	s.mockPreviewer.EXPECT().Preview(gomock.Any()).DoAndReturn(func(payload []byte) (*signature.Preview, error) {
+		data := &evmMessage.AcrossData{}
+		err := json.Unmarshal(payload, data)
+		s.Nil(err)
+		s.Equal(big.NewInt(1000), data.DepositId)
+
+		return &signature.Preview{
+			Calldata:              []byte{0xbe, 0x05},
+			UnlockHash:            unlockHash,
+			TypedData:             typedData,
+			Confirmations:         1,
+			RequiredConfirmations: 2,
+		}, nil
+	})
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandlePreview(recorder, s.request(handlers.AcrossProtocol))
+
+	s.Equal(http.StatusOK, recorder.Code)
+	resp := handlers.PreviewResponse{}
+	err = json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Nil(err)
+	s.Equal("1-1000", resp.ID)
+	s.Equal(uint64(1), resp.Confirmations)
+	s.Equal(uint64(2), resp.RequiredConfirmations)
+	s.Equal(unlockHash, []byte(resp.UnlockHash))
+	// typed data returned to users has to hash to the same digest
+	decodedHash, err := signature.UnlockHash(resp.TypedData)
+	s.Nil(err)
+	s.Equal(unlockHash, decodedHash)
+}
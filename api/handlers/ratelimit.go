@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"golang.org/x/time/rate"
+)
+
+const (
+	CLIENT_LIMITER_TTL = time.Minute * 10
+
+	ClientLimit   = "client"
+	ProtocolLimit = "protocol"
+	InFlightLimit = "inflight"
+)
+
+type RateLimitMetrics interface {
+	TrackRateLimited(limit string)
+	TrackInFlightRequests(inFlight int64, maxInFlight int64)
+}
+
+// PendingCounter counts the signing requests that did not reach a final status yet
+type PendingCounter interface {
+	Pending() int
+}
+
+// RateLimiter limits the request rate per client and per protocol. Signing requests are
+// handled asynchronously, so the in-flight limit counts the requests being handled
+// together with the pending signing requests until they are signed or failed.
+type RateLimiter struct {
+	config  relayer.RateLimitConfig
+	metrics RateLimitMetrics
+	pending PendingCounter
+
+	lock             sync.Mutex
+	clientLimiters   *ttlcache.Cache[string, *rate.Limiter]
+	protocolLimiters map[ProtocolType]*rate.Limiter
+	defaultLimiter   *rate.Limiter
+	inFlight         int64
+}
+
+func NewRateLimiter(config relayer.RateLimitConfig, metrics RateLimitMetrics, pending PendingCounter) *RateLimiter {
+	clientLimiters := ttlcache.New(
+		ttlcache.WithTTL[string, *rate.Limiter](CLIENT_LIMITER_TTL),
+	)
+	go clientLimiters.Start()
+
+	protocolLimiters := make(map[ProtocolType]*rate.Limiter)
+	for _, protocol := range []ProtocolType{AcrossProtocol, LifiEscrowProtocol, LighterProtocol, SprinterCreditProtocol} {
+		protocolLimiters[protocol] = newLimiter(config.ProtocolRate, config.ProtocolBurst)
+	}
+
+	metrics.TrackInFlightRequests(0, int64(config.MaxInFlight))
+	return &RateLimiter{
+		config:           config,
+		metrics:          metrics,
+		pending:          pending,
+		clientLimiters:   clientLimiters,
+		protocolLimiters: protocolLimiters,
+		defaultLimiter:   newLimiter(config.ProtocolRate, config.ProtocolBurst),
+	}
+}
+
+// Middleware rejects requests with status code 429 and a Retry-After header if the
+// client or protocol request rate is exceeded or too many requests are already being handled
+// or pending.
+// Batches with more requests of a protocol than its burst can never be accepted and are
+// rejected with status code 400.
+// Clients are identified by their authenticated ID or by their IP address.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.acquire() {
+			l.reject(w, InFlightLimit, time.Second)
+			return
+		}
+		defer l.release()
+
+		delay := l.reserve(l.clientLimiter(requestClient(r)), 1)
+		if delay > 0 {
+			l.reject(w, ClientLimit, delay)
+			return
+		}
+
+		protocols, err := requestProtocols(w, r)
+		if err != nil {
+			JSONError(w, fmt.Errorf("invalid request body: %w", err), bodyErrorStatus(err))
+			return
+		}
+		for protocol, count := range protocols {
+			limiter := l.protocolLimiter(protocol)
+			if limiter.Limit() != rate.Inf && count > limiter.Burst() {
+				JSONError(w, errcode.New(errcode.InvalidRequest, "batch exceeds burst of %d %s requests", limiter.Burst(), protocol).
+					WithDetail("protocol", protocol).
+					WithDetail("burst", limiter.Burst()), http.StatusBadRequest)
+				return
+			}
+
+			delay := l.reserve(limiter, count)
+			if delay > 0 {
+				l.reject(w, ProtocolLimit, delay)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientMiddleware rejects requests with status code 429 and a Retry-After header if only
+// the client request rate is exceeded. It is used for read routes and long lived
+// streams that should not count against the protocol and in-flight limits.
+func (l *RateLimiter) ClientMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delay := l.reserve(l.clientLimiter(requestClient(r)), 1)
+		if delay > 0 {
+			l.reject(w, ClientLimit, delay)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acquire counts the request as in flight if the requests being handled
+// and the pending signing requests are below the in-flight limit
+func (l *RateLimiter) acquire() bool {
+	pending := int64(l.pending.Pending())
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.config.MaxInFlight > 0 && l.inFlight+pending >= int64(l.config.MaxInFlight) {
+		return false
+	}
+
+	l.inFlight++
+	l.metrics.TrackInFlightRequests(l.inFlight+pending, int64(l.config.MaxInFlight))
+	return true
+}
+
+func (l *RateLimiter) release() {
+	pending := int64(l.pending.Pending())
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.inFlight--
+	l.metrics.TrackInFlightRequests(l.inFlight+pending, int64(l.config.MaxInFlight))
+}
+
+func (l *RateLimiter) clientLimiter(client string) *rate.Limiter {
+	limiter, _ := l.clientLimiters.GetOrSet(
+		client,
+		newLimiter(l.config.ClientRate, l.config.ClientBurst),
+	)
+	return limiter.Value()
+}
+
+// protocolLimiter returns the limiter of the protocol. Unknown protocols share
+// a single limiter so clients can't create limiters for arbitrary protocols.
+func (l *RateLimiter) protocolLimiter(protocol ProtocolType) *rate.Limiter {
+	limiter, ok := l.protocolLimiters[protocol]
+	if !ok {
+		return l.defaultLimiter
+	}
+	return limiter
+}
+
+// reserve takes n tokens from the limiter and returns the time the client
+// should wait before retrying if the tokens are not available
+func (l *RateLimiter) reserve(limiter *rate.Limiter, n int) time.Duration {
+	reservation := limiter.ReserveN(time.Now(), n)
+	if !reservation.OK() {
+		return time.Second
+	}
+
+	delay := reservation.Delay()
+	if delay > 0 {
+		reservation.Cancel()
+	}
+	return delay
+}
+
+func (l *RateLimiter) reject(w http.ResponseWriter, limit string, retryAfter time.Duration) {
+	l.metrics.TrackRateLimited(limit)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	JSONError(w, fmt.Errorf("%s rate limit exceeded", limit), http.StatusTooManyRequests)
+}
+
+func newLimiter(limit float64, burst int) *rate.Limiter {
+	if limit <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+func requestClient(r *http.Request) string {
+	client := ClientID(r.Context())
+	if client != "" {
+		return client
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// requestProtocols counts the requested signatures per protocol for
+// single and batch signing requests. Requests without a body, like
+// GET requests, don't request any signatures. Bodies larger than
+// MAX_BODY_SIZE are rejected.
+func requestProtocols(w http.ResponseWriter, r *http.Request) (map[ProtocolType]int, error) {
+	protocols := make(map[ProtocolType]int)
+	body, err := readBody(w, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return protocols, nil
+	}
+
+	type protocolBody struct {
+		Protocol ProtocolType `json:"protocol"`
+		Items    []struct {
+			Protocol ProtocolType `json:"protocol"`
+		} `json:"items"`
+	}
+	b := &protocolBody{}
+	err = json.Unmarshal(body, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Protocol != "" {
+		protocols[b.Protocol]++
+	}
+	for _, item := range b.Items {
+		protocols[item.Protocol]++
+	}
+	return protocols, nil
+}
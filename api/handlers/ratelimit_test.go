@@ -0,0 +1,223 @@
+package handlers_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type RateLimiterTestSuite struct {
+	suite.Suite
+
+	mockMetrics *mock_handlers.MockRateLimitMetrics
+	statuses    *cache.StatusCache
+	handled     int
+}
+
+func TestRunRateLimiterTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimiterTestSuite))
+}
+
+func (s *RateLimiterTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockMetrics = mock_handlers.NewMockRateLimitMetrics(ctrl)
+	s.mockMetrics.EXPECT().TrackInFlightRequests(gomock.Any(), gomock.Any()).AnyTimes()
+	s.statuses = cache.NewStatusCache()
+	s.handled = 0
+}
+
+func (s *RateLimiterTestSuite) handler(config relayer.RateLimitConfig, next http.HandlerFunc) http.Handler {
+	if next == nil {
+		next = func(w http.ResponseWriter, r *http.Request) {
+			s.handled++
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}
+	return handlers.NewRateLimiter(config, s.mockMetrics, s.statuses).Middleware(next)
+}
+
+func (s *RateLimiterTestSuite) request(remoteAddr string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader([]byte(body)))
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_ClientLimitExceeded() {
+	s.mockMetrics.EXPECT().TrackRateLimited(handlers.ClientLimit)
+	handler := s.handler(relayer.RateLimitConfig{
+		ClientRate:  1,
+		ClientBurst: 1,
+	}, nil)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", `{"protocol":"across"}`))
+	s.Equal(http.StatusAccepted, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1001", `{"protocol":"across"}`))
+	s.Equal(http.StatusTooManyRequests, recorder.Code)
+	s.Equal("1", recorder.Header().Get("Retry-After"))
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.2:1000", `{"protocol":"across"}`))
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal(2, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_ProtocolLimitExceeded() {
+	s.mockMetrics.EXPECT().TrackRateLimited(handlers.ProtocolLimit)
+	handler := s.handler(relayer.RateLimitConfig{
+		ProtocolRate:  1,
+		ProtocolBurst: 2,
+	}, nil)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", `{"items":[{"protocol":"across"},{"protocol":"lighter"}]}`))
+	s.Equal(http.StatusAccepted, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.2:1000", `{"protocol":"lifi-escrow"}`))
+	s.Equal(http.StatusAccepted, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.3:1000", `{"items":[{"protocol":"across"},{"protocol":"across"}]}`))
+	s.Equal(http.StatusTooManyRequests, recorder.Code)
+	s.NotEmpty(recorder.Header().Get("Retry-After"))
+	s.Equal(2, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_BatchExceedsBurst() {
+	handler := s.handler(relayer.RateLimitConfig{
+		ProtocolRate:  1,
+		ProtocolBurst: 1,
+	}, nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", `{"items":[{"protocol":"across"},{"protocol":"across"}]}`))
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.Empty(recorder.Header().Get("Retry-After"))
+	s.Contains(recorder.Body.String(), "batch exceeds burst of 1 across requests")
+	s.Equal(0, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_UnknownProtocolsShareLimit() {
+	s.mockMetrics.EXPECT().TrackRateLimited(handlers.ProtocolLimit)
+	handler := s.handler(relayer.RateLimitConfig{
+		ProtocolRate:  1,
+		ProtocolBurst: 1,
+	}, nil)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", `{"protocol":"unknown-1"}`))
+	s.Equal(http.StatusAccepted, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.2:1000", `{"protocol":"unknown-2"}`))
+	s.Equal(http.StatusTooManyRequests, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.3:1000", `{"protocol":"across"}`))
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal(2, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_InFlightLimitExceeded() {
+	s.mockMetrics.EXPECT().TrackRateLimited(handlers.InFlightLimit)
+	var handler http.Handler
+	recorder := httptest.NewRecorder()
+	handler = s.handler(relayer.RateLimitConfig{
+		MaxInFlight: 1,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(recorder, s.request("10.0.0.2:1000", `{"protocol":"across"}`))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	handler.ServeHTTP(httptest.NewRecorder(), s.request("10.0.0.1:1000", `{"protocol":"across"}`))
+
+	s.Equal(http.StatusTooManyRequests, recorder.Code)
+	s.Equal("1", recorder.Header().Get("Retry-After"))
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_PendingRequestsCountAsInFlight() {
+	s.mockMetrics.EXPECT().TrackRateLimited(handlers.InFlightLimit)
+	handler := s.handler(relayer.RateLimitConfig{
+		MaxInFlight: 1,
+	}, nil)
+	err := s.statuses.Receive("1-1", "", "hash")
+	s.Nil(err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", `{"protocol":"across"}`))
+	s.Equal(http.StatusTooManyRequests, recorder.Code)
+
+	s.statuses.Fail("1-1", fmt.Errorf("error"))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", `{"protocol":"across"}`))
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal(1, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_InvalidBody() {
+	handler := s.handler(relayer.RateLimitConfig{}, nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", `invalid`))
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.Equal(0, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_BodyTooLarge() {
+	handler := s.handler(relayer.RateLimitConfig{}, nil)
+	recorder := httptest.NewRecorder()
+
+	body := fmt.Sprintf(`{"protocol":"across","calldata":"%s"}`, strings.Repeat("0", handlers.MAX_BODY_SIZE))
+	handler.ServeHTTP(recorder, s.request("10.0.0.1:1000", body))
+
+	s.Equal(http.StatusRequestEntityTooLarge, recorder.Code)
+	s.Equal(0, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_Middleware_EmptyBody() {
+	handler := s.handler(relayer.RateLimitConfig{}, nil)
+	recorder := httptest.NewRecorder()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/signatures", nil)
+	req.RemoteAddr = "10.0.0.1:1000"
+	handler.ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal(1, s.handled)
+}
+
+func (s *RateLimiterTestSuite) Test_ClientMiddleware_ClientLimitExceeded() {
+	s.mockMetrics.EXPECT().TrackRateLimited(handlers.ClientLimit)
+	handler := handlers.NewRateLimiter(relayer.RateLimitConfig{
+		ClientRate:  1,
+		ClientBurst: 1,
+		MaxInFlight: 1,
+	}, s.mockMetrics, s.statuses).ClientMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.handled++
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/1", nil))
+	s.Equal(http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/1", nil))
+	s.Equal(http.StatusTooManyRequests, recorder.Code)
+	s.Equal(1, s.handled)
+}
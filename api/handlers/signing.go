@@ -4,14 +4,23 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/cache"
 	evmMessage "github.com/sprintertech/sprinter-signing/chains/evm/message"
 	lighterMessage "github.com/sprintertech/sprinter-signing/chains/lighter/message"
+	"github.com/sprintertech/sprinter-signing/errcode"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 )
 
@@ -26,6 +35,7 @@ const (
 
 type SigningBody struct {
 	ChainId          uint64
+	ClientID         string       `json:"-"`
 	DepositId        string       `json:"depositId"`
 	Nonce            *BigInt      `json:"nonce"`
 	Protocol         ProtocolType `json:"protocol"`
@@ -37,22 +47,53 @@ type SigningBody struct {
 	RepaymentChainId uint64       `json:"repaymentChainId"`
 	Deadline         uint64       `json:"deadline"`
 	TokenOut         string       `json:"tokenOut"`
+	CallbackURL      string       `json:"callbackUrl"`
+}
+
+type SigningResponse struct {
+	ID string `json:"id"`
+}
+
+type CallbackRegistrar interface {
+	Validate(url string) error
+	Register(id string, url string)
+}
+
+// RequestDeduplicator decides if a signing request is a retry of an existing request
+type RequestDeduplicator interface {
+	Attach(id string, paramsHash string) (bool, error)
+}
+
+type paramsHasher interface {
+	ParamsHash() string
 }
 
 type SigningHandler struct {
-	msgChan chan []*message.Message
-	chains  map[uint64]struct{}
+	msgChan   chan []*message.Message
+	chains    map[uint64]struct{}
+	callbacks CallbackRegistrar
+	requests  RequestDeduplicator
 }
 
-func NewSigningHandler(msgChan chan []*message.Message, chains map[uint64]struct{}) *SigningHandler {
+func NewSigningHandler(
+	msgChan chan []*message.Message,
+	chains map[uint64]struct{},
+	callbacks CallbackRegistrar,
+	requests RequestDeduplicator,
+) *SigningHandler {
 	return &SigningHandler{
-		msgChan: msgChan,
-		chains:  chains,
+		msgChan:   msgChan,
+		chains:    chains,
+		callbacks: callbacks,
+		requests:  requests,
 	}
 }
 
-// HandleSigning sends a message to the across message handler and returns status code 202
-// if the deposit has been accepted for the signing process
+// HandleSigning sends a message to the according message handler and returns status code 202
+// with the request ID once the request is accepted for processing. Verification and
+// signing results are tracked through the status endpoints keyed by the request ID.
+// Retries of in progress or signed requests attach to the existing request, while
+// retries with different parameters are rejected with status code 409.
 func (h *SigningHandler) HandleSigning(w http.ResponseWriter, r *http.Request) {
 	b := &SigningBody{}
 	d := json.NewDecoder(r.Body)
@@ -65,11 +106,166 @@ func (h *SigningHandler) HandleSigning(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	err = h.validate(b, vars)
 	if err != nil {
-		JSONError(w, fmt.Errorf("invalid request body: %s", err), http.StatusBadRequest)
+		JSONError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
 		return
 	}
-	errChn := make(chan error, 1)
+	b.ClientID = ClientID(r.Context())
+	m, err := signingMessage(b, make(chan error, 1))
+	if err != nil {
+		JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+	id := fmt.Sprintf("%d-%s", b.ChainId, b.DepositId)
+	attach, err := h.requests.Attach(id, m.Data.(paramsHasher).ParamsHash())
+	if err != nil {
+		JSONError(w, err, http.StatusConflict)
+		return
+	}
+
+	if b.CallbackURL != "" {
+		h.callbacks.Register(id, b.CallbackURL)
+	}
+	if !attach {
+		h.msgChan <- []*message.Message{m}
+	}
+
+	data, _ := json.Marshal(SigningResponse{
+		ID: id,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write(data)
+}
+
+func (h *SigningHandler) validate(b *SigningBody, vars map[string]string) error {
+	chainId, ok := new(big.Int).SetString(vars["chainId"], 10)
+	if !ok {
+		return errcode.Fields([]errcode.FieldError{{Field: "chainId", Reason: "invalid"}})
+	}
+	b.ChainId = chainId.Uint64()
+
+	err := validateSigningBody(b, h.chains)
+	if err != nil {
+		return err
+	}
+	return validateCallback(b, h.callbacks)
+}
 
+// validateSigningBody checks that all fields required by the request protocol are set
+// and valid and that the request chain is supported. All invalid fields are reported at once.
+func validateSigningBody(b *SigningBody, chains map[uint64]struct{}) error {
+	v := &fieldValidator{}
+	v.require("depositId", b.DepositId != "")
+	v.require("liquidityPool", b.LiquidityPool != "")
+	v.check("liquidityPool", b.LiquidityPool == "" || common.IsHexAddress(b.LiquidityPool), "invalid")
+	v.require("nonce", b.Nonce != nil)
+	v.require("chainId", b.ChainId != 0)
+	v.require("deadline", b.Deadline != 0)
+	if b.CallbackURL != "" {
+		u, err := url.Parse(b.CallbackURL)
+		v.check("callbackUrl", err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "invalid")
+	}
+
+	switch b.Protocol {
+	case AcrossProtocol:
+		{
+			_, ok := new(big.Int).SetString(b.DepositId, 10)
+			v.check("depositId", b.DepositId == "" || ok, "invalid")
+			v.requireCaller(b)
+			v.require("borrowAmount", b.BorrowAmount != nil)
+			v.check("depositTxHash", b.DepositTxHash == "" || isHash(b.DepositTxHash), "invalid")
+		}
+	case LifiEscrowProtocol:
+		{
+			v.requireCaller(b)
+			v.require("borrowAmount", b.BorrowAmount != nil)
+			v.require("depositTxHash", b.DepositTxHash != "")
+			v.check("depositTxHash", b.DepositTxHash == "" || isHash(b.DepositTxHash), "invalid")
+		}
+	case LighterProtocol:
+		{
+			v.require("depositTxHash", b.DepositTxHash != "")
+		}
+	case SprinterCreditProtocol:
+		{
+			v.requireCaller(b)
+			v.require("borrowAmount", b.BorrowAmount != nil)
+			v.require("tokenOut", b.TokenOut != "")
+			v.check("tokenOut", b.TokenOut == "" || common.IsHexAddress(b.TokenOut), "invalid")
+			_, err := hex.DecodeString(b.Calldata)
+			v.check("calldata", err == nil, "invalid")
+		}
+	case "":
+		v.require("protocol", false)
+	default:
+		v.check("protocol", false, "not supported")
+	}
+
+	if len(v.fields) != 0 {
+		return errcode.Fields(v.fields)
+	}
+
+	_, ok := chains[b.ChainId]
+	if !ok {
+		return errcode.New(errcode.ChainNotSupported, "chain '%d' not supported", b.ChainId).
+			WithDetail("chainId", b.ChainId)
+	}
+
+	return nil
+}
+
+// validateCallback checks that callbacks are enabled if the request has a
+// callback url and that the url can be delivered to
+func validateCallback(b *SigningBody, callbacks CallbackRegistrar) error {
+	if b.CallbackURL == "" {
+		return nil
+	}
+	if callbacks == nil {
+		return errcode.Fields([]errcode.FieldError{{Field: "callbackUrl", Reason: "not supported"}})
+	}
+
+	err := callbacks.Validate(b.CallbackURL)
+	if err != nil {
+		return errcode.Fields([]errcode.FieldError{{Field: "callbackUrl", Reason: err.Error()}})
+	}
+	return nil
+}
+
+// fieldValidator collects all invalid fields of a request
+type fieldValidator struct {
+	fields []errcode.FieldError
+}
+
+func (v *fieldValidator) require(field string, set bool) {
+	v.check(field, set, "missing")
+}
+
+func (v *fieldValidator) requireCaller(b *SigningBody) {
+	v.checkAddress("caller", b.Caller)
+}
+
+// checkAddress requires the field to be set to a valid hex address
+func (v *fieldValidator) checkAddress(field string, address string) {
+	v.require(field, address != "")
+	v.check(field, address == "" || common.IsHexAddress(address), "invalid")
+}
+
+func (v *fieldValidator) check(field string, valid bool, reason string) {
+	if !valid {
+		v.fields = append(v.fields, errcode.FieldError{
+			Field:  field,
+			Reason: reason,
+		})
+	}
+}
+
+func isHash(s string) bool {
+	b, err := hexutil.Decode(s)
+	return err == nil && len(b) == common.HashLength
+}
+
+// signingMessage converts the signing request into a message for the according protocol
+func signingMessage(b *SigningBody, errChn chan error) (*message.Message, error) {
 	var m *message.Message
 	switch b.Protocol {
 	case AcrossProtocol:
@@ -87,6 +283,7 @@ func (h *SigningHandler) HandleSigning(w http.ResponseWriter, r *http.Request) {
 				DepositTxHash:    common.HexToHash(b.DepositTxHash),
 				Deadline:         b.Deadline,
 				RepaymentChainID: b.RepaymentChainId,
+				ClientID:         b.ClientID,
 			})
 		}
 	case LifiEscrowProtocol:
@@ -102,6 +299,7 @@ func (h *SigningHandler) HandleSigning(w http.ResponseWriter, r *http.Request) {
 				Destination:   b.ChainId,
 				Deadline:      b.Deadline,
 				BorrowAmount:  b.BorrowAmount.Int,
+				ClientID:      b.ClientID,
 			})
 		}
 	case LighterProtocol:
@@ -115,6 +313,7 @@ func (h *SigningHandler) HandleSigning(w http.ResponseWriter, r *http.Request) {
 				ErrChn:        errChn,
 				Source:        0,
 				Destination:   b.ChainId,
+				ClientID:      b.ClientID,
 			})
 		}
 	case SprinterCreditProtocol:
@@ -134,95 +333,90 @@ func (h *SigningHandler) HandleSigning(w http.ResponseWriter, r *http.Request) {
 					Calldata:      b.Calldata,
 					Deadline:      b.Deadline,
 					DepositID:     b.DepositId,
+					ClientID:      b.ClientID,
 				})
 		}
 	default:
-		JSONError(w, fmt.Errorf("invalid protocol %s", b.Protocol), http.StatusBadRequest)
-		return
+		return nil, errcode.New(errcode.ProtocolNotSupported, "invalid protocol %s", b.Protocol)
 	}
-	h.msgChan <- []*message.Message{m}
-
-	err = <-errChn
-	if err != nil {
-		JSONError(w, fmt.Errorf("signing failed: %s", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusAccepted)
+	return m, nil
 }
 
-func (h *SigningHandler) validate(b *SigningBody, vars map[string]string) error {
-	chainId, ok := new(big.Int).SetString(vars["chainId"], 10)
-	if !ok {
-		return fmt.Errorf("field 'chainId' invalid")
-	}
-	b.ChainId = chainId.Uint64()
-
-	if b.DepositId == "" {
-		return fmt.Errorf("missing field 'depositId'")
-	}
-
-	if b.LiquidityPool == "" {
-		return fmt.Errorf("missing field 'liquidityPool'")
-	}
-
-	if b.Caller == "" {
-		return fmt.Errorf("missing field 'caller'")
-	}
-
-	if b.Nonce == nil {
-		return fmt.Errorf("missing field 'nonce'")
-	}
-
-	if b.ChainId == 0 {
-		return fmt.Errorf("missing field 'chainId'")
-	}
-
-	if b.Deadline == 0 {
-		return fmt.Errorf("missing field 'deadline'")
-	}
-
-	_, ok = h.chains[b.ChainId]
-	if !ok {
-		return fmt.Errorf("chain '%d' not supported", b.ChainId)
-	}
-
-	return nil
-}
+const (
+	HEARTBEAT_INTERVAL = time.Second * 15
+	// FAILED_STREAM_TIMEOUT is how long status streams of failed requests are kept open
+	// as the request can still be signed by other peers or retried
+	FAILED_STREAM_TIMEOUT = time.Minute * 5
+)
 
 type SignatureCacher interface {
 	Subscribe(ctx context.Context, id string, sigChannel chan []byte)
+	Signature(id string) ([]byte, error)
+	Fetch(id string)
+}
+
+type StatusCacher interface {
+	Subscribe(ctx context.Context, id string, after uint64, statusChn chan types.RequestStatus) error
+	Status(id string) (types.RequestStatus, error)
 }
 
 type StatusHandler struct {
-	cache  SignatureCacher
-	chains map[uint64]struct{}
+	cache    SignatureCacher
+	statuses StatusCacher
+	chains   map[uint64]struct{}
 }
 
-func NewStatusHandler(cache SignatureCacher, chains map[uint64]struct{}) *StatusHandler {
+func NewStatusHandler(sigCache SignatureCacher, statuses StatusCacher, chains map[uint64]struct{}) *StatusHandler {
 	return &StatusHandler{
-		cache:  cache,
-		chains: chains,
+		cache:    sigCache,
+		statuses: statuses,
+		chains:   chains,
 	}
 }
 
-// HandleRequest is an sse handler that waits until the signing signature is ready
-// and returns it
-func (h *StatusHandler) HandleRequest(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	chainId, ok := new(big.Int).SetString(vars["chainId"], 0)
-	if !ok {
-		JSONError(w, fmt.Errorf("chain id invalid"), http.StatusBadRequest)
+// HandleStatus returns the latest lifecycle status of the signing request
+// and the signature if the request has already been signed. Missing signatures
+// of finished requests with a known digest are requested from peers so later
+// requests can return them.
+func (h *StatusHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	id, code, err := h.requestID(r)
+	if err != nil {
+		JSONError(w, err, code)
 		return
 	}
-	_, ok = h.chains[chainId.Uint64()]
-	if !ok {
-		JSONError(w, fmt.Errorf("chain %d not supported", chainId.Int64()), http.StatusNotFound)
+
+	resp := types.StatusResponse{}
+	status, statusErr := h.statuses.Status(id)
+	if statusErr == nil {
+		resp.RequestStatus = status
+	}
+	sig, sigErr := h.cache.Signature(id)
+	if sigErr == nil {
+		resp.ID = id
+		resp.State = types.SignedState
+		resp.Signature = hex.EncodeToString(sig)
+	} else if statusErr == nil {
+		h.fetch(status)
+	}
+	if statusErr != nil && sigErr != nil {
+		JSONError(w, fmt.Errorf("request %s not found", id), http.StatusNotFound)
 		return
 	}
-	depositId, ok := vars["depositId"]
-	if !ok {
-		JSONError(w, fmt.Errorf("missing 'depositId"), http.StatusBadRequest)
+
+	data, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// HandleRequest is an sse handler that streams status transitions of the signing request
+// and returns the signature once it is ready. Status events resume after the sequence
+// sent in the Last-Event-ID header. Failed requests are streamed until they are signed
+// or FAILED_STREAM_TIMEOUT passes, while slow clients are disconnected and have to resume.
+func (h *StatusHandler) HandleRequest(w http.ResponseWriter, r *http.Request) {
+	id, code, err := h.requestID(r)
+	if err != nil {
+		JSONError(w, err, code)
 		return
 	}
 
@@ -232,11 +426,49 @@ func (h *StatusHandler) HandleRequest(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	sigChn := make(chan []byte, 1)
-	h.cache.Subscribe(ctx, fmt.Sprintf("%d-%s", chainId, depositId), sigChn)
+	go h.cache.Subscribe(ctx, id, sigChn)
+	status, err := h.statuses.Status(id)
+	if err == nil {
+		h.fetch(status)
+	}
+	statusChn := make(chan types.RequestStatus, types.STATUS_BUFFER_SIZE)
+	errChn := make(chan error, 1)
+	go func() {
+		errChn <- h.statuses.Subscribe(ctx, id, h.lastEventID(r), statusChn)
+	}()
+
+	heartbeat := time.NewTicker(HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+	var failedTimeout <-chan time.Time
 	for {
 		select {
-		case <-r.Context().Done():
+		case <-ctx.Done():
+			return
+		case <-failedTimeout:
 			return
+		case err := <-errChn:
+			{
+				if errors.Is(err, cache.ErrSlowSubscriber) {
+					log.Debug().Msgf("Disconnecting slow status subscriber of request %s", id)
+					return
+				}
+			}
+		case <-heartbeat.C:
+			{
+				fmt.Fprint(w, ": heartbeat\n\n")
+				w.(http.Flusher).Flush()
+			}
+		case status := <-statusChn:
+			{
+				data, _ := json.Marshal(status)
+				fmt.Fprintf(w, "id: %d\nevent: status\ndata: %s\n\n", status.Sequence, data)
+				w.(http.Flusher).Flush()
+				if status.State == types.FailedState {
+					failedTimeout = time.After(FAILED_STREAM_TIMEOUT)
+				} else {
+					failedTimeout = nil
+				}
+			}
 		case sig := <-sigChn:
 			{
 				fmt.Fprintf(w, "data: %s\n\n", hex.EncodeToString(sig))
@@ -247,6 +479,45 @@ func (h *StatusHandler) HandleRequest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// fetch requests the missing signature from peers if the request is finished and its
+// digest is known, as only such signatures can be verified. Requests in progress are
+// still being signed and unknown requests are not fetched so clients can't make the
+// relayer broadcast requests for arbitrary IDs.
+func (h *StatusHandler) fetch(status types.RequestStatus) {
+	if !status.Final() || status.Digest == "" {
+		return
+	}
+
+	h.cache.Fetch(status.ID)
+}
+
+func (h *StatusHandler) requestID(r *http.Request) (string, int, error) {
+	vars := mux.Vars(r)
+	chainId, ok := new(big.Int).SetString(vars["chainId"], 0)
+	if !ok {
+		return "", http.StatusBadRequest, fmt.Errorf("chain id invalid")
+	}
+	_, ok = h.chains[chainId.Uint64()]
+	if !ok {
+		return "", http.StatusNotFound, errcode.New(errcode.ChainNotSupported, "chain %d not supported", chainId.Int64()).
+			WithDetail("chainId", chainId.Uint64())
+	}
+	depositId, ok := vars["depositId"]
+	if !ok {
+		return "", http.StatusBadRequest, fmt.Errorf("missing 'depositId")
+	}
+
+	return fmt.Sprintf("%d-%s", chainId, depositId), http.StatusOK, nil
+}
+
+func (h *StatusHandler) lastEventID(r *http.Request) uint64 {
+	after, err := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return after
+}
+
 func (h *StatusHandler) setheaders(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
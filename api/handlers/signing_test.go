@@ -15,8 +15,11 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/sprintertech/sprinter-signing/api/handlers"
 	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/cache"
 	across "github.com/sprintertech/sprinter-signing/chains/evm/message"
 	lighter "github.com/sprintertech/sprinter-signing/chains/lighter/message"
+	"github.com/sprintertech/sprinter-signing/errcode"
 	"github.com/stretchr/testify/suite"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"go.uber.org/mock/gomock"
@@ -25,7 +28,10 @@ import (
 type SigningHandlerTestSuite struct {
 	suite.Suite
 
-	chains map[uint64]struct{}
+	mockCallbacks *mock_handlers.MockCallbackRegistrar
+
+	chains   map[uint64]struct{}
+	statuses *cache.StatusCache
 }
 
 func TestRunSigningHandlerTestSuite(t *testing.T) {
@@ -33,6 +39,10 @@ func TestRunSigningHandlerTestSuite(t *testing.T) {
 }
 
 func (s *SigningHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockCallbacks = mock_handlers.NewMockCallbackRegistrar(ctrl)
+	s.statuses = cache.NewStatusCache()
+
 	chains := make(map[uint64]struct{})
 	chains[1] = struct{}{}
 	s.chains = chains
@@ -40,7 +50,7 @@ func (s *SigningHandlerTestSuite) SetupTest() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_MissingDepositID() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		Protocol:      "across",
@@ -71,7 +81,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_MissingDepositID() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_MissingCaller() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		Protocol:      "across",
@@ -102,7 +112,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_MissingCaller() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_MissingLiquidityPool() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		Protocol:  "across",
@@ -133,7 +143,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_MissingLiquidityPool() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_InvalidChainID() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:     "1000",
@@ -165,7 +175,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_InvalidChainID() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_ChainNotSupported() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:     "1000",
@@ -197,7 +207,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_ChainNotSupported() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_InvalidProtocol() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:     "1000",
@@ -227,9 +237,48 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_InvalidProtocol() {
 	s.Equal(http.StatusBadRequest, recorder.Code)
 }
 
-func (s *SigningHandlerTestSuite) Test_HandleSigning_ErrorHandlingMessage() {
+func (s *SigningHandlerTestSuite) Test_HandleSigning_ReportsAllInvalidFields() {
+	msgChn := make(chan []*message.Message)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
+
+	input := handlers.SigningBody{
+		DepositId: "invalid",
+		Protocol:  "across",
+		Caller:    "0x1234",
+	}
+	body, _ := json.Marshal(input)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId": "1",
+	})
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+
+	handler.HandleSigning(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	resp := struct {
+		ErrorCode errcode.Code         `json:"errorCode"`
+		Fields    []errcode.FieldError `json:"fields"`
+	}{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Nil(err)
+	s.Equal(errcode.InvalidRequest, resp.ErrorCode)
+	s.Equal([]errcode.FieldError{
+		{Field: "liquidityPool", Reason: "missing"},
+		{Field: "nonce", Reason: "missing"},
+		{Field: "deadline", Reason: "missing"},
+		{Field: "depositId", Reason: "invalid"},
+		{Field: "caller", Reason: "invalid"},
+		{Field: "borrowAmount", Reason: "missing"},
+	}, resp.Fields)
+}
+
+func (s *SigningHandlerTestSuite) Test_HandleSigning_ErrorHandlingMessageIsAsync() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:     "1000",
@@ -259,12 +308,15 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_ErrorHandlingMessage() {
 
 	handler.HandleSigning(recorder, req)
 
-	s.Equal(http.StatusInternalServerError, recorder.Code)
+	resp := handlers.SigningResponse{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Equal("1-1000", resp.ID)
 }
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_AcrossSuccess() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:        "1000",
@@ -299,9 +351,175 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_AcrossSuccess() {
 	s.Equal(http.StatusAccepted, recorder.Code)
 }
 
+func (s *SigningHandlerTestSuite) Test_HandleSigning_RetryAttachesOrConflicts() {
+	msgChn := make(chan []*message.Message, 1)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
+	request := func(borrowAmount int64) *httptest.ResponseRecorder {
+		input := handlers.SigningBody{
+			DepositId:        "1000",
+			Protocol:         "across",
+			LiquidityPool:    "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+			Caller:           "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+			BorrowAmount:     &handlers.BigInt{big.NewInt(borrowAmount)},
+			Nonce:            &handlers.BigInt{big.NewInt(1001)},
+			RepaymentChainId: 5,
+			Deadline:         uint64(1000),
+		}
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{
+			"chainId": "1",
+		})
+		recorder := httptest.NewRecorder()
+		handler.HandleSigning(recorder, req)
+		return recorder
+	}
+
+	recorder := request(1000)
+	s.Equal(http.StatusAccepted, recorder.Code)
+	msg := <-msgChn
+	ad := msg[0].Data.(*across.AcrossData)
+	err := s.statuses.Receive("1-1000", ad.Caller.Hex(), ad.ParamsHash())
+	s.Nil(err)
+
+	recorder = request(1000)
+	s.Equal(http.StatusAccepted, recorder.Code)
+	s.Len(msgChn, 0)
+
+	recorder = request(2000)
+	s.Equal(http.StatusConflict, recorder.Code)
+	s.Len(msgChn, 0)
+}
+
+func (s *SigningHandlerTestSuite) Test_HandleSigning_InvalidCallbackURL() {
+	msgChn := make(chan []*message.Message)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
+
+	input := handlers.SigningBody{
+		DepositId:     "1000",
+		Protocol:      "across",
+		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
+		Nonce:         &handlers.BigInt{big.NewInt(1001)},
+		Deadline:      uint64(1000),
+		CallbackURL:   "ftp://callback",
+	}
+	body, _ := json.Marshal(input)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId": "1",
+	})
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+
+	handler.HandleSigning(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *SigningHandlerTestSuite) Test_HandleSigning_CallbackRegistered() {
+	msgChn := make(chan []*message.Message)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
+
+	input := handlers.SigningBody{
+		DepositId:     "1000",
+		Protocol:      "across",
+		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
+		Nonce:         &handlers.BigInt{big.NewInt(1001)},
+		Deadline:      uint64(1000),
+		CallbackURL:   "https://solver.com/callback",
+	}
+	body, _ := json.Marshal(input)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId": "1",
+	})
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+
+	s.mockCallbacks.EXPECT().Validate("https://solver.com/callback").Return(nil)
+	s.mockCallbacks.EXPECT().Register("1-1000", "https://solver.com/callback")
+	go func() {
+		<-msgChn
+	}()
+
+	handler.HandleSigning(recorder, req)
+
+	s.Equal(http.StatusAccepted, recorder.Code)
+}
+
+func (s *SigningHandlerTestSuite) Test_HandleSigning_PrivateCallbackURL() {
+	msgChn := make(chan []*message.Message)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
+
+	input := handlers.SigningBody{
+		DepositId:     "1000",
+		Protocol:      "across",
+		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
+		Nonce:         &handlers.BigInt{big.NewInt(1001)},
+		Deadline:      uint64(1000),
+		CallbackURL:   "http://169.254.169.254/latest/meta-data",
+	}
+	body, _ := json.Marshal(input)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId": "1",
+	})
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+
+	s.mockCallbacks.EXPECT().Validate("http://169.254.169.254/latest/meta-data").Return(fmt.Errorf("private host not allowed"))
+
+	handler.HandleSigning(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.Contains(recorder.Body.String(), "private host not allowed")
+}
+
+func (s *SigningHandlerTestSuite) Test_HandleSigning_CallbacksDisabled() {
+	msgChn := make(chan []*message.Message)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, nil, s.statuses)
+
+	input := handlers.SigningBody{
+		DepositId:     "1000",
+		Protocol:      "across",
+		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
+		Nonce:         &handlers.BigInt{big.NewInt(1001)},
+		Deadline:      uint64(1000),
+		CallbackURL:   "https://solver.com/callback",
+	}
+	body, _ := json.Marshal(input)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chains/1/signatures", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId": "1",
+	})
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+
+	handler.HandleSigning(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	s.Contains(recorder.Body.String(), "not supported")
+}
+
 func (s *SigningHandlerTestSuite) Test_HandleSigning_LifiSuccess() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:     "depositID",
@@ -309,6 +527,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_LifiSuccess() {
 		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
 		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
 		Calldata:      "0xbe5",
+		DepositTxHash: "0x0b2e0a2f6f3bb4e9b2d4b4b8b4f7c8e1e4f0f6c2a9f1d2b3c4d5e6f708192a3b",
 		Nonce:         &handlers.BigInt{big.NewInt(1001)},
 		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
 		//nolint:gosec
@@ -337,7 +556,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_LifiSuccess() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_LighterSuccess() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:     "depositID",
@@ -345,6 +564,7 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_LighterSuccess() {
 		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
 		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
 		Calldata:      "0xbe5",
+		DepositTxHash: "1234",
 		Nonce:         &handlers.BigInt{big.NewInt(1001)},
 		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
 		//nolint:gosec
@@ -373,14 +593,15 @@ func (s *SigningHandlerTestSuite) Test_HandleSigning_LighterSuccess() {
 
 func (s *SigningHandlerTestSuite) Test_HandleSigning_SprinterSuccess() {
 	msgChn := make(chan []*message.Message)
-	handler := handlers.NewSigningHandler(msgChn, s.chains)
+	handler := handlers.NewSigningHandler(msgChn, s.chains, s.mockCallbacks, s.statuses)
 
 	input := handlers.SigningBody{
 		DepositId:     "depositID",
 		Protocol:      "sprinter-credit",
 		LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
 		Caller:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
-		Calldata:      "0xbe5",
+		Calldata:      "be05",
+		TokenOut:      "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
 		Nonce:         &handlers.BigInt{big.NewInt(1001)},
 		BorrowAmount:  &handlers.BigInt{big.NewInt(1000)},
 		//nolint:gosec
@@ -411,6 +632,7 @@ type StatusHandlerTestSuite struct {
 	suite.Suite
 
 	mockSignatureCacher *mock_handlers.MockSignatureCacher
+	mockStatusCacher    *mock_handlers.MockStatusCacher
 	handler             *handlers.StatusHandler
 }
 
@@ -426,7 +648,8 @@ func (s *StatusHandlerTestSuite) SetupTest() {
 	chains[1] = struct{}{}
 
 	s.mockSignatureCacher = mock_handlers.NewMockSignatureCacher(ctrl)
-	s.handler = handlers.NewStatusHandler(s.mockSignatureCacher, chains)
+	s.mockStatusCacher = mock_handlers.NewMockStatusCacher(ctrl)
+	s.handler = handlers.NewStatusHandler(s.mockSignatureCacher, s.mockStatusCacher, chains)
 }
 
 func (s *StatusHandlerTestSuite) Test_HandleRequest_MissingDepositID() {
@@ -488,6 +711,8 @@ func (s *StatusHandlerTestSuite) Test_HandleRequest_ValidSignature() {
 				sigChannel <- expectedSignature
 			}()
 		})
+	s.mockStatusCacher.EXPECT().Subscribe(gomock.Any(), "1-id", uint64(0), gomock.Any()).AnyTimes()
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(cache.RequestStatus{}, fmt.Errorf("not found"))
 
 	go s.handler.HandleRequest(recorder, req)
 
@@ -500,3 +725,221 @@ func (s *StatusHandlerTestSuite) Test_HandleRequest_ValidSignature() {
 	s.Equal("*", recorder.Header().Get("Access-Control-Allow-Origin"))
 	s.Equal("data: "+hex.EncodeToString(expectedSignature)+"\n\n", recorder.Body.String())
 }
+
+func (s *StatusHandlerTestSuite) Test_HandleRequest_StatusEvents() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id", nil)
+	req.Header.Set("Last-Event-ID", "5")
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	status := cache.RequestStatus{
+		Sequence: 6,
+		ID:       "1-id",
+		State:    cache.FailedState,
+		Reason:   "deposit not found",
+	}
+	s.mockSignatureCacher.EXPECT().Subscribe(gomock.Any(), "1-id", gomock.Any()).AnyTimes()
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(status, nil)
+	s.mockStatusCacher.EXPECT().
+		Subscribe(gomock.Any(), "1-id", uint64(5), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, after uint64, statusChn chan cache.RequestStatus) error {
+			statusChn <- status
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	ctx, cancel := context.WithTimeout(req.Context(), 100*time.Millisecond)
+	defer cancel()
+	s.handler.HandleRequest(recorder, req.WithContext(ctx))
+
+	data, _ := json.Marshal(status)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal(fmt.Sprintf("id: 6\nevent: status\ndata: %s\n\n", data), recorder.Body.String())
+}
+
+func (s *StatusHandlerTestSuite) Test_HandleRequest_FailedRequestSignedByPeers() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	status := cache.RequestStatus{
+		Sequence: 1,
+		ID:       "1-id",
+		State:    cache.FailedState,
+		Reason:   "tss timeout",
+		Digest:   "0x01",
+	}
+	expectedSignature := []byte{0x01, 0x02, 0x03}
+	s.mockSignatureCacher.EXPECT().
+		Subscribe(gomock.Any(), "1-id", gomock.Any()).
+		Do(func(ctx context.Context, id string, sigChannel chan []byte) {
+			time.Sleep(50 * time.Millisecond)
+			sigChannel <- expectedSignature
+		})
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(status, nil)
+	s.mockSignatureCacher.EXPECT().Fetch("1-id")
+	s.mockStatusCacher.EXPECT().
+		Subscribe(gomock.Any(), "1-id", uint64(0), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, after uint64, statusChn chan cache.RequestStatus) error {
+			statusChn <- status
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	s.handler.HandleRequest(recorder, req)
+
+	data, _ := json.Marshal(status)
+	s.Equal(
+		fmt.Sprintf("id: 1\nevent: status\ndata: %s\n\ndata: %s\n\n", data, hex.EncodeToString(expectedSignature)),
+		recorder.Body.String(),
+	)
+}
+
+func (s *StatusHandlerTestSuite) Test_HandleRequest_SlowSubscriberDisconnected() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	s.mockSignatureCacher.EXPECT().Subscribe(gomock.Any(), "1-id", gomock.Any()).AnyTimes()
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(cache.RequestStatus{}, fmt.Errorf("not found"))
+	s.mockStatusCacher.EXPECT().
+		Subscribe(gomock.Any(), "1-id", uint64(0), gomock.Any()).
+		Return(cache.ErrSlowSubscriber)
+
+	s.handler.HandleRequest(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal("", recorder.Body.String())
+}
+
+func (s *StatusHandlerTestSuite) Test_HandleStatus_NotFound() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id/status", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(cache.RequestStatus{}, fmt.Errorf("not found"))
+	s.mockSignatureCacher.EXPECT().Signature("1-id").Return(nil, fmt.Errorf("not found"))
+
+	s.handler.HandleStatus(recorder, req)
+
+	s.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *StatusHandlerTestSuite) Test_HandleStatus_WaitingConfirmations() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id/status", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	status := cache.RequestStatus{
+		Sequence:              3,
+		ID:                    "1-id",
+		State:                 cache.WaitingConfirmationsState,
+		Confirmations:         2,
+		RequiredConfirmations: 5,
+	}
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(status, nil)
+	s.mockSignatureCacher.EXPECT().Signature("1-id").Return(nil, fmt.Errorf("not found"))
+
+	s.handler.HandleStatus(recorder, req)
+
+	resp := types.StatusResponse{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal(cache.WaitingConfirmationsState, resp.State)
+	s.Equal(uint64(2), resp.Confirmations)
+	s.Equal(uint64(5), resp.RequiredConfirmations)
+	s.Equal("", resp.Signature)
+}
+
+func (s *StatusHandlerTestSuite) Test_HandleStatus_FailedFetchesSignature() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id/status", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	status := cache.RequestStatus{
+		Sequence: 4,
+		ID:       "1-id",
+		State:    cache.FailedState,
+		Reason:   "tss timeout",
+		Digest:   "0x01",
+	}
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(status, nil)
+	s.mockSignatureCacher.EXPECT().Signature("1-id").Return(nil, fmt.Errorf("not found"))
+	s.mockSignatureCacher.EXPECT().Fetch("1-id")
+
+	s.handler.HandleStatus(recorder, req)
+
+	resp := types.StatusResponse{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal(cache.FailedState, resp.State)
+}
+
+func (s *StatusHandlerTestSuite) Test_HandleStatus_FailedWithoutDigestNotFetched() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id/status", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	status := cache.RequestStatus{
+		Sequence: 4,
+		ID:       "1-id",
+		State:    cache.FailedState,
+		Reason:   "deposit not found",
+	}
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(status, nil)
+	s.mockSignatureCacher.EXPECT().Signature("1-id").Return(nil, fmt.Errorf("not found"))
+
+	s.handler.HandleStatus(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *StatusHandlerTestSuite) Test_HandleStatus_Signed() {
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/id/status", nil)
+	req = mux.SetURLVars(req, map[string]string{
+		"chainId":   "1",
+		"depositId": "id",
+	})
+
+	recorder := httptest.NewRecorder()
+
+	expectedSignature := []byte{0x01, 0x02, 0x03}
+	s.mockStatusCacher.EXPECT().Status("1-id").Return(cache.RequestStatus{}, fmt.Errorf("not found"))
+	s.mockSignatureCacher.EXPECT().Signature("1-id").Return(expectedSignature, nil)
+
+	s.handler.HandleStatus(recorder, req)
+
+	resp := types.StatusResponse{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal(cache.SignedState, resp.State)
+	s.Equal(hex.EncodeToString(expectedSignature), resp.Signature)
+}
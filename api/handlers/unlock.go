@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 	evmMessage "github.com/sprintertech/sprinter-signing/chains/evm/message"
+	"github.com/sprintertech/sprinter-signing/errcode"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 )
@@ -55,7 +56,7 @@ func (h *UnlockHandler) HandleUnlock(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	err = h.validate(b, vars)
 	if err != nil {
-		JSONError(w, fmt.Errorf("invalid request body: %s", err), http.StatusBadRequest)
+		JSONError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -70,10 +71,11 @@ func (h *UnlockHandler) HandleUnlock(w http.ResponseWriter, r *http.Request) {
 				SigChn:      sigChn,
 				OrderID:     b.OrderID,
 				Settler:     common.HexToAddress(b.Settler),
+				ClientID:    ClientID(r.Context()),
 			})
 		}
 	default:
-		JSONError(w, fmt.Errorf("invalid protocol %s", b.Protocol), http.StatusBadRequest)
+		JSONError(w, errcode.New(errcode.ProtocolNotSupported, "invalid protocol %s", b.Protocol), http.StatusBadRequest)
 		return
 	}
 	h.msgChan <- []*message.Message{m}
@@ -81,7 +83,7 @@ func (h *UnlockHandler) HandleUnlock(w http.ResponseWriter, r *http.Request) {
 	for {
 		select {
 		case <-time.After(SIGNATURE_TIMEOUT):
-			JSONError(w, fmt.Errorf("timeout"), http.StatusInternalServerError)
+			JSONError(w, errcode.New(errcode.Timeout, "timeout"), http.StatusInternalServerError)
 			return
 		case sig := <-sigChn:
 			{
@@ -117,7 +119,8 @@ func (h *UnlockHandler) validate(b *UnlockBody, vars map[string]string) error {
 
 	_, ok = h.chains[b.ChainId]
 	if !ok {
-		return fmt.Errorf("chain '%d' not supported", b.ChainId)
+		return errcode.New(errcode.ChainNotSupported, "chain '%d' not supported", b.ChainId).
+			WithDetail("chainId", b.ChainId)
 	}
 
 	return nil
@@ -1,13 +1,21 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net/http"
 	"strings"
+
+	"github.com/sprintertech/sprinter-signing/errcode"
 )
 
+// MAX_BODY_SIZE is the maximum size of request bodies read by middlewares
+const MAX_BODY_SIZE = 1 << 20
+
 type BigInt struct {
 	*big.Int
 }
@@ -30,17 +38,73 @@ func (b *BigInt) MarshalJSON() ([]byte, error) {
 	return []byte(b.String()), nil
 }
 
+// JSONError writes the error response with the HTTP status code. The error code
+// and details are taken from coded errors and derived from the status code otherwise.
 func JSONError(w http.ResponseWriter, err error, code int) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.WriteHeader(code)
 	type errorResponse struct {
-		Code   int    `json:"code"`
-		Reason string `json:"reason"`
+		Code      int                  `json:"code"`
+		ErrorCode errcode.Code         `json:"errorCode"`
+		Reason    string               `json:"reason"`
+		Details   map[string]any       `json:"details,omitempty"`
+		Fields    []errcode.FieldError `json:"fields,omitempty"`
 	}
 	resp := errorResponse{
-		Reason: err.Error(),
-		Code:   code,
+		Reason:    err.Error(),
+		Code:      code,
+		ErrorCode: errcode.CodeOf(err, statusErrorCode(code)),
+	}
+	e := errcode.As(err)
+	if e != nil {
+		resp.Details = e.Details
+		resp.Fields = e.Fields
 	}
 	_ = json.NewEncoder(w).Encode(resp)
 }
+
+// readBody reads the request body up to MAX_BODY_SIZE and replaces it
+// so it can be read again by the next handler
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MAX_BODY_SIZE))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// bodyErrorStatus returns status code 413 if the request body exceeded
+// MAX_BODY_SIZE and status code 400 otherwise
+func bodyErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+func statusErrorCode(code int) errcode.Code {
+	switch code {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return errcode.InvalidRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errcode.Unauthorized
+	case http.StatusNotFound:
+		return errcode.NotFound
+	case http.StatusConflict:
+		return errcode.RequestConflict
+	case http.StatusUnprocessableEntity:
+		return errcode.VerificationFailed
+	case http.StatusTooManyRequests:
+		return errcode.RateLimited
+	case http.StatusServiceUnavailable:
+		return errcode.Unavailable
+	case http.StatusGatewayTimeout:
+		return errcode.Timeout
+	default:
+		return errcode.Internal
+	}
+}
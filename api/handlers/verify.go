@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
+	"github.com/sprintertech/sprinter-signing/errcode"
+)
+
+// BorrowParams are the parameters of a liquidity pool borrow signed by the MPC committee
+type BorrowParams struct {
+	ChainId       uint64  `json:"chainId"`
+	LiquidityPool string  `json:"liquidityPool"`
+	Caller        string  `json:"caller"`
+	Token         string  `json:"token"`
+	Amount        *BigInt `json:"amount"`
+	Target        string  `json:"target"`
+	Calldata      string  `json:"calldata"`
+	Nonce         *BigInt `json:"nonce"`
+	Deadline      uint64  `json:"deadline"`
+}
+
+// AllowOpenParams are the parameters of a Lifi escrow unlock signed by the MPC committee
+type AllowOpenParams struct {
+	ChainId     uint64 `json:"chainId"`
+	OrderID     string `json:"orderId"`
+	Settler     string `json:"settler"`
+	Destination string `json:"destination"`
+}
+
+// VerifyBody contains the signature and either the raw digest or the
+// parameters of exactly one of the signed messages
+type VerifyBody struct {
+	Signature string           `json:"signature"`
+	Digest    string           `json:"digest"`
+	Borrow    *BorrowParams    `json:"borrow"`
+	AllowOpen *AllowOpenParams `json:"allowOpen"`
+}
+
+type VerifyResponse struct {
+	Digest  hexutil.Bytes `json:"digest"`
+	Signer  string        `json:"signer"`
+	Valid   bool          `json:"valid"`
+	Current bool          `json:"current"`
+}
+
+type VerifyHandler struct {
+	keyshares  KeyshareFetcher
+	historical []common.Address
+}
+
+func NewVerifyHandler(keyshares KeyshareFetcher, historical []common.Address) *VerifyHandler {
+	return &VerifyHandler{
+		keyshares:  keyshares,
+		historical: historical,
+	}
+}
+
+// HandleVerify recovers the signer of the signature and reports if it was signed
+// by the current MPC address or by any of the historical MPC addresses.
+func (h *VerifyHandler) HandleVerify(w http.ResponseWriter, r *http.Request) {
+	b := &VerifyBody{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(b)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid request body: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	err = validateVerifyBody(b)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	digest, err := verifyDigest(b)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid request body: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	sig, _ := hexutil.Decode(b.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		JSONError(w, fmt.Errorf("invalid signature: %s", err), http.StatusBadRequest)
+		return
+	}
+	signer := crypto.PubkeyToAddress(*pub)
+
+	current := h.currentAddress()
+	resp := VerifyResponse{
+		Digest:  digest,
+		Signer:  signer.Hex(),
+		Current: current != nil && *current == signer,
+	}
+	resp.Valid = resp.Current || slices.Contains(h.historical, signer)
+
+	data, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// currentAddress returns the MPC address of the current keyshare or nil if the
+// relayer has no keyshare
+func (h *VerifyHandler) currentAddress() *common.Address {
+	k, err := h.keyshares.GetKeyshare()
+	if err != nil || k.Key.ECDSAPub == nil {
+		return nil
+	}
+
+	address := crypto.PubkeyToAddress(*k.Key.ECDSAPub.ToBtcecPubKey().ToECDSA())
+	return &address
+}
+
+// validateVerifyBody checks that the signature and exactly one of the signed
+// messages are set and valid. All invalid fields are reported at once.
+func validateVerifyBody(b *VerifyBody) error {
+	v := &fieldValidator{}
+	sig, err := hexutil.Decode(b.Signature)
+	v.require("signature", b.Signature != "")
+	v.check("signature", b.Signature == "" || (err == nil && len(sig) == crypto.SignatureLength), "invalid")
+
+	set := 0
+	for _, isSet := range []bool{b.Digest != "", b.Borrow != nil, b.AllowOpen != nil} {
+		if isSet {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		v.require("digest", false)
+	case set > 1:
+		v.check("digest", false, "exactly one of 'digest', 'borrow' or 'allowOpen' required")
+	case b.Borrow != nil:
+		{
+			p := b.Borrow
+			v.require("borrow.chainId", p.ChainId != 0)
+			v.require("borrow.amount", p.Amount != nil && p.Amount.Int != nil)
+			v.require("borrow.nonce", p.Nonce != nil && p.Nonce.Int != nil)
+			v.checkAddress("borrow.liquidityPool", p.LiquidityPool)
+			v.checkAddress("borrow.caller", p.Caller)
+			v.checkAddress("borrow.token", p.Token)
+			v.checkAddress("borrow.target", p.Target)
+			_, err := hexutil.Decode(p.Calldata)
+			v.check("borrow.calldata", p.Calldata == "" || err == nil, "invalid")
+		}
+	case b.AllowOpen != nil:
+		{
+			p := b.AllowOpen
+			v.require("allowOpen.chainId", p.ChainId != 0)
+			v.require("allowOpen.orderId", p.OrderID != "")
+			v.checkAddress("allowOpen.settler", p.Settler)
+			v.checkAddress("allowOpen.destination", p.Destination)
+		}
+	default:
+		v.check("digest", isHash(b.Digest), "invalid")
+	}
+
+	if len(v.fields) != 0 {
+		return errcode.Fields(v.fields)
+	}
+	return nil
+}
+
+// verifyDigest returns the digest of the signed message of the validated request
+func verifyDigest(b *VerifyBody) ([]byte, error) {
+	switch {
+	case b.Borrow != nil:
+		return borrowDigest(b.Borrow)
+	case b.AllowOpen != nil:
+		return allowOpenDigest(b.AllowOpen)
+	default:
+		return hexutil.Decode(b.Digest)
+	}
+}
+
+func borrowDigest(p *BorrowParams) ([]byte, error) {
+	calldata := []byte{}
+	if p.Calldata != "" {
+		calldata = common.FromHex(p.Calldata)
+	}
+
+	return signature.BorrowUnlockHash(
+		calldata,
+		p.Amount.Int,
+		common.HexToAddress(p.Token),
+		new(big.Int).SetUint64(p.ChainId),
+		common.HexToAddress(p.Target),
+		p.Deadline,
+		common.HexToAddress(p.Caller),
+		common.HexToAddress(p.LiquidityPool),
+		p.Nonce.Int,
+	)
+}
+
+func allowOpenDigest(p *AllowOpenParams) ([]byte, error) {
+	return signature.AllowOpenUnlockHash(
+		p.ChainId,
+		common.HexToHash(p.OrderID),
+		common.HexToAddress(p.Destination),
+		common.HexToAddress(p.Settler),
+	)
+}
@@ -0,0 +1,213 @@
+package handlers_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethereumCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type VerifyHandlerTestSuite struct {
+	suite.Suite
+
+	mockKeyshares *mock_handlers.MockKeyshareFetcher
+
+	currentKey    *ecdsa.PrivateKey
+	historicalKey *ecdsa.PrivateKey
+	handler       *handlers.VerifyHandler
+}
+
+func TestRunVerifyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyHandlerTestSuite))
+}
+
+func (s *VerifyHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+
+	s.currentKey, _ = ethereumCrypto.GenerateKey()
+	s.historicalKey, _ = ethereumCrypto.GenerateKey()
+	s.mockKeyshares = mock_handlers.NewMockKeyshareFetcher(ctrl)
+	s.handler = handlers.NewVerifyHandler(
+		s.mockKeyshares,
+		[]common.Address{ethereumCrypto.PubkeyToAddress(s.historicalKey.PublicKey)},
+	)
+}
+
+func (s *VerifyHandlerTestSuite) expectCurrentKey() {
+	pub, err := crypto.NewECPoint(tss.S256(), s.currentKey.PublicKey.X, s.currentKey.PublicKey.Y)
+	s.Nil(err)
+	s.mockKeyshares.EXPECT().GetKeyshare().Return(keyshare.ECDSAKeyshare{
+		Key: keygen.LocalPartySaveData{
+			ECDSAPub: pub,
+		},
+	}, nil)
+}
+
+func (s *VerifyHandlerTestSuite) verify(body handlers.VerifyBody) (int, handlers.VerifyResponse) {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/v1/signatures/verify", bytes.NewReader(b))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleVerify(recorder, req)
+
+	resp := handlers.VerifyResponse{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &resp)
+	return recorder.Code, resp
+}
+
+func (s *VerifyHandlerTestSuite) sign(digest []byte, key *ecdsa.PrivateKey) string {
+	sig, err := ethereumCrypto.Sign(digest, key)
+	s.Nil(err)
+	sig[ethereumCrypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig)
+}
+
+func (s *VerifyHandlerTestSuite) Test_HandleVerify_MissingMessage() {
+	code, _ := s.verify(handlers.VerifyBody{
+		Signature: s.sign(common.Hash{}.Bytes(), s.currentKey),
+	})
+
+	s.Equal(http.StatusBadRequest, code)
+}
+
+func (s *VerifyHandlerTestSuite) Test_HandleVerify_InvalidSignature() {
+	code, _ := s.verify(handlers.VerifyBody{
+		Signature: "0x1234",
+		Digest:    common.Hash{}.Hex(),
+	})
+
+	s.Equal(http.StatusBadRequest, code)
+}
+
+func (s *VerifyHandlerTestSuite) Test_HandleVerify_ReportsAllInvalidFields() {
+	b, _ := json.Marshal(handlers.VerifyBody{
+		Signature: "0x1234",
+		Borrow: &handlers.BorrowParams{
+			LiquidityPool: "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+			Caller:        "0x1234",
+			Token:         "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+			Target:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+			Amount:        &handlers.BigInt{big.NewInt(1000)},
+			Calldata:      "invalid",
+		},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/signatures/verify", bytes.NewReader(b))
+	recorder := httptest.NewRecorder()
+
+	s.handler.HandleVerify(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+	resp := struct {
+		ErrorCode errcode.Code         `json:"errorCode"`
+		Fields    []errcode.FieldError `json:"fields"`
+	}{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &resp)
+	s.Nil(err)
+	s.Equal(errcode.InvalidRequest, resp.ErrorCode)
+	s.Equal([]errcode.FieldError{
+		{Field: "signature", Reason: "invalid"},
+		{Field: "borrow.chainId", Reason: "missing"},
+		{Field: "borrow.nonce", Reason: "missing"},
+		{Field: "borrow.caller", Reason: "invalid"},
+		{Field: "borrow.calldata", Reason: "invalid"},
+	}, resp.Fields)
+}
+
+func (s *VerifyHandlerTestSuite) Test_HandleVerify_Borrow_CurrentAddress() {
+	s.expectCurrentKey()
+	digest, err := signature.BorrowUnlockHash(
+		common.Hex2Bytes("abcd"),
+		big.NewInt(1000),
+		common.HexToAddress("0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"),
+		big.NewInt(10),
+		common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		1000,
+		common.HexToAddress("0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5"),
+		common.HexToAddress("0x1c3A03D04c026b1f4B4208D2ce053c5686E6FB8d"),
+		big.NewInt(1001),
+	)
+	s.Nil(err)
+
+	code, resp := s.verify(handlers.VerifyBody{
+		Signature: s.sign(digest, s.currentKey),
+		Borrow: &handlers.BorrowParams{
+			ChainId:       10,
+			LiquidityPool: "0x1c3A03D04c026b1f4B4208D2ce053c5686E6FB8d",
+			Caller:        "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5",
+			Token:         "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
+			Amount:        &handlers.BigInt{big.NewInt(1000)},
+			Target:        "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657",
+			Calldata:      "0xabcd",
+			Nonce:         &handlers.BigInt{big.NewInt(1001)},
+			Deadline:      1000,
+		},
+	})
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(handlers.VerifyResponse{
+		Digest:  digest,
+		Signer:  ethereumCrypto.PubkeyToAddress(s.currentKey.PublicKey).Hex(),
+		Valid:   true,
+		Current: true,
+	}, resp)
+}
+
+func (s *VerifyHandlerTestSuite) Test_HandleVerify_AllowOpen_HistoricalAddress() {
+	s.mockKeyshares.EXPECT().GetKeyshare().Return(keyshare.ECDSAKeyshare{}, fmt.Errorf("error on reading keyshare file"))
+	digest, err := signature.AllowOpenUnlockHash(
+		10,
+		common.HexToHash("0x0b2e0a2f6f3bb4e9b2d4b4b8b4f7c8e1e4f0f6c2a9f1d2b3c4d5e6f708192a3b"),
+		common.HexToAddress("0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5"),
+		common.HexToAddress("0x1c3A03D04c026b1f4B4208D2ce053c5686E6FB8d"),
+	)
+	s.Nil(err)
+
+	code, resp := s.verify(handlers.VerifyBody{
+		Signature: s.sign(digest, s.historicalKey),
+		AllowOpen: &handlers.AllowOpenParams{
+			ChainId:     10,
+			OrderID:     "0x0b2e0a2f6f3bb4e9b2d4b4b8b4f7c8e1e4f0f6c2a9f1d2b3c4d5e6f708192a3b",
+			Settler:     "0x1c3A03D04c026b1f4B4208D2ce053c5686E6FB8d",
+			Destination: "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5",
+		},
+	})
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(ethereumCrypto.PubkeyToAddress(s.historicalKey.PublicKey).Hex(), resp.Signer)
+	s.True(resp.Valid)
+	s.False(resp.Current)
+}
+
+func (s *VerifyHandlerTestSuite) Test_HandleVerify_Digest_UnknownSigner() {
+	s.expectCurrentKey()
+	key, _ := ethereumCrypto.GenerateKey()
+	digest := ethereumCrypto.Keccak256([]byte("digest"))
+
+	code, resp := s.verify(handlers.VerifyBody{
+		Signature: s.sign(digest, key),
+		Digest:    hexutil.Encode(digest),
+	})
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(ethereumCrypto.PubkeyToAddress(key.PublicKey).Hex(), resp.Signer)
+	s.False(resp.Valid)
+	s.False(resp.Current)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	_ "embed"
 	"net/http"
 	"time"
 
@@ -11,24 +12,72 @@ import (
 	"github.com/sprintertech/sprinter-signing/health"
 )
 
-func Serve(
-	ctx context.Context,
-	addr string,
+// OpenAPI is the OpenAPI document describing the routes registered by NewRouter
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+// NewRouter registers all signing API routes
+func NewRouter(
 	signingHandler *handlers.SigningHandler,
 	unlockHandler *handlers.UnlockHandler,
 	statusHandler *handlers.StatusHandler,
 	confirmationsHandler *handlers.ConfirmationsHandler,
-) {
+	batchHandler *handlers.BatchSigningHandler,
+	previewHandler *handlers.PreviewHandler,
+	verifyHandler *handlers.VerifyHandler,
+	firehoseHandler *handlers.FirehoseHandler,
+	historyHandler *handlers.SignatureHistoryHandler,
+	authenticator *handlers.Authenticator,
+	rateLimiter *handlers.RateLimiter,
+	drainGuard *handlers.DrainGuard,
+) *mux.Router {
+	protected := func(handler http.HandlerFunc) http.Handler {
+		return authenticator.Middleware(rateLimiter.Middleware(handler))
+	}
+	// signing routes start new tss processes and are rejected while draining
+	signing := func(handler http.HandlerFunc) http.Handler {
+		return drainGuard.Middleware(protected(handler))
+	}
+
 	r := mux.NewRouter()
-	r.HandleFunc("/v1/chains/{chainId:[0-9]+}/unlocks", unlockHandler.HandleUnlock).Methods("POST")
-	r.HandleFunc("/v1/chains/{chainId:[0-9]+}/signatures", signingHandler.HandleSigning).Methods("POST")
-	r.HandleFunc("/v1/chains/{chainId:[0-9]+}/signatures/{depositId}", statusHandler.HandleRequest).Methods("GET")
+	r.Handle("/v1/chains/{chainId:[0-9]+}/unlocks", signing(unlockHandler.HandleUnlock)).Methods("POST")
+	r.Handle("/v1/chains/{chainId:[0-9]+}/signatures", signing(signingHandler.HandleSigning)).Methods("POST")
+	r.Handle("/v1/chains/{chainId:[0-9]+}/signatures/preview", protected(previewHandler.HandlePreview)).Methods("POST")
+	r.Handle("/v1/chains/{chainId:[0-9]+}/signatures/{depositId}", rateLimiter.ClientMiddleware(http.HandlerFunc(statusHandler.HandleRequest))).Methods("GET")
+	r.Handle("/v1/chains/{chainId:[0-9]+}/signatures/{depositId}/status", rateLimiter.ClientMiddleware(http.HandlerFunc(statusHandler.HandleStatus))).Methods("GET")
+	r.Handle("/v1/signatures", protected(historyHandler.HandleHistory)).Methods("GET")
+	r.Handle("/v1/signatures/batch", signing(batchHandler.HandleBatchSigning)).Methods("POST")
+	r.HandleFunc("/v1/signatures/verify", verifyHandler.HandleVerify).Methods("POST")
+	r.Handle("/v1/signatures/stream", authenticator.Middleware(http.HandlerFunc(firehoseHandler.HandleFirehose))).Methods("GET")
 	r.HandleFunc("/v1/chains/{chainId:[0-9]+}/confirmations", confirmationsHandler.HandleRequest).Methods("GET")
+	r.HandleFunc("/v1/openapi.json", handleOpenAPI).Methods("GET")
 	r.HandleFunc("/health", health.HealthHandler()).Methods("GET")
+	return r
+}
 
+// NewAdminRouter registers the operator admin routes that should only be
+// served on a private address
+func NewAdminRouter(adminHandler *handlers.AdminHandler) *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/sessions", adminHandler.HandleSessions).Methods("GET")
+	r.HandleFunc("/v1/sessions/{sessionId}", adminHandler.HandleCancelSession).Methods("DELETE")
+	r.HandleFunc("/v1/blame", adminHandler.HandleBlame).Methods("GET")
+	r.HandleFunc("/v1/peers", adminHandler.HandlePeers).Methods("GET")
+	r.HandleFunc("/v1/keyshare", adminHandler.HandleKeyshare).Methods("GET")
+	r.HandleFunc("/v1/log-level", adminHandler.HandleLogLevel).Methods("GET")
+	r.HandleFunc("/v1/log-level", adminHandler.HandleSetLogLevel).Methods("PUT")
+	return r
+}
+
+func Serve(
+	ctx context.Context,
+	addr string,
+	handler http.Handler,
+) {
 	server := &http.Server{
 		Addr:              addr,
-		Handler:           r,
+		Handler:           handler,
 		ReadHeaderTimeout: time.Second * 15,
 	}
 	go func() {
@@ -38,6 +87,33 @@ func Serve(
 		}
 	}()
 
+	shutdown(ctx, server)
+}
+
+// ServeTLS serves the handler over TLS with certificates provided by the cert reloader
+func ServeTLS(
+	ctx context.Context,
+	addr string,
+	handler http.Handler,
+	certs *CertReloader,
+) {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: time.Second * 15,
+		TLSConfig:         certs.TLSConfig(),
+	}
+	go func() {
+		log.Info().Msgf("Starting TLS server on %s", addr)
+		if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			panic(err)
+		}
+	}()
+
+	shutdown(ctx, server)
+}
+
+func shutdown(ctx context.Context, server *http.Server) {
 	<-ctx.Done()
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -49,3 +125,9 @@ func Serve(
 		log.Info().Msgf("Server shut down gracefully.")
 	}
 }
+
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(OpenAPI)
+}
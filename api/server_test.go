@@ -0,0 +1,120 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sprintertech/sprinter-signing/api"
+	"github.com/sprintertech/sprinter-signing/api/handlers"
+	mock_handlers "github.com/sprintertech/sprinter-signing/api/handlers/mock"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type RouterTestSuite struct {
+	suite.Suite
+
+	mockDrainer          *mock_handlers.MockDrainer
+	mockSignatureQuerier *mock_handlers.MockSignatureQuerier
+	router               *mux.Router
+}
+
+func TestRunRouterTestSuite(t *testing.T) {
+	suite.Run(t, new(RouterTestSuite))
+}
+
+func (s *RouterTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	metrics := mock_handlers.NewMockRateLimitMetrics(ctrl)
+	metrics.EXPECT().TrackInFlightRequests(gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockDrainer = mock_handlers.NewMockDrainer(ctrl)
+	s.mockSignatureQuerier = mock_handlers.NewMockSignatureQuerier(ctrl)
+
+	s.router = api.NewRouter(
+		handlers.NewSigningHandler(nil, nil, nil, nil),
+		handlers.NewUnlockHandler(nil, nil),
+		handlers.NewStatusHandler(nil, nil, nil),
+		handlers.NewConfirmationsHandler(nil),
+		handlers.NewBatchSigningHandler(nil, nil, nil, nil),
+		handlers.NewPreviewHandler(nil),
+		handlers.NewVerifyHandler(nil, nil),
+		handlers.NewFirehoseHandler(nil, nil, nil),
+		handlers.NewSignatureHistoryHandler(s.mockSignatureQuerier),
+		handlers.NewAuthenticator(nil),
+		handlers.NewRateLimiter(relayer.RateLimitConfig{}, metrics, cache.NewStatusCache()),
+		handlers.NewDrainGuard(s.mockDrainer),
+	)
+}
+
+func (s *RouterTestSuite) Test_OpenAPI_MatchesRoutes() {
+	doc := struct {
+		Paths map[string]map[string]any `json:"paths"`
+	}{}
+	err := json.Unmarshal(api.OpenAPI, &doc)
+	s.Nil(err)
+
+	documented := make(map[string]struct{})
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			documented[strings.ToUpper(method)+" "+path] = struct{}{}
+		}
+	}
+
+	routes := make(map[string]struct{})
+	pattern := regexp.MustCompile(`\{(\w+):[^}]+\}`)
+	err = s.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			routes[method+" "+pattern.ReplaceAllString(path, "{$1}")] = struct{}{}
+		}
+		return nil
+	})
+	s.Nil(err)
+
+	s.Equal(routes, documented)
+}
+
+func (s *RouterTestSuite) Test_Draining_RejectsSigningRequests() {
+	s.mockDrainer.EXPECT().Draining().Return(true).Times(3)
+
+	for _, path := range []string{"/v1/chains/1/signatures", "/v1/chains/1/unlocks", "/v1/signatures/batch"} {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(`{}`)))
+		s.router.ServeHTTP(recorder, req)
+
+		s.Equal(http.StatusServiceUnavailable, recorder.Code)
+	}
+}
+
+func (s *RouterTestSuite) Test_Draining_ServesReadRoutes() {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains/1/signatures/1000", nil)
+	s.router.ServeHTTP(recorder, req)
+
+	s.NotEqual(http.StatusServiceUnavailable, recorder.Code)
+}
+
+func (s *RouterTestSuite) Test_History_GetWithoutBody() {
+	s.mockSignatureQuerier.EXPECT().Signatures("", gomock.Any(), gomock.Any(), handlers.HISTORY_DEFAULT_LIMIT).Return([]cache.SignatureRecord{}, nil)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/signatures", nil)
+	s.router.ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusOK, recorder.Code)
+}
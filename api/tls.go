@@ -0,0 +1,121 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+)
+
+// CertReloader provides the TLS configuration of the signing API and reloads
+// the certificate and the client CA once the files change on disk
+type CertReloader struct {
+	config relayer.TLSConfig
+
+	lock      sync.Mutex
+	modTime   time.Time
+	tlsConfig *tls.Config
+}
+
+func NewCertReloader(config relayer.TLSConfig) (*CertReloader, error) {
+	r := &CertReloader{
+		config: config,
+	}
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	r.modTime = modTime
+	r.tlsConfig = tlsConfig
+	return r, nil
+}
+
+// TLSConfig returns the server TLS configuration that resolves the
+// current certificate and client CA on each handshake
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.configForClient,
+	}
+}
+
+func (r *CertReloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	modTime, err := r.latestModTime()
+	if err != nil || !modTime.After(r.modTime) {
+		return r.tlsConfig, nil
+	}
+
+	// files might be written partially so the previous configuration is kept
+	// until the new one loads successfully
+	tlsConfig, err := r.load()
+	if err != nil {
+		log.Warn().Msgf("Failed reloading tls certificates: %s", err)
+		return r.tlsConfig, nil
+	}
+
+	log.Info().Msgf("Reloaded tls certificates")
+	r.modTime = modTime
+	r.tlsConfig = tlsConfig
+	return r.tlsConfig, nil
+}
+
+func (r *CertReloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading tls certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if r.config.ClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	caBytes, err := os.ReadFile(r.config.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading tls client ca: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("no certificates found in tls client ca %s", r.config.ClientCAFile)
+	}
+
+	// client certificates are optional as clients can still authenticate by signing requests
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	return tlsConfig, nil
+}
+
+func (r *CertReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.config.CertFile, r.config.KeyFile, r.config.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
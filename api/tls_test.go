@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sprintertech/sprinter-signing/api"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/stretchr/testify/suite"
+)
+
+type CertReloaderTestSuite struct {
+	suite.Suite
+
+	config relayer.TLSConfig
+}
+
+func TestRunCertReloaderTestSuite(t *testing.T) {
+	suite.Run(t, new(CertReloaderTestSuite))
+}
+
+func (s *CertReloaderTestSuite) SetupTest() {
+	dir := s.T().TempDir()
+	s.config = relayer.TLSConfig{
+		CertFile:     filepath.Join(dir, "api.crt"),
+		KeyFile:      filepath.Join(dir, "api.key"),
+		ClientCAFile: filepath.Join(dir, "clients.crt"),
+	}
+}
+
+// writeCertificate writes a self signed certificate for the common name as both
+// the server certificate and the client CA
+func (s *CertReloaderTestSuite) writeCertificate(commonName string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Nil(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Nil(err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	s.Nil(err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})
+	s.Nil(os.WriteFile(s.config.CertFile, certPEM, 0600))
+	s.Nil(os.WriteFile(s.config.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	s.Nil(os.WriteFile(s.config.ClientCAFile, certPEM, 0600))
+	for _, file := range []string{s.config.CertFile, s.config.KeyFile, s.config.ClientCAFile} {
+		s.Nil(os.Chtimes(file, modTime, modTime))
+	}
+}
+
+func (s *CertReloaderTestSuite) commonName(reloader *api.CertReloader) string {
+	config, err := reloader.TLSConfig().GetConfigForClient(nil)
+	s.Nil(err)
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	s.Nil(err)
+	return cert.Subject.CommonName
+}
+
+func (s *CertReloaderTestSuite) Test_NewCertReloader_MissingFiles() {
+	_, err := api.NewCertReloader(s.config)
+
+	s.NotNil(err)
+}
+
+func (s *CertReloaderTestSuite) Test_TLSConfig_ReloadsChangedCertificate() {
+	s.writeCertificate("initial", time.Now().Add(-time.Minute))
+	reloader, err := api.NewCertReloader(s.config)
+	s.Nil(err)
+	s.Equal("initial", s.commonName(reloader))
+
+	s.writeCertificate("renewed", time.Now())
+
+	s.Equal("renewed", s.commonName(reloader))
+	config, err := reloader.TLSConfig().GetConfigForClient(nil)
+	s.Nil(err)
+	s.NotNil(config.ClientCAs)
+}
+
+func (s *CertReloaderTestSuite) Test_TLSConfig_KeepsCertificateOnInvalidFiles() {
+	s.writeCertificate("initial", time.Now().Add(-time.Minute))
+	reloader, err := api.NewCertReloader(s.config)
+	s.Nil(err)
+
+	s.Nil(os.WriteFile(s.config.CertFile, []byte("invalid"), 0600))
+
+	s.Equal("initial", s.commonName(reloader))
+}
@@ -0,0 +1,57 @@
+// Package types contains the wire types of the signing API shared by
+// the API handlers and the API client.
+package types
+
+import (
+	"time"
+
+	"github.com/sprintertech/sprinter-signing/errcode"
+)
+
+const (
+	CLIENT_HEADER    = "X-Client-Id"
+	TIMESTAMP_HEADER = "X-Timestamp"
+	SIGNATURE_HEADER = "X-Signature"
+
+	// STATUS_BUFFER_SIZE is the number of status transitions buffered for each status subscriber
+	STATUS_BUFFER_SIZE = 32
+)
+
+type RequestState string
+
+const (
+	ReceivedState             RequestState = "received"
+	VerifyingState            RequestState = "verifying"
+	WaitingConfirmationsState RequestState = "waiting_confirmations"
+	SigningState              RequestState = "signing"
+	SignedState               RequestState = "signed"
+	FailedState               RequestState = "failed"
+)
+
+// RequestStatus represents a single state transition of a signing request
+type RequestStatus struct {
+	Sequence              uint64         `json:"sequence"`
+	ID                    string         `json:"id"`
+	Caller                string         `json:"caller,omitempty"`
+	ParamsHash            string         `json:"paramsHash,omitempty"`
+	Digest                string         `json:"digest,omitempty"`
+	State                 RequestState   `json:"state"`
+	Confirmations         uint64         `json:"confirmations,omitempty"`
+	RequiredConfirmations uint64         `json:"requiredConfirmations,omitempty"`
+	Reason                string         `json:"reason,omitempty"`
+	ErrorCode             errcode.Code   `json:"errorCode,omitempty"`
+	Details               map[string]any `json:"details,omitempty"`
+	Timestamp             time.Time      `json:"timestamp"`
+}
+
+// Final returns true if no further state transitions are expected for the request
+func (s RequestStatus) Final() bool {
+	return s.State == SignedState || s.State == FailedState
+}
+
+// StatusResponse is the latest status of the signing request
+// with the signature once the request is signed
+type StatusResponse struct {
+	RequestStatus
+	Signature string `json:"signature,omitempty"`
+}
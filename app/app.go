@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -28,6 +29,7 @@ import (
 	solverConfig "github.com/sprintertech/solver-config/go/config"
 	"github.com/sprintertech/sprinter-signing/api"
 	"github.com/sprintertech/sprinter-signing/api/handlers"
+	"github.com/sprintertech/sprinter-signing/audit"
 	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains/evm"
 	"github.com/sprintertech/sprinter-signing/chains/evm/calls/contracts"
@@ -37,20 +39,27 @@ import (
 	"github.com/sprintertech/sprinter-signing/metrics"
 
 	lifiConfig "github.com/sprintertech/lifi-solver/pkg/config"
+	"github.com/sprintertech/sprinter-signing/chains/batch"
+	batchMessage "github.com/sprintertech/sprinter-signing/chains/batch/message"
 	"github.com/sprintertech/sprinter-signing/chains/lighter"
 	lighterMessage "github.com/sprintertech/sprinter-signing/chains/lighter/message"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sprintertech/sprinter-signing/comm/elector"
 	"github.com/sprintertech/sprinter-signing/comm/p2p"
 	"github.com/sprintertech/sprinter-signing/config"
+	"github.com/sprintertech/sprinter-signing/health"
 	"github.com/sprintertech/sprinter-signing/jobs"
 	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/sprintertech/sprinter-signing/lvldb"
 	"github.com/sprintertech/sprinter-signing/price"
 	"github.com/sprintertech/sprinter-signing/protocol/across"
 	"github.com/sprintertech/sprinter-signing/protocol/lifi"
 	lighterAPI "github.com/sprintertech/sprinter-signing/protocol/lighter"
 	"github.com/sprintertech/sprinter-signing/topology"
 	"github.com/sprintertech/sprinter-signing/tss"
+	tssCommon "github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
+	"github.com/sprintertech/sprinter-signing/webhook"
 	coreEvm "github.com/sygmaprotocol/sygma-core/chains/evm"
 	evmClient "github.com/sygmaprotocol/sygma-core/chains/evm/client"
 	coreListener "github.com/sygmaprotocol/sygma-core/chains/evm/listener"
@@ -60,9 +69,10 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/store"
-	"github.com/sygmaprotocol/sygma-core/store/lvldb"
 )
 
+const DRAIN_INTERVAL = 500 * time.Millisecond
+
 var Version string
 
 //nolint:gocognit
@@ -124,11 +134,33 @@ func Run() error {
 	communication := p2p.NewCommunication(host, "p2p/sprinter", sygmaMetrics)
 	electorFactory := elector.NewCoordinatorElectorFactory(host, configuration.RelayerConfig.BullyConfig)
 	coordinator := tss.NewCoordinator(host, communication, sygmaMetrics, electorFactory)
+	for _, process := range configuration.RelayerConfig.BullyConfig.Processes {
+		coordinator.Electors[tssCommon.ProcessType(process)] = elector.Bully
+	}
+	go coordinator.Listen(ctx)
 
 	db, err := lvldb.NewLvlDB(viper.GetString(config.BlockstoreFlagName))
 	panicOnError(err)
 	blockstore := store.NewBlockStore(db)
 	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath)
+	auditLog, err := audit.NewLog(configuration.RelayerConfig.AuditLogPath, host.ID())
+	panicOnError(err)
+	defer auditLog.Close()
+
+	var presignatures *signing.PresignaturePool
+	if configuration.RelayerConfig.PresignaturePoolSize > 0 {
+		presignatures = signing.NewPresignaturePool(sygmaMetrics)
+		presignatureGenerator := signing.NewPresignatureGenerator(
+			configuration.RelayerConfig.PresignaturePoolSize,
+			host,
+			communication,
+			keyshareStore,
+			coordinator,
+			presignatures,
+		)
+		go presignatureGenerator.Listen(ctx)
+		go presignatureGenerator.Run(ctx)
+	}
 
 	msgChan := make(chan []*message.Message)
 	sigChn := make(chan interface{})
@@ -137,12 +169,30 @@ func Run() error {
 		configuration.RelayerConfig.CoinmarketcapConfig.Url,
 		configuration.RelayerConfig.CoinmarketcapConfig.ApiKey)
 
-	signatureCache := cache.NewSignatureCache(communication, sygmaMetrics)
+	signatureStore := cache.NewSignatureStore(db, configuration.RelayerConfig.SignatureRetention)
+	go signatureStore.Run(ctx)
+	statusCache := cache.NewStatusCache()
+	signatureCache := cache.NewSignatureCache(communication, host, sygmaMetrics, statusCache, signatureStore, keyshareStore)
 	go signatureCache.Watch(ctx, sigChn)
+	healthChecks := health.NewHealth()
+	healthChecks.AddLivenessCheck("signature-cache", signatureCache)
+	healthChecks.AddReadinessCheck("keyshare", health.KeyshareCheck(keyshareStore))
+	healthChecks.AddReadinessCheck("peers", health.PeersCheck(host.ID(), topologyStore, host.Network()))
+	healthChecks.AddReadinessCheck("drain", health.DrainCheck(coordinator))
 
 	supportedChains := make(map[uint64]struct{})
 	confirmationsPerChain := make(map[uint64]map[uint64]uint64)
 	domains := make(map[uint64]relayer.RelayedChain)
+	batchMh := batchMessage.NewBatchMessageHandler(
+		coordinator,
+		host,
+		communication,
+		keyshareStore,
+		auditLog,
+		statusCache,
+		sigChn,
+	)
+	previewHandler := handlers.NewPreviewHandler(supportedChains)
 
 	solverConfigOpts := []solverConfig.Option{
 		solverConfig.WithCredentials(
@@ -156,8 +206,9 @@ func Run() error {
 	solverConfig, err := solverConfig.FetchSolverConfig(ctx, solverConfigOpts...)
 	panicOnError(err)
 
-	configChanged, err := config.StartConfigWatcher(ctx, solverConfig, solverConfigOpts)
+	configWatcher, err := config.StartConfigWatcher(ctx, solverConfig, solverConfigOpts)
 	panicOnError(err)
+	healthChecks.AddReadinessCheck("solver-config", health.SolverConfigCheck(configWatcher, config.ConfigWatcherInterval*5))
 
 	keyshare, err := keyshareStore.GetKeyshare()
 	var mpcAddress common.Address
@@ -231,6 +282,10 @@ func Run() error {
 				panicOnError(err)
 
 				log.Info().Uint64("chain", *c.GeneralChainConfig.Id).Msgf("Registering EVM domain")
+				healthChecks.AddReadinessCheck(
+					fmt.Sprintf("chain-%d", *c.GeneralChainConfig.Id),
+					// nolint:gosec
+					health.ChainHeadCheck(client, time.Duration(c.GeneralChainConfig.Blocktime)*time.Second))
 
 				l := log.With().Str("chain", fmt.Sprintf("%v", c.GeneralChainConfig.Name)).Uint64("domainID", *c.GeneralChainConfig.Id)
 
@@ -241,6 +296,7 @@ func Run() error {
 					c.ConfirmationsByValue,
 					// nolint:gosec
 					time.Duration(c.GeneralChainConfig.Blocktime)*time.Second,
+					statusCache,
 				)
 
 				mh := message.NewMessageHandler()
@@ -260,12 +316,17 @@ func Run() error {
 						host,
 						communication,
 						keyshareStore,
+						auditLog,
+						presignatures,
 						acrossDepositFetcher,
 						watcher,
+						statusCache,
 						sigChn)
 					go acrossMh.Listen(ctx)
 
 					mh.RegisterMessageHandler(message.MessageType(comm.AcrossMsg.String()), acrossMh)
+					batchMh.RegisterDigester(*c.GeneralChainConfig.Id, message.MessageType(comm.AcrossMsg.String()), acrossMh)
+					previewHandler.RegisterPreviewer(*c.GeneralChainConfig.Id, message.MessageType(comm.AcrossMsg.String()), acrossMh)
 					supportedChains[*c.GeneralChainConfig.Id] = struct{}{}
 					confirmationsPerChain[*c.GeneralChainConfig.Id] = c.ConfirmationsByValue
 				}
@@ -294,16 +355,21 @@ func Run() error {
 						host,
 						communication,
 						keyshareStore,
+						auditLog,
+						presignatures,
 						watcher,
 						tokenStore,
 						lifiApi,
 						orderPricer,
 						router.NewRouter(resolver, nil, nil, lifiConfig.Routes),
 						lifiValidator,
+						statusCache,
 						sigChn,
 					)
 					go lifiMh.Listen(ctx)
 					mh.RegisterMessageHandler(message.MessageType(comm.LifiEscrowMsg.String()), lifiMh)
+					batchMh.RegisterDigester(*c.GeneralChainConfig.Id, message.MessageType(comm.LifiEscrowMsg.String()), lifiMh)
+					previewHandler.RegisterPreviewer(*c.GeneralChainConfig.Id, message.MessageType(comm.LifiEscrowMsg.String()), lifiMh)
 					supportedChains[*c.GeneralChainConfig.Id] = struct{}{}
 					confirmationsPerChain[*c.GeneralChainConfig.Id] = c.ConfirmationsByValue
 				}
@@ -316,6 +382,9 @@ func Run() error {
 						host,
 						communication,
 						keyshareStore,
+						auditLog,
+						presignatures,
+						statusCache,
 						sigChn,
 					)
 					go srcMh.Listen(ctx)
@@ -323,6 +392,8 @@ func Run() error {
 						message.MessageType(comm.SprinterCreditMsg.String()),
 						srcMh,
 					)
+					batchMh.RegisterDigester(*c.GeneralChainConfig.Id, message.MessageType(comm.SprinterCreditMsg.String()), srcMh)
+					previewHandler.RegisterPreviewer(*c.GeneralChainConfig.Id, message.MessageType(comm.SprinterCreditMsg.String()), srcMh)
 				}
 
 				lifiUnlockMh := evmMessage.NewLifiUnlockHandler(
@@ -332,6 +403,8 @@ func Run() error {
 					host,
 					communication,
 					keyshareStore,
+					auditLog,
+					presignatures,
 				)
 				go lifiUnlockMh.Listen(ctx)
 				mh.RegisterMessageHandler(message.MessageType(comm.LifiUnlockMsg.String()), lifiUnlockMh)
@@ -373,12 +446,20 @@ func Run() error {
 		host,
 		communication,
 		keyshareStore,
+		auditLog,
+		presignatures,
+		statusCache,
 		sigChn,
 	)
 	go lighterMessageHandler.Listen(ctx)
 	lighterChain := lighter.NewLighterChain(lighterMessageHandler)
 	domains[lighter.LIGHTER_DOMAIN_ID] = lighterChain
 	supportedChains[lighter.LIGHTER_DOMAIN_ID] = struct{}{}
+	batchMh.RegisterDigester(lighter.LIGHTER_DOMAIN_ID, message.MessageType(comm.LighterMsg.String()), lighterMessageHandler)
+	previewHandler.RegisterPreviewer(lighter.LIGHTER_DOMAIN_ID, message.MessageType(comm.LighterMsg.String()), lighterMessageHandler)
+
+	go batchMh.Listen(ctx)
+	domains[batch.BATCH_DOMAIN_ID] = batch.NewBatchChain(batchMh)
 
 	go jobs.StartCommunicationHealthCheckJob(host, configuration.RelayerConfig.MpcConfig.CommHealthCheckInterval, sygmaMetrics)
 
@@ -400,34 +481,130 @@ func Run() error {
 		log.Info().Msg("Relayer not part of MPC. Waiting for refresh event...")
 	}
 
-	signingHandler := handlers.NewSigningHandler(msgChan, supportedChains)
-	statusHandler := handlers.NewStatusHandler(signatureCache, supportedChains)
+	var callbacks handlers.CallbackRegistrar
+	if configuration.RelayerConfig.WebhookConfig.Secret != "" {
+		deadLetterFile, err := os.OpenFile(
+			configuration.RelayerConfig.WebhookConfig.DeadLetterPath,
+			os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+			0600)
+		panicOnError(err)
+		defer deadLetterFile.Close()
+		webhooks, err := webhook.NewDispatcher(
+			ctx,
+			statusCache,
+			signatureCache,
+			configuration.RelayerConfig.WebhookConfig,
+			deadLetterFile)
+		panicOnError(err)
+		callbacks = webhooks
+	} else {
+		log.Warn().Msg("Webhook secret not set, signing callbacks are disabled")
+	}
+
+	signingHandler := handlers.NewSigningHandler(msgChan, supportedChains, callbacks, statusCache)
+	statusHandler := handlers.NewStatusHandler(signatureCache, statusCache, supportedChains)
 	confirmationsHandler := handlers.NewConfirmationsHandler(confirmationsPerChain)
 	unlockHandler := handlers.NewUnlockHandler(msgChan, supportedChains)
-	go api.Serve(
-		ctx,
-		configuration.RelayerConfig.ApiAddr,
+	batchHandler := handlers.NewBatchSigningHandler(msgChan, supportedChains, callbacks, statusCache)
+	firehoseHandler := handlers.NewFirehoseHandler(signatureCache, statusCache, supportedChains)
+	historyHandler := handlers.NewSignatureHistoryHandler(signatureStore)
+	historicalMpcAddresses := configuration.RelayerConfig.MpcConfig.HistoricalAddresses
+	if solverConfig.ProtocolsMetadata.Sprinter.MpcAddress != "" {
+		historicalMpcAddresses = append(historicalMpcAddresses, common.HexToAddress(solverConfig.ProtocolsMetadata.Sprinter.MpcAddress))
+	}
+	verifyHandler := handlers.NewVerifyHandler(keyshareStore, historicalMpcAddresses)
+	if len(configuration.RelayerConfig.ApiCredentials) == 0 {
+		log.Warn().Msg("No API credentials configured. Signing API is not authenticated")
+	}
+	authenticator := handlers.NewAuthenticator(configuration.RelayerConfig.ApiCredentials)
+	rateLimiter := handlers.NewRateLimiter(configuration.RelayerConfig.RateLimitConfig, sygmaMetrics, statusCache)
+	router := api.NewRouter(
 		signingHandler,
 		unlockHandler,
 		statusHandler,
-		confirmationsHandler)
+		confirmationsHandler,
+		batchHandler,
+		previewHandler,
+		verifyHandler,
+		firehoseHandler,
+		historyHandler,
+		authenticator,
+		rateLimiter,
+		handlers.NewDrainGuard(coordinator))
+	apiCtx, stopAPI := context.WithCancel(ctx)
+	defer stopAPI()
+	if configuration.RelayerConfig.TLSConfig.Enabled() {
+		certs, err := api.NewCertReloader(configuration.RelayerConfig.TLSConfig)
+		panicOnError(err)
+		go api.ServeTLS(apiCtx, configuration.RelayerConfig.ApiAddr, router, certs)
+	} else {
+		go api.Serve(apiCtx, configuration.RelayerConfig.ApiAddr, router)
+	}
+	go api.Serve(ctx, fmt.Sprintf(":%d", configuration.RelayerConfig.HealthPort), healthChecks.Router())
+
+	if configuration.RelayerConfig.AdminAddr != "" {
+		adminHandler := handlers.NewAdminHandler(host.ID(), coordinator, coordinator.Blame, topologyStore, keyshareStore, host.Network())
+		go api.Serve(ctx, configuration.RelayerConfig.AdminAddr, api.NewAdminRouter(adminHandler))
+	}
 
 	for {
 		select {
 		case sig := <-sysErr:
 			{
 				log.Info().Msgf("terminating got ` [%v] signal", sig)
+				drain(stopAPI, coordinator, statusCache, configuration.RelayerConfig.DrainTimeout)
 				return nil
 			}
-		case <-configChanged:
+		case <-configWatcher.Changed():
 			{
 				log.Info().Msgf("terminating to reload config")
+				drain(stopAPI, coordinator, statusCache, configuration.RelayerConfig.DrainTimeout)
 				return nil
 			}
 		}
 	}
 }
 
+// drain announces to peers that the relayer is leaving, which also rejects new
+// signing API requests. It then waits for requests in progress and pending tss
+// processes to finish until the timeout, after which remaining processes are handed
+// off. The API keeps serving statuses and signatures until the drain completes.
+func drain(stopAPI context.CancelFunc, coordinator *tss.Coordinator, statuses *cache.StatusCache, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	defer stopAPI()
+
+	coordinator.Leave()
+
+	requests := statuses.InProgress()
+	log.Info().Msgf("Draining %d requests in progress", len(requests))
+	ticker := time.NewTicker(DRAIN_INTERVAL)
+	defer ticker.Stop()
+	for len(requests) != 0 {
+		select {
+		case <-ticker.C:
+			{
+				requests = slices.DeleteFunc(requests, func(id string) bool {
+					status, err := statuses.Status(id)
+					return err != nil || status.Final()
+				})
+			}
+		case <-ctx.Done():
+			{
+				log.Warn().Msgf("Drain timeout reached with %d requests in progress", len(requests))
+				requests = nil
+			}
+		}
+	}
+
+	err := coordinator.Drain(ctx)
+	if err != nil {
+		log.Warn().Msgf("Failed draining tss processes: %s", err)
+		return
+	}
+	log.Info().Msg("Successfully drained relayer")
+}
+
 func panicOnError(err error) {
 	if err != nil {
 		panic(err)
@@ -0,0 +1,267 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Request describes the signing request of an audited digest
+type Request struct {
+	Protocol    string          `json:"protocol"`
+	Params      json.RawMessage `json:"params,omitempty"`
+	Coordinator peer.ID         `json:"coordinator"`
+}
+
+// NewRequest creates the audit request with JSON encoded request parameters
+func NewRequest(protocol string, params any, coordinator peer.ID) Request {
+	paramBytes, _ := json.Marshal(params)
+	return Request{
+		Protocol:    protocol,
+		Params:      paramBytes,
+		Coordinator: coordinator,
+	}
+}
+
+// Entry is a single record of a digest signed by the MPC key. Each entry
+// commits to the previous one through its hash so that modified, removed
+// or reordered entries are detected when the chain is verified.
+type Entry struct {
+	Sequence  uint64  `json:"sequence"`
+	Peer      peer.ID `json:"peer"`
+	Digest    string  `json:"digest"`
+	SessionID string  `json:"sessionId"`
+	Request
+	Peers     []peer.ID `json:"peers"`
+	StartedAt time.Time `json:"startedAt"`
+	SignedAt  time.Time `json:"signedAt"`
+	PrevHash  string    `json:"prevHash"`
+	Hash      string    `json:"hash"`
+}
+
+// ComputeHash returns the hash of the entry content chained to the previous entry hash
+func (e Entry) ComputeHash() (string, error) {
+	e.Hash = ""
+	entryBytes, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(entryBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Log is an append-only hash chained log of signed digests written by the relayer
+type Log struct {
+	peer peer.ID
+
+	lock     sync.Mutex
+	file     *os.File
+	sequence uint64
+	lastHash string
+}
+
+// NewLog opens the audit log at the path and verifies the existing entries
+// so that new entries are only appended to an intact chain
+func NewLog(path string, peer peer.ID) (*Log, error) {
+	entries, err := ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	err = Verify(entries)
+	if err != nil {
+		return nil, fmt.Errorf("audit log %s is corrupted: %w", path, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{
+		peer: peer,
+		file: file,
+	}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		l.sequence = last.Sequence
+		l.lastHash = last.Hash
+	}
+	return l, nil
+}
+
+// Append chains the entry to the last entry of the log and writes it to disk
+func (l *Log) Append(entry Entry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	entry.Sequence = l.sequence + 1
+	entry.Peer = l.peer
+	entry.StartedAt = entry.StartedAt.UTC()
+	entry.SignedAt = entry.SignedAt.UTC()
+	entry.PrevHash = l.lastHash
+	hash, err := entry.ComputeHash()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = l.file.Write(append(entryBytes, '\n'))
+	if err != nil {
+		return err
+	}
+	err = l.file.Sync()
+	if err != nil {
+		return err
+	}
+
+	l.sequence = entry.Sequence
+	l.lastHash = entry.Hash
+	return nil
+}
+
+func (l *Log) Close() error {
+	return l.file.Close()
+}
+
+// ReadFile reads all entries of the audit log at the path
+func ReadFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
+
+// Read decodes newline delimited audit log entries
+func Read(r io.Reader) ([]Entry, error) {
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		entry := Entry{}
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry on line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Verify checks that the entries are sequential, written by the same peer
+// and that each entry hash commits to the entry and the previous entry
+func Verify(entries []Entry) error {
+	prevHash := ""
+	for i, entry := range entries {
+		if entry.Sequence != uint64(i)+1 {
+			return fmt.Errorf("entry %d has sequence %d", i+1, entry.Sequence)
+		}
+		if entry.Peer != entries[0].Peer {
+			return fmt.Errorf("entry %d written by peer %s instead of %s", entry.Sequence, entry.Peer, entries[0].Peer)
+		}
+		if entry.PrevHash != prevHash {
+			return fmt.Errorf("entry %d does not chain to the previous entry", entry.Sequence)
+		}
+
+		hash, err := entry.ComputeHash()
+		if err != nil {
+			return err
+		}
+		if hash != entry.Hash {
+			return fmt.Errorf("entry %d hash mismatch", entry.Sequence)
+		}
+		prevHash = entry.Hash
+	}
+	return nil
+}
+
+// Discrepancy is a digest that was recorded by some peers of its signing
+// subset but is missing from the audit logs of other peers of the subset
+type Discrepancy struct {
+	Digest      string    `json:"digest"`
+	SessionID   string    `json:"sessionId"`
+	RecordedBy  []peer.ID `json:"recordedBy"`
+	MissingFrom []peer.ID `json:"missingFrom"`
+}
+
+// Compare cross checks the audit logs of multiple peers. A digest is expected
+// in the logs of all compared peers that participated in signing it. Peers
+// are identified by their entries so logs without entries are not compared.
+func Compare(logs [][]Entry) []Discrepancy {
+	type signedDigest struct {
+		digest    string
+		sessionID string
+	}
+
+	comparedPeers := make(map[peer.ID]struct{})
+	recorded := make(map[signedDigest]map[peer.ID]struct{})
+	participants := make(map[signedDigest]map[peer.ID]struct{})
+	order := make([]signedDigest, 0)
+	for _, entries := range logs {
+		for _, entry := range entries {
+			comparedPeers[entry.Peer] = struct{}{}
+
+			key := signedDigest{digest: entry.Digest, sessionID: entry.SessionID}
+			if _, ok := recorded[key]; !ok {
+				recorded[key] = make(map[peer.ID]struct{})
+				participants[key] = make(map[peer.ID]struct{})
+				order = append(order, key)
+			}
+			recorded[key][entry.Peer] = struct{}{}
+			for _, p := range entry.Peers {
+				participants[key][p] = struct{}{}
+			}
+		}
+	}
+
+	discrepancies := make([]Discrepancy, 0)
+	for _, key := range order {
+		missing := make([]peer.ID, 0)
+		for p := range participants[key] {
+			if _, ok := comparedPeers[p]; !ok {
+				continue
+			}
+			if _, ok := recorded[key][p]; !ok {
+				missing = append(missing, p)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		recordedBy := make([]peer.ID, 0, len(recorded[key]))
+		for p := range recorded[key] {
+			recordedBy = append(recordedBy, p)
+		}
+		slices.Sort(recordedBy)
+		slices.Sort(missing)
+		discrepancies = append(discrepancies, Discrepancy{
+			Digest:      key.digest,
+			SessionID:   key.sessionID,
+			RecordedBy:  recordedBy,
+			MissingFrom: missing,
+		})
+	}
+	return discrepancies
+}
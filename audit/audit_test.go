@@ -0,0 +1,131 @@
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/audit"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	peerA, _ = peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	peerB, _ = peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	peerC, _ = peer.Decode("QmYayosTHxL2xa4jyrQ2PmbhGbrkSxsGM1kzXLTT8SsLVy")
+)
+
+type AuditLogTestSuite struct {
+	suite.Suite
+
+	path string
+}
+
+func TestRunAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditLogTestSuite))
+}
+
+func (s *AuditLogTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "audit.log")
+}
+
+func (s *AuditLogTestSuite) entry(digest string, sessionID string, peers ...peer.ID) audit.Entry {
+	return audit.Entry{
+		Digest:    digest,
+		SessionID: sessionID,
+		Request:   audit.NewRequest("across", map[string]string{"depositId": "1"}, peerA),
+		Peers:     peers,
+		StartedAt: time.Now().Add(-time.Second),
+		SignedAt:  time.Now(),
+	}
+}
+
+func (s *AuditLogTestSuite) writeLog(path string, p peer.ID, entries ...audit.Entry) {
+	log, err := audit.NewLog(path, p)
+	s.Nil(err)
+	for _, entry := range entries {
+		s.Nil(log.Append(entry))
+	}
+	s.Nil(log.Close())
+}
+
+func (s *AuditLogTestSuite) Test_Append_ChainsEntries() {
+	s.writeLog(s.path, peerA, s.entry("0x01", "1"), s.entry("0x02", "2"))
+
+	entries, err := audit.ReadFile(s.path)
+	s.Nil(err)
+	s.Nil(audit.Verify(entries))
+	s.Len(entries, 2)
+	s.Equal(uint64(1), entries[0].Sequence)
+	s.Equal(peerA, entries[0].Peer)
+	s.Equal("", entries[0].PrevHash)
+	s.Equal(entries[0].Hash, entries[1].PrevHash)
+	s.Equal("across", entries[1].Protocol)
+	s.JSONEq(`{"depositId":"1"}`, string(entries[1].Params))
+}
+
+func (s *AuditLogTestSuite) Test_NewLog_ContinuesExistingChain() {
+	s.writeLog(s.path, peerA, s.entry("0x01", "1"))
+	s.writeLog(s.path, peerA, s.entry("0x02", "2"))
+
+	entries, err := audit.ReadFile(s.path)
+	s.Nil(err)
+	s.Nil(audit.Verify(entries))
+	s.Len(entries, 2)
+	s.Equal(uint64(2), entries[1].Sequence)
+}
+
+func (s *AuditLogTestSuite) Test_Verify_DetectsModifiedEntry() {
+	s.writeLog(s.path, peerA, s.entry("0x01", "1"), s.entry("0x02", "2"))
+	content, err := os.ReadFile(s.path)
+	s.Nil(err)
+	err = os.WriteFile(s.path, []byte(strings.Replace(string(content), "0x01", "0x03", 1)), 0600)
+	s.Nil(err)
+
+	entries, err := audit.ReadFile(s.path)
+	s.Nil(err)
+	s.NotNil(audit.Verify(entries))
+	_, err = audit.NewLog(s.path, peerA)
+	s.NotNil(err)
+}
+
+func (s *AuditLogTestSuite) Test_Verify_DetectsRemovedEntry() {
+	s.writeLog(s.path, peerA, s.entry("0x01", "1"), s.entry("0x02", "2"), s.entry("0x03", "3"))
+
+	entries, err := audit.ReadFile(s.path)
+	s.Nil(err)
+	err = audit.Verify(append(entries[:1], entries[2:]...))
+
+	s.NotNil(err)
+}
+
+func (s *AuditLogTestSuite) Test_Compare_ReportsMissingDigests() {
+	dir := s.T().TempDir()
+	s.writeLog(filepath.Join(dir, "a.log"), peerA,
+		s.entry("0x01", "1", peerA, peerB),
+		s.entry("0x02", "2", peerA, peerB, peerC),
+	)
+	s.writeLog(filepath.Join(dir, "b.log"), peerB,
+		s.entry("0x01", "1", peerA, peerB),
+	)
+	logs := make([][]audit.Entry, 0)
+	for _, name := range []string{"a.log", "b.log"} {
+		entries, err := audit.ReadFile(filepath.Join(dir, name))
+		s.Nil(err)
+		logs = append(logs, entries)
+	}
+
+	discrepancies := audit.Compare(logs)
+
+	s.Equal([]audit.Discrepancy{
+		{
+			Digest:      "0x02",
+			SessionID:   "2",
+			RecordedBy:  []peer.ID{peerA},
+			MissingFrom: []peer.ID{peerB},
+		},
+	}, discrepancies)
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cache/signature.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./cache/signature.go -destination=./cache/mock/signature.go
+//
+
+// Package mock_cache is a generated GoMock package.
+package mock_cache
+
+import (
+	reflect "reflect"
+
+	keyshare "github.com/sprintertech/sprinter-signing/keyshare"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMetrics is a mock of Metrics interface.
+type MockMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockMetricsMockRecorder is the mock recorder for MockMetrics.
+type MockMetricsMockRecorder struct {
+	mock *MockMetrics
+}
+
+// NewMockMetrics creates a new mock instance.
+func NewMockMetrics(ctrl *gomock.Controller) *MockMetrics {
+	mock := &MockMetrics{ctrl: ctrl}
+	mock.recorder = &MockMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
+	return m.recorder
+}
+
+// EndProcess mocks base method.
+func (m *MockMetrics) EndProcess(sessionID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EndProcess", sessionID)
+}
+
+// EndProcess indicates an expected call of EndProcess.
+func (mr *MockMetricsMockRecorder) EndProcess(sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndProcess", reflect.TypeOf((*MockMetrics)(nil).EndProcess), sessionID)
+}
+
+// TrackInvalidSignature mocks base method.
+func (m *MockMetrics) TrackInvalidSignature(peerID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackInvalidSignature", peerID)
+}
+
+// TrackInvalidSignature indicates an expected call of TrackInvalidSignature.
+func (mr *MockMetricsMockRecorder) TrackInvalidSignature(peerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackInvalidSignature", reflect.TypeOf((*MockMetrics)(nil).TrackInvalidSignature), peerID)
+}
+
+// MockKeyshareFetcher is a mock of KeyshareFetcher interface.
+type MockKeyshareFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyshareFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyshareFetcherMockRecorder is the mock recorder for MockKeyshareFetcher.
+type MockKeyshareFetcherMockRecorder struct {
+	mock *MockKeyshareFetcher
+}
+
+// NewMockKeyshareFetcher creates a new mock instance.
+func NewMockKeyshareFetcher(ctrl *gomock.Controller) *MockKeyshareFetcher {
+	mock := &MockKeyshareFetcher{ctrl: ctrl}
+	mock.recorder = &MockKeyshareFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyshareFetcher) EXPECT() *MockKeyshareFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetKeyshare mocks base method.
+func (m *MockKeyshareFetcher) GetKeyshare() (keyshare.ECDSAKeyshare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyshare")
+	ret0, _ := ret[0].(keyshare.ECDSAKeyshare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyshare indicates an expected call of GetKeyshare.
+func (mr *MockKeyshareFetcherMockRecorder) GetKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockKeyshareFetcher)(nil).GetKeyshare))
+}
@@ -1,112 +1,463 @@
 package cache
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/jellydator/ttlcache/v3"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
 	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/keyshare"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
 	"github.com/sprintertech/sprinter-signing/tss/message"
 )
 
 const (
-	SIGNATURE_TTL = time.Minute * 10
+	// FETCH_INTERVAL is the minimum interval between requests to peers for the same missing signature
+	FETCH_INTERVAL = time.Second * 30
+	// MAX_PENDING_SIGNATURES is the default maximum number of peer signatures waiting for the digest of their request
+	MAX_PENDING_SIGNATURES = 1024
+)
+
+var (
+	// ErrInvalidSignature is returned if a signature was not signed by the MPC key over the expected digest
+	ErrInvalidSignature = errors.New("invalid signature")
 )
 
 type Metrics interface {
 	EndProcess(sessionID string)
+	TrackInvalidSignature(peerID string)
 }
 
+type KeyshareFetcher interface {
+	GetKeyshare() (keyshare.ECDSAKeyshare, error)
+}
+
+type pendingSignature struct {
+	from      peer.ID
+	signature []byte
+}
+
+// SignatureCache watches for signatures of the committee and persists
+// them with the metadata of their request to the signature store.
+// Signatures received from peers are only persisted if they were
+// signed by the MPC key over the digest of the request.
 type SignatureCache struct {
-	sigCache *ttlcache.Cache[string, []byte]
-	comm     comm.Communication
-	metrics  Metrics
+	// MaxPendingSignatures is the maximum number of requests with a peer
+	// signature waiting for the request digest
+	MaxPendingSignatures int
+
+	store     *SignatureStore
+	comm      comm.Communication
+	host      host.Host
+	metrics   Metrics
+	statuses  *StatusCache
+	keyshares KeyshareFetcher
+	fetched   *ttlcache.Cache[string, struct{}]
+	watching  atomic.Bool
+
+	pendingLock sync.Mutex
+	pending     *ttlcache.Cache[string, pendingSignature]
+
+	lock    sync.Mutex
+	waiters map[string]map[chan []byte]struct{}
 }
 
-func NewSignatureCache(c comm.Communication, metrics Metrics) *SignatureCache {
-	cache := ttlcache.New(
-		ttlcache.WithTTL[string, []byte](SIGNATURE_TTL),
+func NewSignatureCache(
+	c comm.Communication,
+	h host.Host,
+	metrics Metrics,
+	statuses *StatusCache,
+	store *SignatureStore,
+	keyshares KeyshareFetcher,
+) *SignatureCache {
+	fetched := ttlcache.New(
+		ttlcache.WithTTL[string, struct{}](FETCH_INTERVAL),
+		ttlcache.WithDisableTouchOnHit[string, struct{}](),
 	)
 
-	sc := &SignatureCache{
-		sigCache: cache,
-		comm:     c,
-		metrics:  metrics,
-	}
+	pending := ttlcache.New(
+		ttlcache.WithTTL[string, pendingSignature](STATUS_TTL),
+		ttlcache.WithDisableTouchOnHit[string, pendingSignature](),
+	)
 
-	go cache.Start()
-	return sc
+	go fetched.Start()
+	go pending.Start()
+	return &SignatureCache{
+		MaxPendingSignatures: MAX_PENDING_SIGNATURES,
+		store:                store,
+		comm:                 c,
+		host:                 h,
+		metrics:              metrics,
+		statuses:             statuses,
+		keyshares:            keyshares,
+		fetched:              fetched,
+		pending:              pending,
+		waiters:              make(map[string]map[chan []byte]struct{}),
+	}
 }
 
-// Subscribe watches for new signatures for given id and returns it through the channel
+// Subscribe waits for the signature with the given id and returns it through the channel.
+// Waiters are notified once the signature is stored and removed when the context is cancelled.
 func (s *SignatureCache) Subscribe(ctx context.Context, id string, sigChannel chan []byte) {
-	sig := s.sigCache.Get(id)
-	if sig != nil {
-		sigChannel <- sig.Value()
-		return
+	waiter := make(chan []byte, 1)
+	s.lock.Lock()
+	if _, ok := s.waiters[id]; !ok {
+		s.waiters[id] = make(map[chan []byte]struct{})
 	}
+	s.waiters[id][waiter] = struct{}{}
+	s.lock.Unlock()
+	defer s.unsubscribe(id, waiter)
 
-	ping := time.Tick(time.Millisecond * 50)
-	for {
+	// the signature is checked after registering the waiter so
+	// signatures stored in the meantime are not missed
+	sig, err := s.Signature(id)
+	if err == nil {
 		select {
-		case <-ctx.Done():
-			return
-		case <-ping:
-			{
-				sig := s.sigCache.Get(id)
-				if sig == nil {
-					continue
-				}
+		case waiter <- sig:
+		default:
+		}
+	}
 
-				sigChannel <- sig.Value()
-				return
+	select {
+	case sig := <-waiter:
+		{
+			select {
+			case sigChannel <- sig:
+			case <-ctx.Done():
 			}
 		}
+	case <-ctx.Done():
+	}
+}
+
+func (s *SignatureCache) unsubscribe(id string, waiter chan []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.waiters[id], waiter)
+	if len(s.waiters[id]) == 0 {
+		delete(s.waiters, id)
 	}
 }
 
 func (s *SignatureCache) Signature(id string) ([]byte, error) {
-	sig := s.sigCache.Get(id)
-	if sig == nil {
-		return []byte{}, fmt.Errorf("no signature found with id %s", id)
+	record, err := s.store.Signature(id)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return record.Signature, nil
+}
+
+// Check fails if the cache is not watching for new signatures
+func (s *SignatureCache) Check(ctx context.Context) error {
+	if !s.watching.Load() {
+		return fmt.Errorf("not watching for signatures")
+	}
+	return nil
+}
+
+// Fetch requests the signature with the given id from peers if it is not stored.
+// Peers only respond if they have the signature, which is persisted once verified
+// over the digest of the request status.
+// The same signature is requested at most once per FETCH_INTERVAL.
+func (s *SignatureCache) Fetch(id string) {
+	_, err := s.store.Signature(id)
+	if err == nil {
+		return
+	}
+	_, requested := s.fetched.GetOrSet(id, struct{}{})
+	if requested {
+		return
 	}
 
-	return sig.Value(), nil
+	go func() {
+		msgBytes, err := message.MarshalSignatureRequestMessage(id)
+		if err != nil {
+			log.Warn().Msgf("Failed to marshal signature request message: %s", err)
+			return
+		}
+
+		log.Debug().Msgf("Requesting signature for ID %s from peers", id)
+		err = s.comm.Broadcast(s.host.Peerstore().Peers(), msgBytes, comm.SignatureRequestMsg, comm.SignatureSessionID)
+		if err != nil {
+			log.Warn().Msgf("Failed requesting signature for ID %s: %s", id, err)
+		}
+	}()
 }
 
 func (s *SignatureCache) Watch(ctx context.Context, sigChn chan interface{}) {
 	msgChn := make(chan *comm.WrappedMessage)
 	subID := s.comm.Subscribe(comm.SignatureSessionID, comm.SignatureMsg, msgChn)
+	requestChn := make(chan *comm.WrappedMessage)
+	requestSubID := s.comm.Subscribe(comm.SignatureSessionID, comm.SignatureRequestMsg, requestChn)
+	responseChn := make(chan *comm.WrappedMessage)
+	responseSubID := s.comm.Subscribe(comm.SignatureSessionID, comm.SignatureResponseMsg, responseChn)
+	s.watching.Store(true)
+	defer s.watching.Store(false)
 
 	for {
 		select {
 		case sig := <-sigChn:
 			{
 				sig := sig.(signing.EcdsaSignature)
-				s.sigCache.Set(sig.ID, sig.Signature, ttlcache.DefaultTTL)
-				s.metrics.EndProcess(sig.ID)
+				s.persist(sig.ID, sig.Signature, "")
 			}
-		case msg := <-msgChn:
+		case wMsg := <-msgChn:
 			{
-				msg, err := message.UnmarshalSignatureMessage(msg.Payload)
+				msg, err := message.UnmarshalSignatureMessage(wMsg.Payload)
 				if err != nil {
 					log.Warn().Msgf("Failed to unmarshal signature message: %s", err)
 					continue
 				}
 
-				log.Debug().Msgf("Received signature for ID: %s", msg.ID)
-				s.sigCache.Set(msg.ID, msg.Signature, ttlcache.DefaultTTL)
-				s.metrics.EndProcess(msg.ID)
+				log.Debug().Msgf("Received signature for ID %s from peer %s", msg.ID, wMsg.From)
+				go s.persistPeerSignature(ctx, wMsg.From, msg.ID, msg.Signature)
+			}
+		case wMsg := <-requestChn:
+			{
+				msg, err := message.UnmarshalSignatureRequestMessage(wMsg.Payload)
+				if err != nil {
+					log.Warn().Msgf("Failed to unmarshal signature request message: %s", err)
+					continue
+				}
+
+				go s.respond(wMsg.From, msg.ID)
+			}
+		case wMsg := <-responseChn:
+			{
+				msg, err := message.UnmarshalSignatureResponseMessage(wMsg.Payload)
+				if err != nil {
+					log.Warn().Msgf("Failed to unmarshal signature response message: %s", err)
+					continue
+				}
+
+				log.Debug().Msgf("Received requested signature for ID %s from peer %s", msg.ID, wMsg.From)
+				go s.persistFetchedSignature(wMsg.From, msg.ID, msg.Signature)
 			}
 		case <-ctx.Done():
 			{
-				s.sigCache.Stop()
 				s.comm.UnSubscribe(subID)
+				s.comm.UnSubscribe(requestSubID)
+				s.comm.UnSubscribe(responseSubID)
 				return
 			}
 		}
 	}
 }
+
+// persistPeerSignature persists the signature received from the peer after verifying it.
+// Invalid signatures are rejected and their senders tracked in metrics.
+// Peers can finish signing before the relayer verifies the request, so the latest signature
+// of requests without a digest is kept pending until the digest is known. Signatures of new
+// requests are dropped while MaxPendingSignatures requests are pending.
+func (s *SignatureCache) persistPeerSignature(ctx context.Context, from peer.ID, id string, signature []byte) {
+	status, err := s.statuses.Status(id)
+	if err != nil {
+		log.Debug().Msgf("Unable to verify signature for ID %s from peer %s: %s", id, from, err)
+		return
+	}
+	if status.Digest != "" {
+		digest, err := hexutil.Decode(status.Digest)
+		if err != nil {
+			log.Warn().Msgf("Invalid digest for ID %s: %s", id, err)
+			return
+		}
+
+		s.verifyAndPersist(from, id, digest, signature)
+		return
+	}
+
+	s.pendingLock.Lock()
+	waiting := s.pending.Has(id)
+	if !waiting && s.pending.Len() >= s.MaxPendingSignatures {
+		s.pendingLock.Unlock()
+		log.Warn().Msgf("Dropping signature for ID %s from peer %s: too many pending signatures", id, from)
+		return
+	}
+	s.pending.Set(id, pendingSignature{from: from, signature: signature}, ttlcache.DefaultTTL)
+	s.pendingLock.Unlock()
+	if waiting {
+		return
+	}
+
+	digest, err := s.expectedDigest(ctx, id)
+	s.pendingLock.Lock()
+	item := s.pending.Get(id)
+	s.pending.Delete(id)
+	s.pendingLock.Unlock()
+	if err != nil {
+		log.Debug().Msgf("Unable to verify signature for ID %s from peer %s: %s", id, from, err)
+		return
+	}
+	if item == nil {
+		return
+	}
+
+	pending := item.Value()
+	s.verifyAndPersist(pending.from, id, digest, pending.signature)
+}
+
+// persistFetchedSignature persists the signature requested from peers after verifying it
+// over the digest of the request known to the relayer. Signatures of requests with an
+// unknown digest are dropped, as a valid signature over a different digest must not be
+// stored for the request.
+func (s *SignatureCache) persistFetchedSignature(from peer.ID, id string, signature []byte) {
+	if !s.fetched.Has(id) {
+		log.Debug().Msgf("Ignoring unrequested signature for ID %s from peer %s", id, from)
+		return
+	}
+
+	status, err := s.statuses.Status(id)
+	if err != nil || status.Digest == "" {
+		log.Debug().Msgf("Ignoring signature for ID %s with unknown digest from peer %s", id, from)
+		return
+	}
+	digest, err := hexutil.Decode(status.Digest)
+	if err != nil {
+		log.Warn().Msgf("Invalid digest for ID %s: %s", id, err)
+		return
+	}
+
+	s.verifyAndPersist(from, id, digest, signature)
+}
+
+// respond sends the stored signature to the peer that requested it
+func (s *SignatureCache) respond(to peer.ID, id string) {
+	record, err := s.store.Signature(id)
+	if err != nil {
+		return
+	}
+
+	msgBytes, err := message.MarshalSignatureResponseMessage(id, record.Signature)
+	if err != nil {
+		log.Warn().Msgf("Failed to marshal signature response message: %s", err)
+		return
+	}
+
+	err = s.comm.Broadcast(peer.IDSlice{to}, msgBytes, comm.SignatureResponseMsg, comm.SignatureSessionID)
+	if err != nil {
+		log.Warn().Msgf("Failed sending signature for ID %s to peer %s: %s", id, to, err)
+	}
+}
+
+// verifyAndPersist persists the signature received from the peer if it was signed
+// by the MPC key over the digest
+func (s *SignatureCache) verifyAndPersist(from peer.ID, id string, digest []byte, signature []byte) {
+	err := s.verify(digest, signature)
+	if errors.Is(err, ErrInvalidSignature) {
+		log.Warn().Msgf("Rejected signature for ID %s from peer %s: %s", id, from, err)
+		s.metrics.TrackInvalidSignature(from.String())
+		return
+	}
+	if err != nil {
+		log.Warn().Msgf("Unable to verify signature for ID %s from peer %s: %s", id, from, err)
+		return
+	}
+
+	s.persist(id, signature, hexutil.Encode(digest))
+}
+
+// expectedDigest waits up to STATUS_TTL for the digest of the request
+// that is set once the relayer starts signing it
+func (s *SignatureCache) expectedDigest(ctx context.Context, id string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, STATUS_TTL)
+	defer cancel()
+	statusChn := make(chan RequestStatus)
+	go s.statuses.Subscribe(ctx, id, 0, statusChn)
+	for {
+		select {
+		case status := <-statusChn:
+			{
+				if status.Digest != "" {
+					return hexutil.Decode(status.Digest)
+				}
+				if status.Final() {
+					return nil, fmt.Errorf("request %s %s without digest", id, status.State)
+				}
+			}
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no digest for request %s", id)
+		}
+	}
+}
+
+// verify recovers the signer of the digest and checks it is the MPC key of the current keyshare
+func (s *SignatureCache) verify(digest []byte, signature []byte) error {
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: invalid length %d", ErrInvalidSignature, len(signature))
+	}
+	sig := slices.Clone(signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	k, err := s.keyshares.GetKeyshare()
+	if err != nil {
+		return err
+	}
+	if k.Key.ECDSAPub == nil {
+		return fmt.Errorf("keyshare has no public key")
+	}
+	mpcKey := k.Key.ECDSAPub.ToBtcecPubKey().ToECDSA()
+	if !bytes.Equal(crypto.FromECDSAPub(pub), crypto.FromECDSAPub(mpcKey)) {
+		return fmt.Errorf("%w: signed by %s", ErrInvalidSignature, crypto.PubkeyToAddress(*pub))
+	}
+	return nil
+}
+
+// persist stores the signature with the caller, params hash and digest of the
+// request and marks the request as signed. The digest of the request status
+// is used if the digest is empty.
+func (s *SignatureCache) persist(id string, signature []byte, digest string) {
+	record := SignatureRecord{
+		ID:        id,
+		Digest:    digest,
+		Signature: signature,
+		SignedAt:  time.Now(),
+	}
+	status, err := s.statuses.Status(id)
+	if err == nil {
+		record.Caller = status.Caller
+		record.ParamsHash = status.ParamsHash
+		if record.Digest == "" {
+			record.Digest = status.Digest
+		}
+	}
+
+	err = s.store.Store(record)
+	if err != nil {
+		log.Error().Msgf("Failed storing signature for ID %s: %s", id, err)
+	}
+	s.notify(id, signature)
+	s.statuses.Update(id, SignedState)
+	s.metrics.EndProcess(id)
+}
+
+// notify wakes all subscribers waiting for the signature
+func (s *SignatureCache) notify(id string, signature []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for waiter := range s.waiters[id] {
+		select {
+		case waiter <- signature:
+		default:
+		}
+	}
+}
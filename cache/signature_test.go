@@ -2,15 +2,27 @@ package cache_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+	ethereumCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
 	"github.com/sprintertech/sprinter-signing/cache"
+	mock_cache "github.com/sprintertech/sprinter-signing/cache/mock"
 	"github.com/sprintertech/sprinter-signing/comm"
 	mock_communication "github.com/sprintertech/sprinter-signing/comm/mock"
+	mock_host "github.com/sprintertech/sprinter-signing/comm/p2p/mock/host"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/sprintertech/sprinter-signing/lvldb"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
 	"github.com/sprintertech/sprinter-signing/tss/message"
-	mock_tss "github.com/sprintertech/sprinter-signing/tss/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -18,14 +30,22 @@ import (
 type SignatureCacheTestSuite struct {
 	suite.Suite
 
-	sc  *cache.SignatureCache
-	ctx context.Context
+	sc       *cache.SignatureCache
+	store    *cache.SignatureStore
+	statuses *cache.StatusCache
+	ctx      context.Context
 
 	mockCommunication *mock_communication.MockCommunication
-	mockMetrics       *mock_tss.MockMetrics
+	mockMetrics       *mock_cache.MockMetrics
+	mockKeyshares     *mock_cache.MockKeyshareFetcher
+	key               *ecdsa.PrivateKey
+	peer              peer.ID
+	mockHost          *mock_host.MockHost
 	cancel            context.CancelFunc
 	sigChn            chan interface{}
 	msgChn            chan *comm.WrappedMessage
+	requestChn        chan *comm.WrappedMessage
+	responseChn       chan *comm.WrappedMessage
 }
 
 func TestRunSignatureCacheTestSuite(t *testing.T) {
@@ -39,20 +59,65 @@ func (s *SignatureCacheTestSuite) SetupTest() {
 
 	s.mockCommunication = mock_communication.NewMockCommunication(ctrl)
 	s.mockCommunication.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(sessionID string, msgType comm.MessageType, channel chan *comm.WrappedMessage) comm.SubscriptionID {
-		s.msgChn = channel
-		return comm.NewSubscriptionID("ID", comm.SignatureMsg)
-	})
+		switch msgType {
+		case comm.SignatureRequestMsg:
+			s.requestChn = channel
+		case comm.SignatureResponseMsg:
+			s.responseChn = channel
+		default:
+			s.msgChn = channel
+		}
+		return comm.NewSubscriptionID("ID", msgType)
+	}).Times(3)
+	s.mockHost = mock_host.NewMockHost(ctrl)
 	s.mockCommunication.EXPECT().UnSubscribe(gomock.Any()).AnyTimes()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
 	s.ctx = ctx
 
-	s.mockMetrics = mock_tss.NewMockMetrics(gomock.NewController(s.T()))
-	s.sc = cache.NewSignatureCache(s.mockCommunication, s.mockMetrics)
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.T().Cleanup(func() { _ = db.Close() })
+	s.store = cache.NewSignatureStore(db, time.Hour)
+	s.statuses = cache.NewStatusCache()
+
+	s.key, err = ethereumCrypto.GenerateKey()
+	s.Nil(err)
+	mpcKey, err := crypto.NewECPoint(tss.S256(), s.key.X, s.key.Y)
+	s.Nil(err)
+	s.peer, _ = peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+
+	s.mockMetrics = mock_cache.NewMockMetrics(gomock.NewController(s.T()))
+	s.mockKeyshares = mock_cache.NewMockKeyshareFetcher(ctrl)
+	s.mockKeyshares.EXPECT().GetKeyshare().Return(keyshare.ECDSAKeyshare{
+		Key: keygen.LocalPartySaveData{
+			ECDSAPub: mpcKey,
+		},
+	}, nil).AnyTimes()
+	s.sc = cache.NewSignatureCache(s.mockCommunication, s.mockHost, s.mockMetrics, s.statuses, s.store, s.mockKeyshares)
 	go s.sc.Watch(s.ctx, s.sigChn)
 	time.Sleep(time.Millisecond * 100)
 }
+
+// sign starts signing the request and returns the signature of its digest by the key
+func (s *SignatureCacheTestSuite) sign(id string, key *ecdsa.PrivateKey) []byte {
+	digest := ethereumCrypto.Keccak256([]byte(id))
+	s.statuses.Sign(id, digest)
+
+	sig, err := ethereumCrypto.Sign(digest, key)
+	s.Nil(err)
+	sig[ethereumCrypto.RecoveryIDOffset] += 27
+	return sig
+}
+
+func (s *SignatureCacheTestSuite) signatureMessage(id string, signature []byte) *comm.WrappedMessage {
+	msgBytes, _ := message.MarshalSignatureMessage(id, signature)
+	return &comm.WrappedMessage{
+		Payload: msgBytes,
+		From:    s.peer,
+	}
+}
 func (s *SignatureCacheTestSuite) TearDownTest() {
 	s.cancel()
 }
@@ -78,18 +143,38 @@ func (s *SignatureCacheTestSuite) Test_Signature_ValidSignatureResult() {
 	s.Equal(sig, expectedSig.Signature)
 }
 
+func (s *SignatureCacheTestSuite) Test_Signature_PersistsRequestMetadata() {
+	err := s.statuses.Receive("1-1", "0xcaller", "hash")
+	s.Nil(err)
+	s.mockMetrics.EXPECT().EndProcess("1-1")
+	s.sigChn <- signing.EcdsaSignature{
+		Signature: []byte("signature"),
+		ID:        "1-1",
+	}
+	time.Sleep(time.Millisecond * 100)
+
+	record, err := s.store.Signature("1-1")
+	s.Nil(err)
+	s.Equal("0xcaller", record.Caller)
+	s.Equal("hash", record.ParamsHash)
+	s.Equal([]byte("signature"), record.Signature)
+	status, err := s.statuses.Status("1-1")
+	s.Nil(err)
+	s.Equal(cache.SignedState, status.State)
+
+	restarted := cache.NewSignatureCache(s.mockCommunication, s.mockHost, s.mockMetrics, cache.NewStatusCache(), s.store, s.mockKeyshares)
+	sig, err := restarted.Signature("1-1")
+	s.Nil(err)
+	s.Equal([]byte("signature"), sig)
+}
+
 func (s *SignatureCacheTestSuite) Test_Signature_ValidMessage() {
 	expectedSig := signing.EcdsaSignature{
-		Signature: []byte("signature"),
+		Signature: s.sign("signatureID", s.key),
 		ID:        "signatureID",
 	}
 	s.mockMetrics.EXPECT().EndProcess(expectedSig.ID)
-	wMsgBytes, _ := message.MarshalSignatureMessage(expectedSig.ID, expectedSig.Signature)
-	wMsg := &comm.WrappedMessage{
-		Payload: wMsgBytes,
-	}
-
-	s.msgChn <- wMsg
+	s.msgChn <- s.signatureMessage(expectedSig.ID, expectedSig.Signature)
 	time.Sleep(time.Millisecond * 100)
 
 	sig, err := s.sc.Signature(expectedSig.ID)
@@ -98,16 +183,111 @@ func (s *SignatureCacheTestSuite) Test_Signature_ValidMessage() {
 	s.Equal(sig, expectedSig.Signature)
 }
 
+func (s *SignatureCacheTestSuite) Test_Signature_InvalidSignerRejected() {
+	otherKey, _ := ethereumCrypto.GenerateKey()
+	s.mockMetrics.EXPECT().TrackInvalidSignature(s.peer.String())
+	s.msgChn <- s.signatureMessage("1-1", s.sign("1-1", otherKey))
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+
+	s.NotNil(err)
+}
+
+func (s *SignatureCacheTestSuite) Test_Signature_InvalidDigestRejected() {
+	s.sign("1-1", s.key)
+	sig := s.sign("1-2", s.key)
+	s.mockMetrics.EXPECT().TrackInvalidSignature(s.peer.String())
+	s.msgChn <- s.signatureMessage("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+
+	s.NotNil(err)
+}
+
+func (s *SignatureCacheTestSuite) Test_Signature_UnknownRequestIgnored() {
+	digest := ethereumCrypto.Keccak256([]byte("1-1"))
+	sig, _ := ethereumCrypto.Sign(digest, s.key)
+	s.msgChn <- s.signatureMessage("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+
+	s.NotNil(err)
+}
+
+func (s *SignatureCacheTestSuite) Test_Signature_WaitsForDigest() {
+	err := s.statuses.Receive("1-1", "", "hash")
+	s.Nil(err)
+	digest := ethereumCrypto.Keccak256([]byte("1-1"))
+	sig, _ := ethereumCrypto.Sign(digest, s.key)
+	s.mockMetrics.EXPECT().EndProcess("1-1")
+	s.msgChn <- s.signatureMessage("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err = s.sc.Signature("1-1")
+	s.NotNil(err)
+
+	s.statuses.Sign("1-1", digest)
+	time.Sleep(time.Millisecond * 100)
+
+	storedSig, err := s.sc.Signature("1-1")
+	s.Nil(err)
+	s.Equal(sig, storedSig)
+}
+
+func (s *SignatureCacheTestSuite) Test_Signature_PendingSignatureReplaced() {
+	err := s.statuses.Receive("1-1", "", "hash")
+	s.Nil(err)
+	digest := ethereumCrypto.Keccak256([]byte("1-1"))
+	sig, _ := ethereumCrypto.Sign(digest, s.key)
+	otherSig, _ := ethereumCrypto.Sign(ethereumCrypto.Keccak256([]byte("other")), s.key)
+	s.mockMetrics.EXPECT().EndProcess("1-1")
+	s.msgChn <- s.signatureMessage("1-1", otherSig)
+	time.Sleep(time.Millisecond * 50)
+	s.msgChn <- s.signatureMessage("1-1", sig)
+	time.Sleep(time.Millisecond * 50)
+
+	s.statuses.Sign("1-1", digest)
+	time.Sleep(time.Millisecond * 100)
+
+	storedSig, err := s.sc.Signature("1-1")
+	s.Nil(err)
+	s.Equal(sig, storedSig)
+}
+
+func (s *SignatureCacheTestSuite) Test_Signature_PendingLimitReached() {
+	s.sc.MaxPendingSignatures = 1
+	for _, id := range []string{"1-1", "1-2"} {
+		err := s.statuses.Receive(id, "", "hash")
+		s.Nil(err)
+	}
+	digest := ethereumCrypto.Keccak256([]byte("digest"))
+	sig, _ := ethereumCrypto.Sign(digest, s.key)
+	s.mockMetrics.EXPECT().EndProcess("1-1")
+	s.msgChn <- s.signatureMessage("1-1", sig)
+	time.Sleep(time.Millisecond * 50)
+	s.msgChn <- s.signatureMessage("1-2", sig)
+	time.Sleep(time.Millisecond * 50)
+
+	s.statuses.Sign("1-1", digest)
+	s.statuses.Sign("1-2", digest)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+	s.Nil(err)
+	_, err = s.sc.Signature("1-2")
+	s.NotNil(err)
+}
+
 func (s *SignatureCacheTestSuite) Test_Subscribe_ValidMessage_EarlyExit() {
 	expectedSig := signing.EcdsaSignature{
-		Signature: []byte("signature"),
+		Signature: s.sign("signatureID", s.key),
 		ID:        "signatureID",
 	}
 	s.mockMetrics.EXPECT().EndProcess(expectedSig.ID)
-	wMsgBytes, _ := message.MarshalSignatureMessage(expectedSig.ID, expectedSig.Signature)
-	wMsg := &comm.WrappedMessage{
-		Payload: wMsgBytes,
-	}
+	wMsg := s.signatureMessage(expectedSig.ID, expectedSig.Signature)
 
 	s.msgChn <- wMsg
 	time.Sleep(time.Millisecond * 100)
@@ -125,14 +305,11 @@ func (s *SignatureCacheTestSuite) Test_Subscribe_ValidMessage_EarlyExit() {
 
 func (s *SignatureCacheTestSuite) Test_Subscribe_ValidMessage() {
 	expectedSig := signing.EcdsaSignature{
-		Signature: []byte("signature"),
+		Signature: s.sign("signatureID", s.key),
 		ID:        "signatureID",
 	}
 	s.mockMetrics.EXPECT().EndProcess(expectedSig.ID)
-	wMsgBytes, _ := message.MarshalSignatureMessage(expectedSig.ID, expectedSig.Signature)
-	wMsg := &comm.WrappedMessage{
-		Payload: wMsgBytes,
-	}
+	wMsg := s.signatureMessage(expectedSig.ID, expectedSig.Signature)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -147,3 +324,159 @@ func (s *SignatureCacheTestSuite) Test_Subscribe_ValidMessage() {
 	sig := <-sigChn
 	s.Equal(sig, expectedSig.Signature)
 }
+
+func (s *SignatureCacheTestSuite) Test_Subscribe_NotifiesAllSubscribers() {
+	s.mockMetrics.EXPECT().EndProcess("1-1")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChns := make([]chan []byte, 100)
+	for i := range sigChns {
+		sigChns[i] = make(chan []byte, 1)
+		go s.sc.Subscribe(ctx, "1-1", sigChns[i])
+	}
+	time.Sleep(time.Millisecond * 100)
+	s.sigChn <- signing.EcdsaSignature{
+		Signature: []byte("signature"),
+		ID:        "1-1",
+	}
+
+	for _, sigChn := range sigChns {
+		select {
+		case sig := <-sigChn:
+			s.Equal([]byte("signature"), sig)
+		case <-time.After(time.Second):
+			s.Fail("subscriber not notified")
+		}
+	}
+}
+
+func (s *SignatureCacheTestSuite) Test_Subscribe_ReturnsOnCancel() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		s.sc.Subscribe(ctx, "1-1", make(chan []byte, 1))
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("subscriber not removed on cancel")
+	}
+}
+
+func (s *SignatureCacheTestSuite) Test_Fetch_RequestsMissingSignatureOnce() {
+	p, _ := pstoremem.NewPeerstore()
+	s.mockHost.EXPECT().Peerstore().Return(p)
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.SignatureRequestMsg, comm.SignatureSessionID).Return(nil)
+
+	s.sc.Fetch("1-1")
+	s.sc.Fetch("1-1")
+	time.Sleep(time.Millisecond * 100)
+}
+
+func (s *SignatureCacheTestSuite) Test_Fetch_PersistsVerifiedResponse() {
+	p, _ := pstoremem.NewPeerstore()
+	s.mockHost.EXPECT().Peerstore().Return(p)
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.SignatureRequestMsg, comm.SignatureSessionID).Return(nil)
+	s.mockMetrics.EXPECT().EndProcess("1-1")
+	sig := s.sign("1-1", s.key)
+	s.statuses.Fail("1-1", fmt.Errorf("tss timeout"))
+
+	s.sc.Fetch("1-1")
+	s.responseChn <- s.signatureResponse("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	record, err := s.store.Signature("1-1")
+	s.Nil(err)
+	s.Equal(sig, record.Signature)
+	s.Equal("0x"+hex.EncodeToString(ethereumCrypto.Keccak256([]byte("1-1"))), record.Digest)
+}
+
+func (s *SignatureCacheTestSuite) Test_Fetch_DifferentDigestRejected() {
+	p, _ := pstoremem.NewPeerstore()
+	s.mockHost.EXPECT().Peerstore().Return(p)
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.SignatureRequestMsg, comm.SignatureSessionID).Return(nil)
+	s.mockMetrics.EXPECT().TrackInvalidSignature(s.peer.String())
+	s.sign("1-1", s.key)
+	s.statuses.Fail("1-1", fmt.Errorf("tss timeout"))
+	sig := s.sign("1-2", s.key)
+
+	s.sc.Fetch("1-1")
+	s.responseChn <- s.signatureResponse("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+	s.NotNil(err)
+}
+
+func (s *SignatureCacheTestSuite) Test_Fetch_UnknownDigestIgnored() {
+	p, _ := pstoremem.NewPeerstore()
+	s.mockHost.EXPECT().Peerstore().Return(p)
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.SignatureRequestMsg, comm.SignatureSessionID).Return(nil)
+	digest := ethereumCrypto.Keccak256([]byte("1-1"))
+	sig, _ := ethereumCrypto.Sign(digest, s.key)
+
+	s.sc.Fetch("1-1")
+	s.responseChn <- s.signatureResponse("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+	s.NotNil(err)
+}
+
+func (s *SignatureCacheTestSuite) Test_Fetch_InvalidResponseRejected() {
+	p, _ := pstoremem.NewPeerstore()
+	s.mockHost.EXPECT().Peerstore().Return(p)
+	s.mockCommunication.EXPECT().Broadcast(gomock.Any(), gomock.Any(), comm.SignatureRequestMsg, comm.SignatureSessionID).Return(nil)
+	s.mockMetrics.EXPECT().TrackInvalidSignature(s.peer.String())
+	otherKey, _ := ethereumCrypto.GenerateKey()
+	sig := s.sign("1-1", otherKey)
+
+	s.sc.Fetch("1-1")
+	s.responseChn <- s.signatureResponse("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+	s.NotNil(err)
+}
+
+func (s *SignatureCacheTestSuite) Test_Fetch_UnrequestedResponseIgnored() {
+	sig := s.sign("1-1", s.key)
+
+	s.responseChn <- s.signatureResponse("1-1", sig)
+	time.Sleep(time.Millisecond * 100)
+
+	_, err := s.sc.Signature("1-1")
+	s.NotNil(err)
+}
+
+func (s *SignatureCacheTestSuite) Test_Watch_RespondsWithStoredSignature() {
+	sig := s.sign("1-1", s.key)
+	s.mockMetrics.EXPECT().EndProcess("1-1")
+	s.sigChn <- signing.EcdsaSignature{
+		Signature: sig,
+		ID:        "1-1",
+	}
+	time.Sleep(time.Millisecond * 100)
+	expectedMsg, _ := message.MarshalSignatureResponseMessage("1-1", sig)
+	s.mockCommunication.EXPECT().Broadcast(peer.IDSlice{s.peer}, expectedMsg, comm.SignatureResponseMsg, comm.SignatureSessionID).Return(nil)
+
+	msgBytes, _ := message.MarshalSignatureRequestMessage("1-1")
+	s.requestChn <- &comm.WrappedMessage{
+		Payload: msgBytes,
+		From:    s.peer,
+	}
+	time.Sleep(time.Millisecond * 100)
+}
+
+func (s *SignatureCacheTestSuite) signatureResponse(id string, signature []byte) *comm.WrappedMessage {
+	msgBytes, _ := message.MarshalSignatureResponseMessage(id, signature)
+	return &comm.WrappedMessage{
+		Payload: msgBytes,
+		From:    s.peer,
+	}
+}
@@ -0,0 +1,399 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/sprintertech/sprinter-signing/api/types"
+	"github.com/sprintertech/sprinter-signing/errcode"
+)
+
+const (
+	STATUS_TTL         = time.Minute * 30
+	STATUS_BUFFER_SIZE = types.STATUS_BUFFER_SIZE
+	// FINAL_HISTORY_SIZE is the number of final statuses kept for replaying
+	// to subscribers of all requests
+	FINAL_HISTORY_SIZE = 1024
+)
+
+var (
+	ErrSlowSubscriber = errors.New("subscriber is not keeping up with statuses")
+	// ErrReplayGap is returned if final statuses after the requested sequence are no longer
+	// retained or the sequence is from before a restart of the relayer
+	ErrReplayGap = errors.New("statuses after sequence not retained")
+	// ErrDuplicateRequest is returned if the request is already in progress or signed
+	// with the same parameters and the retry should attach to the existing result
+	ErrDuplicateRequest = errors.New("request already received")
+	// ErrParamsConflict is returned if the request was already received with different parameters
+	ErrParamsConflict = errors.New("request already received with different parameters")
+)
+
+type RequestState = types.RequestState
+
+const (
+	ReceivedState             = types.ReceivedState
+	VerifyingState            = types.VerifyingState
+	WaitingConfirmationsState = types.WaitingConfirmationsState
+	SigningState              = types.SigningState
+	SignedState               = types.SignedState
+	FailedState               = types.FailedState
+)
+
+// RequestStatus represents a single state transition of a signing request
+// as it is sent to API clients
+type RequestStatus = types.RequestStatus
+
+// StatusCache tracks the lifecycle of signing requests by their ID
+// and notifies subscribers about each state transition.
+type StatusCache struct {
+	statusCache *ttlcache.Cache[string, []RequestStatus]
+	sequence    uint64
+
+	lock             sync.Mutex
+	subscribers      map[string]map[*subscriber]struct{}
+	finalHistory     []RequestStatus
+	finalDropped     uint64
+	finalSubscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	updateChn chan RequestStatus
+	lagged    bool
+}
+
+func NewStatusCache() *StatusCache {
+	cache := ttlcache.New(
+		ttlcache.WithTTL[string, []RequestStatus](STATUS_TTL),
+	)
+
+	go cache.Start()
+	return &StatusCache{
+		statusCache:      cache,
+		subscribers:      make(map[string]map[*subscriber]struct{}),
+		finalHistory:     make([]RequestStatus, 0, FINAL_HISTORY_SIZE),
+		finalSubscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Update records a new state for the request with the given ID
+func (s *StatusCache) Update(id string, state RequestState) {
+	s.update(RequestStatus{
+		ID:    id,
+		State: state,
+	})
+}
+
+// Receive records that the request of the caller was received. The caller and the
+// params hash are kept on all further statuses of the request. Requests that are
+// already in progress or signed are rejected with ErrDuplicateRequest if the params hash
+// matches and with ErrParamsConflict otherwise, while failed requests can be retried.
+func (s *StatusCache) Receive(id string, caller string, paramsHash string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	statuses := s.statusCache.Get(id)
+	if statuses != nil && len(statuses.Value()) > 0 {
+		history := statuses.Value()
+		attach, err := attachable(history[len(history)-1], paramsHash)
+		if err != nil {
+			return err
+		}
+		if attach {
+			return ErrDuplicateRequest
+		}
+	}
+
+	s.updateLocked(RequestStatus{
+		ID:         id,
+		Caller:     caller,
+		ParamsHash: paramsHash,
+		State:      ReceivedState,
+	})
+	return nil
+}
+
+// Attach returns true if a retry of the request with the params hash should attach
+// to the existing in progress or signed request instead of starting a new one.
+// ErrParamsConflict is returned if the request was received with different parameters.
+func (s *StatusCache) Attach(id string, paramsHash string) (bool, error) {
+	status, err := s.Status(id)
+	if err != nil {
+		return false, nil
+	}
+
+	return attachable(status, paramsHash)
+}
+
+// Sign records that the request is being signed and the digest expected to be
+// signed by the MPC key. The digest is kept on all further statuses of the request.
+func (s *StatusCache) Sign(id string, digest []byte) {
+	s.update(RequestStatus{
+		ID:     id,
+		State:  SigningState,
+		Digest: hexutil.Encode(digest),
+	})
+}
+
+// UpdateConfirmations records the current on-chain confirmations of the request deposit
+func (s *StatusCache) UpdateConfirmations(id string, confirmations uint64, requiredConfirmations uint64) {
+	s.update(RequestStatus{
+		ID:                    id,
+		State:                 WaitingConfirmationsState,
+		Confirmations:         confirmations,
+		RequiredConfirmations: requiredConfirmations,
+	})
+}
+
+// Fail marks the request as failed with the error as the reason. The error code
+// and details are taken from coded errors and errors without a code are internal.
+func (s *StatusCache) Fail(id string, err error) {
+	status := RequestStatus{
+		ID:        id,
+		State:     FailedState,
+		Reason:    err.Error(),
+		ErrorCode: errcode.CodeOf(err, errcode.Internal),
+	}
+	e := errcode.As(err)
+	if e != nil {
+		status.Details = e.Details
+	}
+	s.update(status)
+}
+
+// Status returns the latest status of the request
+func (s *StatusCache) Status(id string) (RequestStatus, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	statuses := s.statusCache.Get(id)
+	if statuses == nil || len(statuses.Value()) == 0 {
+		return RequestStatus{}, fmt.Errorf("no status found with id %s", id)
+	}
+
+	history := statuses.Value()
+	return history[len(history)-1], nil
+}
+
+// InProgress returns the IDs of all requests that are not signed or failed yet
+func (s *StatusCache) InProgress() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ids := make([]string, 0)
+	s.statusCache.Range(func(item *ttlcache.Item[string, []RequestStatus]) bool {
+		history := item.Value()
+		if len(history) > 0 && !history[len(history)-1].Final() {
+			ids = append(ids, item.Key())
+		}
+		return true
+	})
+	return ids
+}
+
+// Pending returns the number of requests that are not signed or failed yet
+func (s *StatusCache) Pending() int {
+	return len(s.InProgress())
+}
+
+// Subscribe sends all statuses of the request with a sequence greater than the provided one
+// to the status channel and keeps sending new statuses until the context is cancelled.
+// Subscribers that do not keep up with new statuses are dropped with ErrSlowSubscriber.
+func (s *StatusCache) Subscribe(ctx context.Context, id string, after uint64, statusChn chan RequestStatus) error {
+	sub := &subscriber{
+		updateChn: make(chan RequestStatus, STATUS_BUFFER_SIZE),
+	}
+
+	s.lock.Lock()
+	history := make([]RequestStatus, 0)
+	statuses := s.statusCache.Get(id)
+	if statuses != nil {
+		for _, status := range statuses.Value() {
+			if status.Sequence > after {
+				history = append(history, status)
+			}
+		}
+	}
+	if _, ok := s.subscribers[id]; !ok {
+		s.subscribers[id] = make(map[*subscriber]struct{})
+	}
+	s.subscribers[id][sub] = struct{}{}
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.subscribers[id], sub)
+		if len(s.subscribers[id]) == 0 {
+			delete(s.subscribers, id)
+		}
+		s.lock.Unlock()
+	}()
+
+	return stream(ctx, sub, history, statusChn)
+}
+
+// SubscribeFinal sends signed and failed statuses of all requests with a sequence greater
+// than the provided one to the status channel and keeps sending new ones until the context
+// is cancelled. Only the last FINAL_HISTORY_SIZE final statuses can be replayed and sequences
+// restart with the relayer, so ErrReplayGap is returned if statuses after the sequence are
+// not retained or the sequence was not issued yet.
+// Subscribers that do not keep up with new statuses are dropped with ErrSlowSubscriber.
+func (s *StatusCache) SubscribeFinal(ctx context.Context, after uint64, statusChn chan RequestStatus) error {
+	sub := &subscriber{
+		updateChn: make(chan RequestStatus, STATUS_BUFFER_SIZE),
+	}
+
+	s.lock.Lock()
+	if after != 0 && (after < s.finalDropped || after > s.sequence) {
+		s.lock.Unlock()
+		return ErrReplayGap
+	}
+	history := make([]RequestStatus, 0)
+	for _, status := range s.finalHistory {
+		if status.Sequence > after {
+			history = append(history, status)
+		}
+	}
+	s.finalSubscribers[sub] = struct{}{}
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.finalSubscribers, sub)
+		s.lock.Unlock()
+	}()
+
+	return stream(ctx, sub, history, statusChn)
+}
+
+// stream sends the replayed statuses followed by new statuses of the subscriber to
+// the status channel until the context is cancelled or the subscriber is dropped
+func stream(ctx context.Context, sub *subscriber, history []RequestStatus, statusChn chan RequestStatus) error {
+	for _, status := range history {
+		select {
+		case statusChn <- status:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		select {
+		case status, ok := <-sub.updateChn:
+			{
+				if !ok {
+					return ErrSlowSubscriber
+				}
+
+				select {
+				case statusChn <- status:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *StatusCache) update(status RequestStatus) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.updateLocked(status)
+}
+
+func (s *StatusCache) updateLocked(status RequestStatus) {
+	history := []RequestStatus{}
+	statuses := s.statusCache.Get(status.ID)
+	if statuses != nil {
+		history = statuses.Value()
+		if len(history) > 0 && !canTransition(history[len(history)-1], status) {
+			return
+		}
+	}
+
+	if status.Caller == "" && len(history) > 0 {
+		status.Caller = history[len(history)-1].Caller
+	}
+	if status.ParamsHash == "" && len(history) > 0 {
+		status.ParamsHash = history[len(history)-1].ParamsHash
+	}
+	if status.Digest == "" && len(history) > 0 {
+		status.Digest = history[len(history)-1].Digest
+	}
+	s.sequence++
+	status.Sequence = s.sequence
+	status.Timestamp = time.Now()
+	s.statusCache.Set(status.ID, append(history, status), ttlcache.DefaultTTL)
+
+	for sub := range s.subscribers[status.ID] {
+		s.publish(sub, status)
+	}
+
+	if status.Final() {
+		s.publishFinal(status)
+	}
+}
+
+// publishFinal stores the final status for replay and publishes it to subscribers of all requests
+func (s *StatusCache) publishFinal(status RequestStatus) {
+	if len(s.finalHistory) == FINAL_HISTORY_SIZE {
+		s.finalDropped = s.finalHistory[0].Sequence
+		s.finalHistory = s.finalHistory[1:]
+	}
+	s.finalHistory = append(s.finalHistory, status)
+
+	for sub := range s.finalSubscribers {
+		s.publish(sub, status)
+	}
+}
+
+// publish sends the status to the subscriber and closes its update
+// channel if its buffer is full so it can resume later
+func (s *StatusCache) publish(sub *subscriber, status RequestStatus) {
+	if sub.lagged {
+		return
+	}
+
+	select {
+	case sub.updateChn <- status:
+	default:
+		{
+			sub.lagged = true
+			close(sub.updateChn)
+		}
+	}
+}
+
+// canTransition prevents overriding final request states. A failed request can
+// only be signed by other peers or retried, while a signed request is never changed.
+func canTransition(current RequestStatus, next RequestStatus) bool {
+	switch current.State {
+	case SignedState:
+		return false
+	case FailedState:
+		return next.State == SignedState || next.State == ReceivedState
+	default:
+		return true
+	}
+}
+
+// attachable checks if a request with the params hash is a retry of the request
+// with the current status. Failed requests are never attached to so they can be retried,
+// while requests with unknown parameters, only seen signed by other peers, are always attached to.
+func attachable(current RequestStatus, paramsHash string) (bool, error) {
+	if current.State == FailedState {
+		return false, nil
+	}
+	if current.ParamsHash != "" && current.ParamsHash != paramsHash {
+		return false, errcode.New(errcode.RequestConflict, "%s: %s", ErrParamsConflict, current.ID).
+			WithCause(ErrParamsConflict)
+	}
+	return true, nil
+}
@@ -0,0 +1,291 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusCacheTestSuite struct {
+	suite.Suite
+
+	sc *cache.StatusCache
+}
+
+func TestRunStatusCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusCacheTestSuite))
+}
+
+func (s *StatusCacheTestSuite) SetupTest() {
+	s.sc = cache.NewStatusCache()
+}
+
+func (s *StatusCacheTestSuite) Test_Status_MissingStatus() {
+	_, err := s.sc.Status("invalid")
+
+	s.NotNil(err)
+}
+
+func (s *StatusCacheTestSuite) Test_Status_LatestStatus() {
+	s.sc.Update("1-id", cache.ReceivedState)
+	s.sc.UpdateConfirmations("1-id", 1, 5)
+
+	status, err := s.sc.Status("1-id")
+
+	s.Nil(err)
+	s.Equal(cache.WaitingConfirmationsState, status.State)
+	s.Equal(uint64(1), status.Confirmations)
+	s.Equal(uint64(5), status.RequiredConfirmations)
+	s.Equal(uint64(2), status.Sequence)
+}
+
+func (s *StatusCacheTestSuite) Test_Update_SignedStatusIsFinal() {
+	s.sc.Update("1-id", cache.SigningState)
+	s.sc.Update("1-id", cache.SignedState)
+	s.sc.Fail("1-id", fmt.Errorf("timeout"))
+
+	status, err := s.sc.Status("1-id")
+
+	s.Nil(err)
+	s.Equal(cache.SignedState, status.State)
+}
+
+func (s *StatusCacheTestSuite) Test_Update_FailedStatusCanBeSigned() {
+	s.sc.Update("1-id", cache.SigningState)
+	s.sc.Fail("1-id", fmt.Errorf("timeout"))
+	s.sc.Update("1-id", cache.VerifyingState)
+
+	status, err := s.sc.Status("1-id")
+	s.Nil(err)
+	s.Equal(cache.FailedState, status.State)
+	s.Equal("timeout", status.Reason)
+
+	s.sc.Update("1-id", cache.SignedState)
+
+	status, err = s.sc.Status("1-id")
+	s.Nil(err)
+	s.Equal(cache.SignedState, status.State)
+}
+
+func (s *StatusCacheTestSuite) Test_Fail_ErrorCode() {
+	s.sc.Fail("1-id", fmt.Errorf("invalid deposit"))
+	s.sc.Fail("2-id", fmt.Errorf("verification failed: %w", errcode.New(errcode.DepositNotFound, "deposit not found").
+		WithDetail("depositId", "1")))
+
+	status, err := s.sc.Status("1-id")
+	s.Nil(err)
+	s.Equal(errcode.Internal, status.ErrorCode)
+	s.Nil(status.Details)
+
+	status, err = s.sc.Status("2-id")
+	s.Nil(err)
+	s.Equal("verification failed: deposit not found", status.Reason)
+	s.Equal(errcode.DepositNotFound, status.ErrorCode)
+	s.Equal(map[string]any{"depositId": "1"}, status.Details)
+}
+
+func (s *StatusCacheTestSuite) Test_InProgress() {
+	s.sc.Update("1-id", cache.WaitingConfirmationsState)
+	s.sc.Update("2-id", cache.SigningState)
+	s.sc.Update("2-id", cache.SignedState)
+	s.sc.Fail("3-id", fmt.Errorf("invalid deposit"))
+
+	s.Equal([]string{"1-id"}, s.sc.InProgress())
+}
+
+func (s *StatusCacheTestSuite) Test_Subscribe_ReplaysAndStreamsStatuses() {
+	s.sc.Update("1-id", cache.ReceivedState)
+	s.sc.Update("1-id", cache.VerifyingState)
+	s.sc.Update("2-id", cache.ReceivedState)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	statusChn := make(chan cache.RequestStatus, 3)
+	go s.sc.Subscribe(ctx, "1-id", 1, statusChn)
+	time.Sleep(time.Millisecond * 50)
+	s.sc.Update("1-id", cache.SigningState)
+
+	status := <-statusChn
+	s.Equal(cache.VerifyingState, status.State)
+	status = <-statusChn
+	s.Equal(cache.SigningState, status.State)
+	s.Equal(uint64(4), status.Sequence)
+}
+
+func (s *StatusCacheTestSuite) Test_Subscribe_SlowSubscriberDropped() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	statusChn := make(chan cache.RequestStatus)
+	errChn := make(chan error)
+	go func() {
+		errChn <- s.sc.Subscribe(ctx, "1-id", 0, statusChn)
+	}()
+	time.Sleep(time.Millisecond * 50)
+	for i := 0; i <= cache.STATUS_BUFFER_SIZE+1; i++ {
+		s.sc.Update("1-id", cache.VerifyingState)
+	}
+
+	received := 0
+	for {
+		select {
+		case <-statusChn:
+			received++
+		case err := <-errChn:
+			{
+				s.ErrorIs(err, cache.ErrSlowSubscriber)
+				s.Equal(cache.STATUS_BUFFER_SIZE+1, received)
+				return
+			}
+		}
+	}
+}
+
+func (s *StatusCacheTestSuite) Test_Receive_CallerKeptOnStatuses() {
+	err := s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "hash")
+	s.Nil(err)
+	s.sc.Update("1-id", cache.SigningState)
+
+	status, err := s.sc.Status("1-id")
+
+	s.Nil(err)
+	s.Equal(cache.SigningState, status.State)
+	s.Equal("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", status.Caller)
+	s.Equal("hash", status.ParamsHash)
+}
+
+func (s *StatusCacheTestSuite) Test_Sign_DigestKeptOnStatuses() {
+	err := s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "hash")
+	s.Nil(err)
+	s.sc.Sign("1-id", []byte{1, 2})
+	s.sc.Update("1-id", cache.SignedState)
+
+	status, err := s.sc.Status("1-id")
+
+	s.Nil(err)
+	s.Equal(cache.SignedState, status.State)
+	s.Equal("0x0102", status.Digest)
+}
+
+func (s *StatusCacheTestSuite) Test_Receive_DuplicateRequest() {
+	err := s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "hash")
+	s.Nil(err)
+	s.sc.Update("1-id", cache.SigningState)
+
+	err = s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "hash")
+	s.ErrorIs(err, cache.ErrDuplicateRequest)
+	err = s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "other-hash")
+	s.ErrorIs(err, cache.ErrParamsConflict)
+
+	status, err := s.sc.Status("1-id")
+	s.Nil(err)
+	s.Equal(cache.SigningState, status.State)
+}
+
+func (s *StatusCacheTestSuite) Test_Receive_FailedRequestRetried() {
+	err := s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "hash")
+	s.Nil(err)
+	s.sc.Fail("1-id", fmt.Errorf("invalid deposit"))
+
+	err = s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "other-hash")
+
+	s.Nil(err)
+	status, err := s.sc.Status("1-id")
+	s.Nil(err)
+	s.Equal(cache.ReceivedState, status.State)
+	s.Equal("other-hash", status.ParamsHash)
+}
+
+func (s *StatusCacheTestSuite) Test_Attach() {
+	attach, err := s.sc.Attach("1-id", "hash")
+	s.Nil(err)
+	s.False(attach)
+
+	err = s.sc.Receive("1-id", "0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657", "hash")
+	s.Nil(err)
+
+	attach, err = s.sc.Attach("1-id", "hash")
+	s.Nil(err)
+	s.True(attach)
+	_, err = s.sc.Attach("1-id", "other-hash")
+	s.ErrorIs(err, cache.ErrParamsConflict)
+}
+
+func (s *StatusCacheTestSuite) Test_SubscribeFinal_ReplaysAndStreamsFinalStatuses() {
+	s.sc.Update("1-id", cache.SigningState)
+	s.sc.Update("1-id", cache.SignedState)
+	s.sc.Fail("2-id", fmt.Errorf("invalid deposit"))
+	s.sc.Fail("3-id", fmt.Errorf("invalid deposit"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	statusChn := make(chan cache.RequestStatus, 3)
+	go func() {
+		_ = s.sc.SubscribeFinal(ctx, 2, statusChn)
+	}()
+	time.Sleep(time.Millisecond * 50)
+	s.sc.Update("4-id", cache.VerifyingState)
+	s.sc.Update("4-id", cache.SignedState)
+
+	status := <-statusChn
+	s.Equal("2-id", status.ID)
+	status = <-statusChn
+	s.Equal("3-id", status.ID)
+	status = <-statusChn
+	s.Equal("4-id", status.ID)
+	s.Equal(cache.SignedState, status.State)
+	s.Equal(uint64(6), status.Sequence)
+}
+
+func (s *StatusCacheTestSuite) Test_SubscribeFinal_ReplayGap() {
+	for i := 0; i < cache.FINAL_HISTORY_SIZE+2; i++ {
+		s.sc.Fail(fmt.Sprintf("1-%d", i), fmt.Errorf("invalid deposit"))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the second status is no longer retained
+	err := s.sc.SubscribeFinal(ctx, 1, make(chan cache.RequestStatus))
+	s.ErrorIs(err, cache.ErrReplayGap)
+	err = s.sc.SubscribeFinal(ctx, 2, make(chan cache.RequestStatus))
+	s.ErrorIs(err, context.Canceled)
+	// the sequence was issued before a restart
+	err = s.sc.SubscribeFinal(ctx, cache.FINAL_HISTORY_SIZE+3, make(chan cache.RequestStatus))
+	s.ErrorIs(err, cache.ErrReplayGap)
+}
+
+func (s *StatusCacheTestSuite) Test_SubscribeFinal_SlowSubscriberDropped() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	statusChn := make(chan cache.RequestStatus)
+	errChn := make(chan error)
+	go func() {
+		errChn <- s.sc.SubscribeFinal(ctx, 0, statusChn)
+	}()
+	time.Sleep(time.Millisecond * 50)
+	for i := 0; i <= cache.STATUS_BUFFER_SIZE+1; i++ {
+		s.sc.Update(fmt.Sprintf("1-%d", i), cache.SignedState)
+	}
+
+	received := 0
+	for {
+		select {
+		case <-statusChn:
+			received++
+		case err := <-errChn:
+			{
+				s.ErrorIs(err, cache.ErrSlowSubscriber)
+				s.Equal(cache.STATUS_BUFFER_SIZE+1, received)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	PRUNE_INTERVAL = time.Hour
+
+	recordPrefix = "signature/record/"
+	timePrefix   = "signature/time/"
+	callerPrefix = "signature/caller/"
+)
+
+// SignatureRecord is a persisted signature with the metadata of its signing request.
+// Caller and params hash are only known to relayers that received the request.
+type SignatureRecord struct {
+	ID         string    `json:"id"`
+	Caller     string    `json:"caller,omitempty"`
+	ParamsHash string    `json:"paramsHash,omitempty"`
+	Digest     string    `json:"digest,omitempty"`
+	Signature  []byte    `json:"signature"`
+	SignedAt   time.Time `json:"signedAt"`
+}
+
+type Database interface {
+	GetByKey(key []byte) ([]byte, error)
+	Write(batch *leveldb.Batch) error
+	Iterate(start []byte, limit []byte, fn func(key []byte, value []byte) bool) error
+}
+
+// SignatureStore persists signatures for the retention period. Records are
+// indexed by signing time and by caller so they can be queried by time range.
+type SignatureStore struct {
+	db        Database
+	retention time.Duration
+
+	lock sync.Mutex
+}
+
+func NewSignatureStore(db Database, retention time.Duration) *SignatureStore {
+	return &SignatureStore{
+		db:        db,
+		retention: retention,
+	}
+}
+
+// Store persists the signature record. Records of already stored
+// signatures are kept as signatures are never changed.
+func (s *SignatureStore) Store(record SignatureRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, err := s.db.GetByKey(recordKey(record.ID))
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, leveldb.ErrNotFound) {
+		return err
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	batch.Put(recordKey(record.ID), value)
+	batch.Put(timeKey(record.SignedAt, record.ID), []byte(record.ID))
+	if record.Caller != "" {
+		batch.Put(callerKey(record.Caller, record.SignedAt, record.ID), []byte(record.ID))
+	}
+	return s.db.Write(batch)
+}
+
+// Signature returns the record of the signature with the given ID
+func (s *SignatureStore) Signature(id string) (SignatureRecord, error) {
+	record, err := s.record(id)
+	if err != nil {
+		return SignatureRecord{}, err
+	}
+	if record.SignedAt.Before(s.expiry()) {
+		return SignatureRecord{}, fmt.Errorf("no signature found with id %s", id)
+	}
+	return record, nil
+}
+
+// Signatures returns up to limit records signed in the range [from, to] ordered by
+// signing time. Records of all callers are returned if the caller is empty.
+func (s *SignatureStore) Signatures(caller string, from time.Time, to time.Time, limit int) ([]SignatureRecord, error) {
+	if from.Before(s.expiry()) {
+		from = s.expiry()
+	}
+
+	prefix := []byte(timePrefix)
+	if caller != "" {
+		prefix = callerIndex(caller)
+	}
+	ids := make([]string, 0)
+	err := s.db.Iterate(
+		indexKey(prefix, from, ""),
+		indexKey(prefix, to.Add(time.Nanosecond), ""),
+		func(key []byte, value []byte) bool {
+			ids = append(ids, string(value))
+			return len(ids) < limit
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]SignatureRecord, 0, len(ids))
+	for _, id := range ids {
+		record, err := s.record(id)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Prune deletes all records signed before the retention period
+func (s *SignatureStore) Prune() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ids := make([]string, 0)
+	err := s.db.Iterate(
+		[]byte(timePrefix),
+		indexKey([]byte(timePrefix), s.expiry(), ""),
+		func(key []byte, value []byte) bool {
+			ids = append(ids, string(value))
+			return true
+		})
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	for _, id := range ids {
+		record, err := s.record(id)
+		if err != nil {
+			return err
+		}
+
+		batch.Delete(recordKey(record.ID))
+		batch.Delete(timeKey(record.SignedAt, record.ID))
+		if record.Caller != "" {
+			batch.Delete(callerKey(record.Caller, record.SignedAt, record.ID))
+		}
+	}
+	log.Debug().Msgf("Pruning %d expired signatures", len(ids))
+	return s.db.Write(batch)
+}
+
+// Run prunes expired records every PRUNE_INTERVAL until the context is cancelled
+func (s *SignatureStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(PRUNE_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		err := s.Prune()
+		if err != nil {
+			log.Warn().Msgf("Failed pruning expired signatures: %s", err)
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *SignatureStore) record(id string) (SignatureRecord, error) {
+	value, err := s.db.GetByKey(recordKey(id))
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return SignatureRecord{}, fmt.Errorf("no signature found with id %s", id)
+	}
+	if err != nil {
+		return SignatureRecord{}, err
+	}
+
+	record := SignatureRecord{}
+	err = json.Unmarshal(value, &record)
+	return record, err
+}
+
+func (s *SignatureStore) expiry() time.Time {
+	return time.Now().Add(-s.retention)
+}
+
+func recordKey(id string) []byte {
+	return []byte(recordPrefix + id)
+}
+
+func timeKey(signedAt time.Time, id string) []byte {
+	return indexKey([]byte(timePrefix), signedAt, id)
+}
+
+func callerKey(caller string, signedAt time.Time, id string) []byte {
+	return indexKey(callerIndex(caller), signedAt, id)
+}
+
+func callerIndex(caller string) []byte {
+	return []byte(callerPrefix + strings.ToLower(caller) + "/")
+}
+
+// indexKey orders keys by time by encoding the timestamp in big endian after the prefix
+func indexKey(prefix []byte, t time.Time, id string) []byte {
+	var timestamp uint64
+	if t.After(time.Unix(0, 0)) {
+		// nolint:gosec
+		timestamp = uint64(t.UnixNano())
+	}
+
+	key := make([]byte, 0, len(prefix)+8+len(id))
+	key = append(key, prefix...)
+	key = binary.BigEndian.AppendUint64(key, timestamp)
+	return append(key, id...)
+}
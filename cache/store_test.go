@@ -0,0 +1,107 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/lvldb"
+	"github.com/stretchr/testify/suite"
+)
+
+type SignatureStoreTestSuite struct {
+	suite.Suite
+
+	db    *lvldb.LVLDB
+	store *cache.SignatureStore
+}
+
+func TestRunSignatureStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SignatureStoreTestSuite))
+}
+
+func (s *SignatureStoreTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.store = cache.NewSignatureStore(db, time.Hour)
+}
+
+func (s *SignatureStoreTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+func (s *SignatureStoreTestSuite) record(id string, caller string, signedAt time.Time) cache.SignatureRecord {
+	record := cache.SignatureRecord{
+		ID:        id,
+		Caller:    caller,
+		Signature: []byte(id),
+		SignedAt:  signedAt,
+	}
+	s.Nil(s.store.Store(record))
+	return record
+}
+
+func (s *SignatureStoreTestSuite) Test_Signature_MissingSignature() {
+	_, err := s.store.Signature("invalid")
+
+	s.NotNil(err)
+}
+
+func (s *SignatureStoreTestSuite) Test_Store_KeepsExistingRecord() {
+	now := time.Now()
+	s.record("1-1", "0xcaller", now)
+
+	err := s.store.Store(cache.SignatureRecord{
+		ID:        "1-1",
+		Signature: []byte("other"),
+		SignedAt:  now.Add(time.Minute),
+	})
+	s.Nil(err)
+
+	record, err := s.store.Signature("1-1")
+	s.Nil(err)
+	s.Equal([]byte("1-1"), record.Signature)
+	s.Equal("0xcaller", record.Caller)
+	s.True(now.Equal(record.SignedAt))
+}
+
+func (s *SignatureStoreTestSuite) Test_Signatures_FiltersByCallerAndTime() {
+	now := time.Now()
+	s.record("1-1", "0xAbc", now.Add(-time.Minute*30))
+	s.record("1-2", "0xdef", now.Add(-time.Minute*20))
+	s.record("1-3", "0xabc", now.Add(-time.Minute*10))
+	s.record("1-4", "0xabc", now)
+
+	records, err := s.store.Signatures("0xABC", now.Add(-time.Minute*30), now.Add(-time.Minute*10), 10)
+	s.Nil(err)
+	s.Len(records, 2)
+	s.Equal("1-1", records[0].ID)
+	s.Equal("1-3", records[1].ID)
+
+	records, err = s.store.Signatures("", time.Time{}, now, 3)
+	s.Nil(err)
+	s.Len(records, 3)
+	s.Equal("1-1", records[0].ID)
+	s.Equal("1-2", records[1].ID)
+	s.Equal("1-3", records[2].ID)
+}
+
+func (s *SignatureStoreTestSuite) Test_Prune_DeletesExpiredSignatures() {
+	now := time.Now()
+	s.record("1-1", "0xabc", now.Add(-time.Hour*2))
+	s.record("1-2", "0xabc", now)
+
+	_, err := s.store.Signature("1-1")
+	s.NotNil(err)
+
+	err = s.store.Prune()
+	s.Nil(err)
+
+	records, err := s.store.Signatures("0xabc", time.Time{}, now, 10)
+	s.Nil(err)
+	s.Len(records, 1)
+	s.Equal("1-2", records[0].ID)
+	_, err = s.db.GetByKey([]byte("signature/record/1-1"))
+	s.NotNil(err)
+}
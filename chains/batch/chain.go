@@ -0,0 +1,44 @@
+package batch
+
+import (
+	"context"
+
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+const (
+	BATCH_DOMAIN_ID uint64 = 2300054446 // lower 32bits of sha256 hash of "batch"
+)
+
+type MessageHandler interface {
+	HandleMessage(m *message.Message) (*proposal.Proposal, error)
+}
+
+// BatchChain is a virtual domain that receives batch signing messages
+// containing signing requests for multiple chains and protocols.
+type BatchChain struct {
+	messageHandler MessageHandler
+	domainID       uint64
+}
+
+func NewBatchChain(messageHandler MessageHandler) *BatchChain {
+	return &BatchChain{
+		messageHandler: messageHandler,
+		domainID:       BATCH_DOMAIN_ID,
+	}
+}
+
+func (c *BatchChain) PollEvents(_ context.Context) {}
+
+func (c *BatchChain) ReceiveMessage(m *message.Message) (*proposal.Proposal, error) {
+	return c.messageHandler.HandleMessage(m)
+}
+
+func (c *BatchChain) Write(_ []*proposal.Proposal) error {
+	return nil
+}
+
+func (c *BatchChain) DomainID() uint64 {
+	return c.domainID
+}
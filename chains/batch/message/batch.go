@@ -0,0 +1,232 @@
+package message
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/audit"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/sprintertech/sprinter-signing/tss"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+type Coordinator interface {
+	Execute(ctx context.Context, tssProcesses []tss.TssProcess, resultChn chan interface{}, coordinator peer.ID) error
+}
+
+type StatusTracker interface {
+	Update(id string, state cache.RequestState)
+	Sign(id string, digest []byte)
+	Fail(id string, err error)
+}
+
+// Digester verifies the JSON encoded message data of a single
+// signing request and returns the request ID and the hash to be signed.
+type Digester interface {
+	Digest(payload []byte) (string, []byte, error)
+}
+
+type digestResult struct {
+	id   string
+	hash []byte
+	err  error
+}
+
+type BatchMessageHandler struct {
+	digesters map[string]Digester
+
+	coordinator Coordinator
+	host        host.Host
+	comm        comm.Communication
+	fetcher     signing.SaveDataFetcher
+	auditLog    signing.AuditLogger
+	statuses    StatusTracker
+	sigChn      chan any
+}
+
+func NewBatchMessageHandler(
+	coordinator Coordinator,
+	host host.Host,
+	comm comm.Communication,
+	fetcher signing.SaveDataFetcher,
+	auditLog signing.AuditLogger,
+	statuses StatusTracker,
+	sigChn chan any,
+) *BatchMessageHandler {
+	return &BatchMessageHandler{
+		digesters:   make(map[string]Digester),
+		coordinator: coordinator,
+		host:        host,
+		comm:        comm,
+		fetcher:     fetcher,
+		auditLog:    auditLog,
+		statuses:    statuses,
+		sigChn:      sigChn,
+	}
+}
+
+// RegisterDigester registers the digester used to verify batch items
+// of the message type for the given chain
+func (h *BatchMessageHandler) RegisterDigester(chainID uint64, msgType message.MessageType, digester Digester) {
+	h.digesters[digesterKey(chainID, msgType)] = digester
+}
+
+// HandleMessage verifies each batch item on its own and signs all valid items
+// in a single coordinated tss execution. Verification errors are sent through the
+// error channel in the same order as the batch items, with nil for valid items.
+func (h *BatchMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
+	data := m.Data.(*BatchData)
+
+	log.Info().Msgf("Handling batch message with %d items", len(data.Items))
+
+	err := h.notify(data)
+	if err != nil {
+		log.Warn().Msgf("Failed to notify relayers because of %s", err)
+	}
+
+	results := h.digest(data.Items)
+	errs := make([]error, len(results))
+	processes := make([]tss.TssProcess, 0)
+	ids := make(map[string][]byte)
+	for i, result := range results {
+		if result.err != nil {
+			errs[i] = result.err
+			continue
+		}
+
+		if _, ok := ids[result.id]; ok {
+			errs[i] = errcode.New(errcode.InvalidRequest, "duplicate batch item %s", result.id)
+			continue
+		}
+
+		signing, err := signing.NewSigning(
+			new(big.Int).SetBytes(result.hash),
+			result.id,
+			result.id,
+			h.host,
+			h.comm,
+			h.fetcher)
+		if err != nil {
+			err = errcode.Wrap(errcode.SigningFailed, err)
+			h.statuses.Fail(result.id, err)
+			errs[i] = err
+			continue
+		}
+
+		signing.Audit(h.auditLog, audit.Request{
+			Protocol:    string(data.Items[i].Type),
+			Params:      data.Items[i].Data,
+			Coordinator: data.Coordinator,
+		})
+		ids[result.id] = result.hash
+		processes = append(processes, signing)
+	}
+	data.ErrChn <- errs
+
+	if len(processes) == 0 {
+		return nil, fmt.Errorf("no valid batch items")
+	}
+
+	for id, digest := range ids {
+		h.statuses.Sign(id, digest)
+	}
+	err = errcode.Wrap(errcode.SigningFailed, h.coordinator.Execute(context.Background(), processes, h.sigChn, data.Coordinator))
+	if err != nil {
+		for id := range ids {
+			h.statuses.Fail(id, err)
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// digest verifies all batch items concurrently
+func (h *BatchMessageHandler) digest(items []BatchItem) []digestResult {
+	results := make([]digestResult, len(items))
+	wg := sync.WaitGroup{}
+	for i, item := range items {
+		digester, ok := h.digesters[digesterKey(item.Destination, item.Type)]
+		if !ok {
+			results[i] = digestResult{
+				err: errcode.New(errcode.ProtocolNotSupported, "message type %s not supported on chain %d", item.Type, item.Destination),
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			id, hash, err := digester.Digest(item.Data)
+			results[i] = digestResult{
+				id:   id,
+				hash: hash,
+				err:  err,
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (h *BatchMessageHandler) Listen(ctx context.Context) {
+	msgChn := make(chan *comm.WrappedMessage)
+	subID := h.comm.Subscribe(comm.BatchSessionID, comm.BatchMsg, msgChn)
+
+	for {
+		select {
+		case wMsg := <-msgChn:
+			{
+				go func(wMsg *comm.WrappedMessage) {
+					d := &BatchData{}
+					err := json.Unmarshal(wMsg.Payload, d)
+					if err != nil {
+						log.Warn().Msgf("Failed unmarshaling batch message: %s", err)
+						return
+					}
+
+					d.ErrChn = make(chan []error, 1)
+					msg := NewBatchMessage(d.Source, d.Destination, d)
+					_, err = h.HandleMessage(msg)
+					if err != nil {
+						log.Err(err).Msgf("Failed handling batch message %+v because of: %s", msg, err)
+					}
+				}(wMsg)
+			}
+		case <-ctx.Done():
+			{
+				h.comm.UnSubscribe(subID)
+				return
+			}
+		}
+	}
+}
+
+func (h *BatchMessageHandler) notify(data *BatchData) error {
+	if data.Coordinator != peer.ID("") {
+		return nil
+	}
+
+	data.Coordinator = h.host.ID()
+	msgBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return h.comm.Broadcast(h.host.Peerstore().Peers(), msgBytes, comm.BatchMsg, comm.BatchSessionID)
+}
+
+func digesterKey(chainID uint64, msgType message.MessageType) string {
+	return fmt.Sprintf("%d-%s", chainID, msgType)
+}
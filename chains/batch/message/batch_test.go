@@ -0,0 +1,152 @@
+package message_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/chains/batch/message"
+	mock_message "github.com/sprintertech/sprinter-signing/chains/batch/message/mock"
+	"github.com/sprintertech/sprinter-signing/comm"
+	mock_communication "github.com/sprintertech/sprinter-signing/comm/mock"
+	mock_host "github.com/sprintertech/sprinter-signing/comm/p2p/mock/host"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/sprintertech/sprinter-signing/tss"
+	mock_tss "github.com/sprintertech/sprinter-signing/tss/ecdsa/common/mock"
+	"github.com/stretchr/testify/suite"
+	coreMessage "github.com/sygmaprotocol/sygma-core/relayer/message"
+	"go.uber.org/mock/gomock"
+)
+
+type BatchMessageHandlerTestSuite struct {
+	suite.Suite
+
+	mockCommunication *mock_communication.MockCommunication
+	mockCoordinator   *mock_message.MockCoordinator
+	mockHost          *mock_host.MockHost
+	mockFetcher       *mock_tss.MockSaveDataFetcher
+	mockDigester      *mock_message.MockDigester
+
+	handler  *message.BatchMessageHandler
+	sigChn   chan interface{}
+	statuses *cache.StatusCache
+}
+
+func TestRunBatchMessageHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchMessageHandlerTestSuite))
+}
+
+func (s *BatchMessageHandlerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+
+	s.mockCommunication = mock_communication.NewMockCommunication(ctrl)
+	s.mockCoordinator = mock_message.NewMockCoordinator(ctrl)
+	s.mockHost = mock_host.NewMockHost(ctrl)
+	s.mockHost.EXPECT().ID().Return(peer.ID("")).AnyTimes()
+
+	s.mockFetcher = mock_tss.NewMockSaveDataFetcher(ctrl)
+	s.mockFetcher.EXPECT().UnlockKeyshare().AnyTimes()
+	s.mockFetcher.EXPECT().LockKeyshare().AnyTimes()
+	s.mockFetcher.EXPECT().GetKeyshare().AnyTimes().Return(keyshare.ECDSAKeyshare{}, nil)
+
+	s.mockDigester = mock_message.NewMockDigester(ctrl)
+
+	s.mockCommunication.EXPECT().Broadcast(
+		gomock.Any(),
+		gomock.Any(),
+		comm.BatchMsg,
+		comm.BatchSessionID,
+	).Return(nil)
+	p, _ := pstoremem.NewPeerstore()
+	s.mockHost.EXPECT().Peerstore().Return(p)
+
+	s.sigChn = make(chan interface{}, 1)
+	s.statuses = cache.NewStatusCache()
+
+	s.handler = message.NewBatchMessageHandler(
+		s.mockCoordinator,
+		s.mockHost,
+		s.mockCommunication,
+		s.mockFetcher,
+		nil,
+		s.statuses,
+		s.sigChn,
+	)
+	s.handler.RegisterDigester(1, coreMessage.MessageType(comm.AcrossMsg.String()), s.mockDigester)
+}
+
+func (s *BatchMessageHandlerTestSuite) Test_HandleMessage_NoValidItems() {
+	errChn := make(chan []error, 1)
+	s.mockDigester.EXPECT().Digest(gomock.Any()).Return("1-1", nil, fmt.Errorf("invalid deposit"))
+
+	m := message.NewBatchMessage(0, 10, &message.BatchData{
+		ErrChn: errChn,
+		Items: []message.BatchItem{
+			{
+				Type:        coreMessage.MessageType(comm.AcrossMsg.String()),
+				Destination: 1,
+				Data:        json.RawMessage("{}"),
+			},
+			{
+				Type:        coreMessage.MessageType(comm.LighterMsg.String()),
+				Destination: 1,
+				Data:        json.RawMessage("{}"),
+			},
+		},
+	})
+	prop, err := s.handler.HandleMessage(m)
+
+	s.Nil(prop)
+	s.NotNil(err)
+
+	errs := <-errChn
+	s.Len(errs, 2)
+	s.NotNil(errs[0])
+	s.NotNil(errs[1])
+}
+
+func (s *BatchMessageHandlerTestSuite) Test_HandleMessage_ValidItemsSignedTogether() {
+	errChn := make(chan []error, 1)
+	s.mockDigester.EXPECT().Digest(json.RawMessage(`{"id":1}`)).Return("1-1", []byte{1}, nil)
+	s.mockDigester.EXPECT().Digest(json.RawMessage(`{"id":2}`)).Return("1-2", nil, fmt.Errorf("invalid deposit"))
+	s.mockDigester.EXPECT().Digest(json.RawMessage(`{"id":3}`)).Return("1-3", []byte{3}, nil)
+	s.mockDigester.EXPECT().Digest(json.RawMessage(`{"id":4}`)).Return("1-3", []byte{3}, nil)
+	s.mockCoordinator.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, processes []tss.TssProcess, _ chan interface{}, _ peer.ID) error {
+			s.Len(processes, 2)
+			s.Equal("1-1", processes[0].SessionID())
+			s.Equal("1-3", processes[1].SessionID())
+			return nil
+		})
+
+	items := []message.BatchItem{}
+	for i := 1; i <= 4; i++ {
+		items = append(items, message.BatchItem{
+			Type:        coreMessage.MessageType(comm.AcrossMsg.String()),
+			Destination: 1,
+			Data:        json.RawMessage(fmt.Sprintf(`{"id":%d}`, i)),
+		})
+	}
+	m := message.NewBatchMessage(0, 10, &message.BatchData{
+		ErrChn: errChn,
+		Items:  items,
+	})
+	prop, err := s.handler.HandleMessage(m)
+
+	s.Nil(prop)
+	s.Nil(err)
+
+	errs := <-errChn
+	s.Len(errs, 4)
+	s.Nil(errs[0])
+	s.NotNil(errs[1])
+	s.Nil(errs[2])
+	s.NotNil(errs[3])
+
+	status, err := s.statuses.Status("1-1")
+	s.Nil(err)
+	s.Equal(cache.SigningState, status.State)
+}
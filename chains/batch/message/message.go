@@ -0,0 +1,37 @@
+package message
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sygmaprotocol/sygma-core/relayer/message"
+)
+
+// BatchItem is a single signing request of the batch with the
+// JSON encoded message data of the according protocol
+type BatchItem struct {
+	Type        message.MessageType
+	Destination uint64
+	Data        json.RawMessage
+}
+
+type BatchData struct {
+	ErrChn chan []error `json:"-"`
+
+	Items       []BatchItem
+	Coordinator peer.ID
+	Source      uint64
+	Destination uint64
+}
+
+func NewBatchMessage(source, destination uint64, batchData *BatchData) *message.Message {
+	return &message.Message{
+		Source:      source,
+		Destination: destination,
+		Data:        batchData,
+		Type:        message.MessageType(comm.BatchMsg.String()),
+		Timestamp:   time.Now(),
+	}
+}
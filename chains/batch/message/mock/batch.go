@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/batch/message/batch.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./chains/batch/message/batch.go -destination=./chains/batch/message/mock/batch.go
+//
+
+// Package mock_message is a generated GoMock package.
+package mock_message
+
+import (
+	context "context"
+	reflect "reflect"
+
+	peer "github.com/libp2p/go-libp2p/core/peer"
+	cache "github.com/sprintertech/sprinter-signing/cache"
+	tss "github.com/sprintertech/sprinter-signing/tss"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCoordinator is a mock of Coordinator interface.
+type MockCoordinator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoordinatorMockRecorder
+	isgomock struct{}
+}
+
+// MockCoordinatorMockRecorder is the mock recorder for MockCoordinator.
+type MockCoordinatorMockRecorder struct {
+	mock *MockCoordinator
+}
+
+// NewMockCoordinator creates a new mock instance.
+func NewMockCoordinator(ctrl *gomock.Controller) *MockCoordinator {
+	mock := &MockCoordinator{ctrl: ctrl}
+	mock.recorder = &MockCoordinatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoordinator) EXPECT() *MockCoordinatorMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockCoordinator) Execute(ctx context.Context, tssProcesses []tss.TssProcess, resultChn chan any, coordinator peer.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, tssProcesses, resultChn, coordinator)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockCoordinatorMockRecorder) Execute(ctx, tssProcesses, resultChn, coordinator any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockCoordinator)(nil).Execute), ctx, tssProcesses, resultChn, coordinator)
+}
+
+// MockStatusTracker is a mock of StatusTracker interface.
+type MockStatusTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusTrackerMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusTrackerMockRecorder is the mock recorder for MockStatusTracker.
+type MockStatusTrackerMockRecorder struct {
+	mock *MockStatusTracker
+}
+
+// NewMockStatusTracker creates a new mock instance.
+func NewMockStatusTracker(ctrl *gomock.Controller) *MockStatusTracker {
+	mock := &MockStatusTracker{ctrl: ctrl}
+	mock.recorder = &MockStatusTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusTracker) EXPECT() *MockStatusTrackerMockRecorder {
+	return m.recorder
+}
+
+// Fail mocks base method.
+func (m *MockStatusTracker) Fail(id string, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fail", id, err)
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockStatusTrackerMockRecorder) Fail(id, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockStatusTracker)(nil).Fail), id, err)
+}
+
+// Sign mocks base method.
+func (m *MockStatusTracker) Sign(id string, digest []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Sign", id, digest)
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockStatusTrackerMockRecorder) Sign(id, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockStatusTracker)(nil).Sign), id, digest)
+}
+
+// Update mocks base method.
+func (m *MockStatusTracker) Update(id string, state cache.RequestState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update", id, state)
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusTrackerMockRecorder) Update(id, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatusTracker)(nil).Update), id, state)
+}
+
+// MockDigester is a mock of Digester interface.
+type MockDigester struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigesterMockRecorder
+	isgomock struct{}
+}
+
+// MockDigesterMockRecorder is the mock recorder for MockDigester.
+type MockDigesterMockRecorder struct {
+	mock *MockDigester
+}
+
+// NewMockDigester creates a new mock instance.
+func NewMockDigester(ctrl *gomock.Controller) *MockDigester {
+	mock := &MockDigester{ctrl: ctrl}
+	mock.recorder = &MockDigesterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigester) EXPECT() *MockDigesterMockRecorder {
+	return m.recorder
+}
+
+// Digest mocks base method.
+func (m *MockDigester) Digest(payload []byte) (string, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Digest", payload)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Digest indicates an expected call of Digest.
+func (mr *MockDigesterMockRecorder) Digest(payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Digest", reflect.TypeOf((*MockDigester)(nil).Digest), payload)
+}
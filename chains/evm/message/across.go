@@ -10,14 +10,18 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/audit"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains"
 	"github.com/sprintertech/sprinter-signing/chains/evm/calls/events"
 	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sprintertech/sprinter-signing/config"
+	"github.com/sprintertech/sprinter-signing/errcode"
 	"github.com/sprintertech/sprinter-signing/tss"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -38,18 +42,39 @@ type Coordinator interface {
 	Execute(ctx context.Context, tssProcesses []tss.TssProcess, resultChn chan interface{}, coordinator peer.ID) error
 }
 
+type StatusTracker interface {
+	Receive(id string, caller string, paramsHash string) error
+	Update(id string, state cache.RequestState)
+	Sign(id string, digest []byte)
+	UpdateConfirmations(id string, confirmations uint64, requiredConfirmations uint64)
+	Fail(id string, err error)
+}
+
 type ConfirmationWatcher interface {
 	WaitForTokenConfirmations(
 		ctx context.Context,
+		id string,
 		chainID uint64,
 		txHash common.Hash,
 		token common.Address,
 		amount *big.Int) error
 	WaitForOrderConfirmations(
 		ctx context.Context,
+		id string,
 		chainID uint64,
 		txHash common.Hash,
 		orderValue float64) error
+	TokenConfirmations(
+		ctx context.Context,
+		chainID uint64,
+		txHash common.Hash,
+		token common.Address,
+		amount *big.Int) (uint64, uint64, error)
+	OrderConfirmations(
+		ctx context.Context,
+		chainID uint64,
+		txHash common.Hash,
+		orderValue float64) (uint64, uint64, error)
 }
 
 type DepositFetcher interface {
@@ -65,10 +90,13 @@ type AcrossMessageHandler struct {
 	confirmationWatcher ConfirmationWatcher
 	depositFetcher      DepositFetcher
 
-	coordinator Coordinator
-	host        host.Host
-	comm        comm.Communication
-	fetcher     signing.SaveDataFetcher
+	coordinator   Coordinator
+	host          host.Host
+	comm          comm.Communication
+	fetcher       signing.SaveDataFetcher
+	auditLog      signing.AuditLogger
+	presignatures *signing.PresignaturePool
+	statuses      StatusTracker
 
 	sigChn chan any
 }
@@ -82,8 +110,11 @@ func NewAcrossMessageHandler(
 	host host.Host,
 	comm comm.Communication,
 	fetcher signing.SaveDataFetcher,
+	auditLog signing.AuditLogger,
+	presignatures *signing.PresignaturePool,
 	depositFetcher DepositFetcher,
 	confirmationWatcher ConfirmationWatcher,
+	statuses StatusTracker,
 	sigChn chan any,
 ) *AcrossMessageHandler {
 	return &AcrossMessageHandler{
@@ -95,9 +126,12 @@ func NewAcrossMessageHandler(
 		host:                host,
 		comm:                comm,
 		fetcher:             fetcher,
+		auditLog:            auditLog,
+		presignatures:       presignatures,
 		sigChn:              sigChn,
 		confirmationWatcher: confirmationWatcher,
 		depositFetcher:      depositFetcher,
+		statuses:            statuses,
 	}
 }
 
@@ -106,39 +140,157 @@ func NewAcrossMessageHandler(
 // cache through the result channel.
 func (h *AcrossMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
 	data := m.Data.(*AcrossData)
+	id := fmt.Sprintf("%d-%s", h.chainID, data.DepositId)
 
 	log.Info().Str("depositId", data.DepositId.String()).Msgf("Handling across message %+v", data)
 
-	sourceChainID := h.chainID
-	repaymentAddress, ok := h.repayers[data.RepaymentChainID]
-	if !ok {
-		err := fmt.Errorf("invalid repayment chain %d", data.RepaymentChainID)
+	err := h.statuses.Receive(id, data.Caller.Hex(), data.ParamsHash())
+	if err != nil {
 		data.ErrChn <- err
 		return nil, err
 	}
 
-	err := h.notify(data)
+	err = h.notify(data)
 	if err != nil {
 		log.Warn().Msgf("Failed to notify relayers because of %s", err)
 	}
 
-	d, err := h.depositFetcher.Deposit(context.Background(), data.DepositTxHash, data.DepositId)
+	unlockHash, err := h.digest(id, data)
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return nil, err
+	}
+
+	err = errcode.Wrap(errcode.SigningFailed, h.sign(id, unlockHash, data))
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// Digest verifies the JSON encoded across data and returns the request ID
+// and the unlock hash that should be signed for it.
+func (h *AcrossMessageHandler) Digest(payload []byte) (string, []byte, error) {
+	data := &AcrossData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return "", nil, err
+	}
+	data.ErrChn = make(chan error, 1)
+	id := fmt.Sprintf("%d-%s", h.chainID, data.DepositId)
+
+	err = h.statuses.Receive(id, data.Caller.Hex(), data.ParamsHash())
+	if err != nil {
+		return id, nil, err
+	}
+
+	unlockHash, err := h.digest(id, data)
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return id, nil, err
+	}
+	return id, unlockHash, nil
+}
+
+// Preview verifies the JSON encoded across data without waiting for confirmations
+// and returns the unlock hash and the typed data that would be signed for it.
+func (h *AcrossMessageHandler) Preview(payload []byte) (*signature.Preview, error) {
+	data := &AcrossData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return nil, err
+	}
+
+	d, sourceTokenAddress, err := h.verify(data)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmations, requiredConfirmations, err := h.confirmationWatcher.TokenConfirmations(
+		context.Background(),
+		h.chainID,
+		data.DepositTxHash,
+		sourceTokenAddress,
+		d.InputAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, typedData, err := h.typedData(d, data)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockHash, err := signature.UnlockHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return &signature.Preview{
+		Calldata:              calldata,
+		UnlockHash:            unlockHash,
+		TypedData:             typedData,
+		Confirmations:         confirmations,
+		RequiredConfirmations: requiredConfirmations,
+	}, nil
+}
+
+func (h *AcrossMessageHandler) digest(id string, data *AcrossData) ([]byte, error) {
+	h.statuses.Update(id, cache.VerifyingState)
+	d, sourceTokenAddress, err := h.verify(data)
 	if err != nil {
 		data.ErrChn <- err
 		return nil, err
 	}
 
+	err = h.confirmationWatcher.WaitForTokenConfirmations(
+		context.Background(),
+		id,
+		h.chainID,
+		data.DepositTxHash,
+		sourceTokenAddress,
+		d.InputAmount)
+	if err != nil {
+		data.ErrChn <- err
+		return nil, err
+	}
+	data.ErrChn <- nil
+
+	_, typedData, err := h.typedData(d, data)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockHash, err := signature.UnlockHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return unlockHash, nil
+}
+
+// verify fetches the across deposit and checks that the borrow amount is covered
+// by the deposit input amount
+func (h *AcrossMessageHandler) verify(data *AcrossData) (*events.AcrossDeposit, common.Address, error) {
+	_, ok := h.repayers[data.RepaymentChainID]
+	if !ok {
+		return nil, common.Address{}, errcode.New(errcode.ChainNotSupported, "invalid repayment chain %d", data.RepaymentChainID).
+			WithDetail("repaymentChainId", data.RepaymentChainID)
+	}
+
+	d, err := h.depositFetcher.Deposit(context.Background(), data.DepositTxHash, data.DepositId)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
 	sourceTokenAddress := common.BytesToAddress(d.InputToken[:])
 	symbol, srcToken, err := h.tokenStore.ConfigByAddress(h.chainID, sourceTokenAddress)
 	if err != nil {
-		err = fmt.Errorf(
+		return nil, common.Address{}, fmt.Errorf(
 			"failed to get source token for address %s on chain %d: %w",
 			sourceTokenAddress.Hex(),
 			h.chainID,
 			err,
 		)
-		data.ErrChn <- err
-		return nil, err
 	}
 
 	destToken, err := h.tokenStore.ConfigBySymbol(
@@ -146,43 +298,35 @@ func (h *AcrossMessageHandler) HandleMessage(m *message.Message) (*proposal.Prop
 		symbol,
 	)
 	if err != nil {
-		err = fmt.Errorf(
+		return nil, common.Address{}, fmt.Errorf(
 			"failed to get destination token by symbol %s on chain %d: %w",
 			symbol,
 			d.DestinationChainId.Uint64(),
 			err,
 		)
-		data.ErrChn <- err
-		return nil, err
 	}
 
 	scaledInputAmount := chains.ScaleTokenAmount(d.InputAmount, int64(srcToken.Decimals), int64(destToken.Decimals))
 	if data.BorrowAmount.Cmp(scaledInputAmount) > 0 {
-		err := fmt.Errorf("borrow amount exceeds input amount")
-		data.ErrChn <- err
-		return nil, err
+		return nil, common.Address{}, errcode.New(errcode.AmountExceeded, "borrow amount exceeds input amount").
+			WithDetail("borrowAmount", data.BorrowAmount.String()).
+			WithDetail("inputAmount", scaledInputAmount.String())
 	}
 
-	err = h.confirmationWatcher.WaitForTokenConfirmations(
-		context.Background(),
-		h.chainID,
-		data.DepositTxHash,
-		sourceTokenAddress,
-		d.InputAmount)
-	if err != nil {
-		data.ErrChn <- err
-		return nil, err
-	}
-	data.ErrChn <- nil
+	return d, sourceTokenAddress, nil
+}
 
+// typedData returns the calldata and the typed data of the borrow
+// that fills the across deposit
+func (h *AcrossMessageHandler) typedData(d *events.AcrossDeposit, data *AcrossData) ([]byte, apitypes.TypedData, error) {
 	calldata, err := d.ToV3RelayData(
-		new(big.Int).SetUint64(sourceChainID),
-	).Calldata(new(big.Int).SetUint64(data.RepaymentChainID), repaymentAddress)
+		new(big.Int).SetUint64(h.chainID),
+	).Calldata(new(big.Int).SetUint64(data.RepaymentChainID), h.repayers[data.RepaymentChainID])
 	if err != nil {
-		return nil, err
+		return nil, apitypes.TypedData{}, err
 	}
 
-	unlockHash, err := signature.BorrowUnlockHash(
+	return calldata, signature.BorrowTypedData(
 		calldata,
 		data.BorrowAmount,
 		common.BytesToAddress(d.OutputToken[:]),
@@ -192,28 +336,25 @@ func (h *AcrossMessageHandler) HandleMessage(m *message.Message) (*proposal.Prop
 		data.Caller,
 		data.LiquidityPool,
 		data.Nonce,
-	)
-	if err != nil {
-		return nil, err
-	}
+	), nil
+}
 
-	sessionID := fmt.Sprintf("%d-%s", sourceChainID, data.DepositId)
+func (h *AcrossMessageHandler) sign(id string, unlockHash []byte, data *AcrossData) error {
 	signing, err := signing.NewSigning(
 		new(big.Int).SetBytes(unlockHash),
-		sessionID,
-		sessionID,
+		id,
+		id,
 		h.host,
 		h.comm,
 		h.fetcher)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	signing.Audit(h.auditLog, audit.NewRequest(comm.AcrossMsg.String(), data, data.Coordinator))
+	signing.UsePresignatures(h.presignatures)
 
-	err = h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
-	if err != nil {
-		return nil, err
-	}
-	return nil, nil
+	h.statuses.Sign(id, unlockHash)
+	return h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
 }
 
 func (h *AcrossMessageHandler) Listen(ctx context.Context) {
@@ -2,6 +2,7 @@ package message_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains/evm/calls/events"
 	"github.com/sprintertech/sprinter-signing/chains/evm/message"
 	mock_message "github.com/sprintertech/sprinter-signing/chains/evm/message/mock"
@@ -41,8 +43,9 @@ type AcrossMessageHandlerTestSuite struct {
 	mockWatcher        *mock_message.MockConfirmationWatcher
 	mockDepositFetcher *mock_message.MockDepositFetcher
 
-	handler *message.AcrossMessageHandler
-	sigChn  chan interface{}
+	handler  *message.AcrossMessageHandler
+	sigChn   chan interface{}
+	statuses *cache.StatusCache
 
 	validLog []byte
 }
@@ -76,6 +79,7 @@ func (s *AcrossMessageHandlerTestSuite) SetupTest() {
 	repayers[10] = common.HexToAddress("0x5c7BCd6E7De5423a257D81B442095A1a6ced35C6")
 
 	s.sigChn = make(chan interface{}, 1)
+	s.statuses = cache.NewStatusCache()
 
 	// Ethereum: 0x93a9d5e32f5c81cbd17ceb842edc65002e3a79da4efbdc9f1e1f7e97fbcd669b
 	s.validLog, _ = hex.DecodeString("000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc200000000000000000000000082af49447d8a07e3bd95bd0d56f35241523fbab100000000000000000000000000000000000000000000000000119baee0ab0400000000000000000000000000000000000000000000000000001199073ea3008d0000000000000000000000000000000000000000000000000000000067bc6e3f0000000000000000000000000000000000000000000000000000000067bc927b00000000000000000000000000000000000000000000000000000000000000000000000000000000000000001886a1eb051c10f20c7386576a6a0716b20b2734000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001400000000000000000000000000000000000000000000000000000000000000000")
@@ -110,13 +114,25 @@ func (s *AcrossMessageHandlerTestSuite) SetupTest() {
 		s.mockHost,
 		s.mockCommunication,
 		s.mockFetcher,
+		nil,
+		nil,
 		s.mockDepositFetcher,
 		s.mockWatcher,
+		s.statuses,
 		s.sigChn,
 	)
 }
 
 func (s *AcrossMessageHandlerTestSuite) Test_HandleMessage_InvalidRepaymentAddress() {
+	s.mockCommunication.EXPECT().Broadcast(
+		gomock.Any(),
+		gomock.Any(),
+		comm.AcrossMsg,
+		fmt.Sprintf("%d-%s", 1, comm.AcrossSessionID),
+	).Return(nil)
+	p, _ := pstoremem.NewPeerstore()
+	s.mockHost.EXPECT().Peerstore().Return(p)
+
 	errChn := make(chan error, 1)
 	ad := &message.AcrossData{
 		ErrChn:           errChn,
@@ -139,6 +155,57 @@ func (s *AcrossMessageHandlerTestSuite) Test_HandleMessage_InvalidRepaymentAddre
 
 	err = <-errChn
 	s.NotNil(err)
+
+	status, err := s.statuses.Status("1-100")
+	s.Nil(err)
+	s.Equal(cache.FailedState, status.State)
+}
+
+func (s *AcrossMessageHandlerTestSuite) Test_HandleMessage_DuplicateRequest() {
+	errChn := make(chan error, 1)
+	ad := &message.AcrossData{
+		ErrChn:           errChn,
+		DepositId:        big.NewInt(100),
+		Nonce:            big.NewInt(101),
+		LiquidityPool:    common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		Caller:           common.HexToAddress("0xde526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		RepaymentChainID: 10,
+	}
+	err := s.statuses.Receive("1-100", ad.Caller.Hex(), ad.ParamsHash())
+	s.Nil(err)
+	s.statuses.Update("1-100", cache.SigningState)
+	m := &coreMessage.Message{
+		Data:        ad,
+		Source:      1,
+		Destination: 2,
+	}
+
+	prop, err := s.handler.HandleMessage(m)
+
+	s.Nil(prop)
+	s.ErrorIs(err, cache.ErrDuplicateRequest)
+	s.ErrorIs(<-errChn, cache.ErrDuplicateRequest)
+
+	status, err := s.statuses.Status("1-100")
+	s.Nil(err)
+	s.Equal(cache.SigningState, status.State)
+}
+
+func (s *AcrossMessageHandlerTestSuite) Test_Digest_MissingCoordinator() {
+	payload, err := json.Marshal(&message.AcrossData{
+		DepositId:        big.NewInt(100),
+		Nonce:            big.NewInt(101),
+		LiquidityPool:    common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		Caller:           common.HexToAddress("0xde526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		RepaymentChainID: 11,
+	})
+	s.Nil(err)
+
+	id, hash, err := s.handler.Digest(payload)
+
+	s.Equal("1-100", id)
+	s.Nil(hash)
+	s.Equal("invalid repayment chain 11", err.Error())
 }
 
 func (s *AcrossMessageHandlerTestSuite) Test_HandleMessage_FailedDepositQuery() {
@@ -262,7 +329,7 @@ func (s *AcrossMessageHandlerTestSuite) Test_HandleMessage_ValidDeposit() {
 	}
 	s.mockDepositFetcher.EXPECT().Deposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(deposit, nil)
 
-	s.mockWatcher.EXPECT().WaitForTokenConfirmations(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	s.mockWatcher.EXPECT().WaitForTokenConfirmations(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	s.mockCoordinator.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 	errChn := make(chan error, 1)
@@ -288,6 +355,51 @@ func (s *AcrossMessageHandlerTestSuite) Test_HandleMessage_ValidDeposit() {
 
 	err = <-errChn
 	s.Nil(err)
+
+	status, err := s.statuses.Status("1-2595221")
+	s.Nil(err)
+	s.Equal(cache.SigningState, status.State)
+}
+
+func (s *AcrossMessageHandlerTestSuite) Test_Preview_ValidDeposit() {
+	deposit := &events.AcrossDeposit{
+		InputToken:         fillBytes32("input_token_address_1234567890"),
+		OutputToken:        fillBytes32("output_token_address_0987654321"),
+		InputAmount:        big.NewInt(1000000000000000000),
+		OutputAmount:       big.NewInt(990000000000000000),
+		DestinationChainId: big.NewInt(137),
+		DepositId:          big.NewInt(123456789),
+		Depositor:          fillBytes32("depositor_address_abcdef123456"),
+		Recipient:          fillBytes32("recipient_address_654321fedcba"),
+		ExclusiveRelayer:   fillBytes32("relayer_address_112233445566"),
+		Message:            []byte("Sample message for AcrossDeposit"),
+	}
+	s.mockDepositFetcher.EXPECT().Deposit(gomock.Any(), gomock.Any(), gomock.Any()).Return(deposit, nil).Times(2)
+	s.mockWatcher.EXPECT().TokenConfirmations(gomock.Any(), uint64(1), gomock.Any(), gomock.Any(), deposit.InputAmount).Return(uint64(1), uint64(2), nil)
+	s.mockWatcher.EXPECT().WaitForTokenConfirmations(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	payload, err := json.Marshal(&message.AcrossData{
+		DepositId:        big.NewInt(2595221),
+		Nonce:            big.NewInt(101),
+		BorrowAmount:     big.NewInt(1000000000000000000),
+		LiquidityPool:    common.HexToAddress("0xbe526bA5d1ad94cC59D7A79d99A59F607d31A657"),
+		Caller:           common.HexToAddress("0x5ECF7351930e4A251193aA022Ef06249C6cBfa27"),
+		RepaymentChainID: 10,
+	})
+	s.Nil(err)
+
+	preview, err := s.handler.Preview(payload)
+
+	s.Nil(err)
+	s.Equal(uint64(1), preview.Confirmations)
+	s.Equal(uint64(2), preview.RequiredConfirmations)
+	s.Equal("Borrow", preview.TypedData.PrimaryType)
+	_, err = s.statuses.Status("1-2595221")
+	s.NotNil(err)
+
+	_, unlockHash, err := s.handler.Digest(payload)
+	s.Nil(err)
+	s.Equal(unlockHash, []byte(preview.UnlockHash))
 }
 
 func (s *AcrossMessageHandlerTestSuite) Test_HandleMessage_BorrowAmountExceedsScaledInputAmount() {
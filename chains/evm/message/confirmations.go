@@ -2,15 +2,17 @@ package message
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"maps"
 	"math/big"
 	"slices"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog/log"
 	"github.com/sprintertech/sprinter-signing/config"
+	"github.com/sprintertech/sprinter-signing/errcode"
 )
 
 type TokenPricer interface {
@@ -23,6 +25,7 @@ type Watcher struct {
 	confirmations map[uint64]uint64
 	blocktime     time.Duration
 	tokenPricer   TokenPricer
+	statuses      StatusTracker
 }
 
 func NewWatcher(
@@ -31,6 +34,7 @@ func NewWatcher(
 	tokenStore config.TokenStore,
 	confirmations map[uint64]uint64,
 	blocktime time.Duration,
+	statuses StatusTracker,
 ) *Watcher {
 	return &Watcher{
 		client:        client,
@@ -38,12 +42,15 @@ func NewWatcher(
 		confirmations: confirmations,
 		blocktime:     blocktime,
 		tokenPricer:   tokenPricer,
+		statuses:      statuses,
 	}
 }
 
 // WaitForTokenConfirmations blocks until the transaction hash has enough on-chain confirmations.
+// Confirmation progress is reported to the status tracker under the request ID.
 func (w *Watcher) WaitForTokenConfirmations(
 	ctx context.Context,
+	id string,
 	chainID uint64,
 	txHash common.Hash,
 	token common.Address,
@@ -62,12 +69,14 @@ func (w *Watcher) WaitForTokenConfirmations(
 		return err
 	}
 
-	return w.wait(ctx, txHash, requiredConfirmations)
+	return w.wait(ctx, id, txHash, requiredConfirmations)
 }
 
 // WaitForConfirmations blocks until the transaction hash has enough on-chain confirmations.
+// Confirmation progress is reported to the status tracker under the request ID.
 func (w *Watcher) WaitForOrderConfirmations(
 	ctx context.Context,
+	id string,
 	chainID uint64,
 	txHash common.Hash,
 	orderValue float64,
@@ -81,14 +90,84 @@ func (w *Watcher) WaitForOrderConfirmations(
 		return err
 	}
 
-	return w.wait(ctx, txHash, requiredConfirmations)
+	return w.wait(ctx, id, txHash, requiredConfirmations)
 }
 
-func (w *Watcher) wait(ctx context.Context, txHash common.Hash, requiredConfirmations uint64) error {
+// TokenConfirmations returns the current on-chain confirmations of the transaction hash
+// and the confirmations required for the token amount without waiting for them.
+func (w *Watcher) TokenConfirmations(
+	ctx context.Context,
+	chainID uint64,
+	txHash common.Hash,
+	token common.Address,
+	amount *big.Int,
+) (uint64, uint64, error) {
+	orderValue, err := w.orderValue(chainID, token, amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	requiredConfirmations, err := w.minimalConfirmations(orderValue)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	confirmations, err := w.confirmationCount(ctx, txHash)
+	return confirmations, requiredConfirmations, err
+}
+
+// OrderConfirmations returns the current on-chain confirmations of the transaction hash
+// and the confirmations required for the order value without waiting for them.
+func (w *Watcher) OrderConfirmations(
+	ctx context.Context,
+	chainID uint64,
+	txHash common.Hash,
+	orderValue float64,
+) (uint64, uint64, error) {
+	orderValueInt, _ := big.NewFloat(orderValue).Int(nil)
+	requiredConfirmations, err := w.minimalConfirmations(orderValueInt)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	confirmations, err := w.confirmationCount(ctx, txHash)
+	return confirmations, requiredConfirmations, err
+}
+
+// confirmationCount returns the number of blocks since the transaction was included
+// or zero if the transaction is still pending
+func (w *Watcher) confirmationCount(ctx context.Context, txHash common.Hash) (uint64, error) {
+	txReceipt, err := w.client.TransactionReceipt(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) || (err == nil && txReceipt == nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	currentBlock, err := w.client.LatestBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	confirmations := new(big.Int).Sub(currentBlock, txReceipt.BlockNumber)
+	if confirmations.Sign() < 0 {
+		return 0, nil
+	}
+	return confirmations.Uint64(), nil
+}
+
+func (w *Watcher) wait(ctx context.Context, id string, txHash common.Hash, requiredConfirmations uint64) error {
+	w.statuses.UpdateConfirmations(id, 0, requiredConfirmations)
+
+	confirmations := big.NewInt(0)
 	for {
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("timed out waiting for confirmations %s", txHash.Hex())
+			return errcode.New(errcode.InsufficientConfirmations, "timed out waiting for confirmations %s", txHash.Hex()).
+				WithDetail("depositTxHash", txHash.Hex()).
+				WithDetail("confirmations", confirmations.Uint64()).
+				WithDetail("requiredConfirmations", requiredConfirmations)
 		default:
 			txReceipt, err := w.client.TransactionReceipt(ctx, txHash)
 			if err != nil {
@@ -109,7 +188,11 @@ func (w *Watcher) wait(ctx context.Context, txHash common.Hash, requiredConfirma
 				continue
 			}
 
-			confirmations := new(big.Int).Sub(currentBlock, txReceipt.BlockNumber)
+			confirmations = new(big.Int).Sub(currentBlock, txReceipt.BlockNumber)
+			if confirmations.Sign() < 0 {
+				confirmations = big.NewInt(0)
+			}
+			w.statuses.UpdateConfirmations(id, confirmations.Uint64(), requiredConfirmations)
 			if confirmations.Cmp(new(big.Int).SetUint64(requiredConfirmations)) != -1 {
 				return nil
 			}
@@ -133,7 +216,8 @@ func (w *Watcher) minimalConfirmations(orderValue *big.Int) (uint64, error) {
 		}
 	}
 
-	return 0, fmt.Errorf("order value %f exceeds confirmation buckets", orderValue)
+	return 0, errcode.New(errcode.AmountExceeded, "order value %s exceeds confirmation buckets", orderValue).
+		WithDetail("orderValue", orderValue.String())
 }
 
 func (w *Watcher) orderValue(chainID uint64, token common.Address, amount *big.Int) (*big.Int, error) {
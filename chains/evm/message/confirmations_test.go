@@ -7,8 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains/evm/message"
 	mock_message "github.com/sprintertech/sprinter-signing/chains/evm/message/mock"
 	"github.com/sprintertech/sprinter-signing/config"
@@ -57,11 +59,12 @@ func (s *WatcherTestSuite) SetupTest() {
 		tokenStore,
 		confirmations,
 		time.Millisecond,
+		cache.NewStatusCache(),
 	)
 }
 
 func (s *WatcherTestSuite) Test_WaitForTokenConfirmations_InvalidToken() {
-	err := s.watcher.WaitForTokenConfirmations(context.Background(), 1, common.Hash{}, common.Address{}, big.NewInt(1000))
+	err := s.watcher.WaitForTokenConfirmations(context.Background(), "1-1", 1, common.Hash{}, common.Address{}, big.NewInt(1000))
 
 	s.NotNil(err)
 }
@@ -69,7 +72,7 @@ func (s *WatcherTestSuite) Test_WaitForTokenConfirmations_InvalidToken() {
 func (s *WatcherTestSuite) Test_WaitForTokenConfirmations_InvalidOrderValue() {
 	s.mockPricer.EXPECT().TokenPrice("USDC").Return(float64(0.99), nil)
 
-	err := s.watcher.WaitForTokenConfirmations(context.Background(), 1, common.Hash{}, s.usdcToken, big.NewInt(1000000000))
+	err := s.watcher.WaitForTokenConfirmations(context.Background(), "1-1", 1, common.Hash{}, s.usdcToken, big.NewInt(1000000000))
 
 	s.NotNil(err)
 }
@@ -80,7 +83,7 @@ func (s *WatcherTestSuite) Test_WaitForTokenConfirmations_TxTimeout() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
 	defer cancel()
 
-	err := s.watcher.WaitForTokenConfirmations(ctx, 1, common.Hash{}, s.usdcToken, big.NewInt(499000000))
+	err := s.watcher.WaitForTokenConfirmations(ctx, "1-1", 1, common.Hash{}, s.usdcToken, big.NewInt(499000000))
 
 	s.NotNil(err)
 }
@@ -96,7 +99,7 @@ func (s *WatcherTestSuite) Test_WaitForTokenConfirmations_ValidTransaction() {
 	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
 	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(102), nil)
 
-	err := s.watcher.WaitForTokenConfirmations(context.Background(), 1, common.Hash{}, s.usdcToken, big.NewInt(499000000))
+	err := s.watcher.WaitForTokenConfirmations(context.Background(), "1-1", 1, common.Hash{}, s.usdcToken, big.NewInt(499000000))
 
 	s.Nil(err)
 }
@@ -111,7 +114,31 @@ func (s *WatcherTestSuite) Test_WaitForOrderConfirmations_ValidTransaction() {
 	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
 	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(102), nil)
 
-	err := s.watcher.WaitForOrderConfirmations(context.Background(), 1, common.Hash{}, 499.95)
+	err := s.watcher.WaitForOrderConfirmations(context.Background(), "1-1", 1, common.Hash{}, 499.95)
 
 	s.Nil(err)
 }
+
+func (s *WatcherTestSuite) Test_TokenConfirmations_PendingTransaction() {
+	s.mockPricer.EXPECT().TokenPrice("USDC").Return(float64(0.99), nil)
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), gomock.Any()).Return(nil, ethereum.NotFound)
+
+	confirmations, requiredConfirmations, err := s.watcher.TokenConfirmations(context.Background(), 1, common.Hash{}, s.usdcToken, big.NewInt(499000000))
+
+	s.Nil(err)
+	s.Equal(uint64(0), confirmations)
+	s.Equal(uint64(2), requiredConfirmations)
+}
+
+func (s *WatcherTestSuite) Test_OrderConfirmations_IncludedTransaction() {
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), gomock.Any()).Return(&types.Receipt{
+		BlockNumber: big.NewInt(100),
+	}, nil)
+	s.mockClient.EXPECT().LatestBlock().Return(big.NewInt(101), nil)
+
+	confirmations, requiredConfirmations, err := s.watcher.OrderConfirmations(context.Background(), 1, common.Hash{}, 499.95)
+
+	s.Nil(err)
+	s.Equal(uint64(1), confirmations)
+	s.Equal(uint64(2), requiredConfirmations)
+}
@@ -9,14 +9,18 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/audit"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains"
 	"github.com/sprintertech/sprinter-signing/chains/evm/calls/consts"
 	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sprintertech/sprinter-signing/config"
+	"github.com/sprintertech/sprinter-signing/errcode"
 	"github.com/sprintertech/sprinter-signing/tss"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -48,11 +52,14 @@ type LifiEscrowMessageHandler struct {
 
 	orderFetcher OrderFetcher
 
-	coordinator Coordinator
-	host        host.Host
-	comm        comm.Communication
-	fetcher     signing.SaveDataFetcher
-	sigChn      chan any
+	coordinator   Coordinator
+	host          host.Host
+	comm          comm.Communication
+	fetcher       signing.SaveDataFetcher
+	auditLog      signing.AuditLogger
+	presignatures *signing.PresignaturePool
+	statuses      StatusTracker
+	sigChn        chan any
 }
 
 func NewLifiEscrowMessageHandler(
@@ -63,12 +70,15 @@ func NewLifiEscrowMessageHandler(
 	host host.Host,
 	comm comm.Communication,
 	fetcher signing.SaveDataFetcher,
+	auditLog signing.AuditLogger,
+	presignatures *signing.PresignaturePool,
 	confirmationWatcher ConfirmationWatcher,
 	tokenStore config.TokenStore,
 	orderFetcher OrderFetcher,
 	orderPricer pricing.OrderPricer,
 	router router.OrderRouter,
 	validator OrderValidator,
+	statuses StatusTracker,
 	sigChn chan any,
 ) *LifiEscrowMessageHandler {
 	return &LifiEscrowMessageHandler{
@@ -79,6 +89,8 @@ func NewLifiEscrowMessageHandler(
 		mpcAddress:          mpcAddress,
 		comm:                comm,
 		fetcher:             fetcher,
+		auditLog:            auditLog,
+		presignatures:       presignatures,
 		confirmationWatcher: confirmationWatcher,
 		tokenStore:          tokenStore,
 		orderFetcher:        orderFetcher,
@@ -86,6 +98,7 @@ func NewLifiEscrowMessageHandler(
 		validator:           validator,
 		sigChn:              sigChn,
 		router:              router,
+		statuses:            statuses,
 	}
 }
 
@@ -93,35 +106,104 @@ func NewLifiEscrowMessageHandler(
 // the order if it is valid
 func (h *LifiEscrowMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
 	data := m.Data.(*LifiEscrowData)
-	err := h.notify(data)
+	id := fmt.Sprintf("%d-%s", h.chainID, data.OrderID)
+
+	log.Info().Str("depositId", data.OrderID).Msgf("Handling lifi escrow message %+v", data)
+
+	err := h.statuses.Receive(id, data.Caller.Hex(), data.ParamsHash())
+	if err != nil {
+		data.ErrChn <- err
+		return nil, err
+	}
+
+	err = h.notify(data)
 	if err != nil {
 		log.Warn().Msgf("Failed to notify relayers because of %s", err)
 	}
 
-	log.Info().Str("depositId", data.OrderID).Msgf("Handling lifi escrow message %+v", data)
+	unlockHash, err := h.digest(id, data)
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return nil, err
+	}
 
-	order, err := h.orderFetcher.Order(
+	err = errcode.Wrap(errcode.SigningFailed, h.sign(id, unlockHash, data))
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// Digest verifies the JSON encoded message data and returns the request ID
+// and the unlock hash that should be signed for it.
+func (h *LifiEscrowMessageHandler) Digest(payload []byte) (string, []byte, error) {
+	data := &LifiEscrowData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return "", nil, err
+	}
+	data.ErrChn = make(chan error, 1)
+	id := fmt.Sprintf("%d-%s", h.chainID, data.OrderID)
+
+	err = h.statuses.Receive(id, data.Caller.Hex(), data.ParamsHash())
+	if err != nil {
+		return id, nil, err
+	}
+
+	unlockHash, err := h.digest(id, data)
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return id, nil, err
+	}
+	return id, unlockHash, nil
+}
+
+// Preview verifies the JSON encoded message data without waiting for confirmations
+// and returns the unlock hash and the typed data that would be signed for it.
+func (h *LifiEscrowMessageHandler) Preview(payload []byte) (*signature.Preview, error) {
+	data := &LifiEscrowData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return nil, err
+	}
+
+	order, orderValue, err := h.verify(data)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmations, requiredConfirmations, err := h.confirmationWatcher.OrderConfirmations(
 		context.Background(),
-		common.HexToHash(data.DepositTxHash),
-		common.HexToHash(data.OrderID))
+		h.chainID,
+		*order.Meta.OrderInitiatedTxHash,
+		orderValue,
+	)
 	if err != nil {
-		data.ErrChn <- err
 		return nil, err
 	}
 
-	err = h.verifyOrder(order, data.BorrowAmount)
+	calldata, typedData, err := h.typedData(order, data)
 	if err != nil {
-		data.ErrChn <- err
 		return nil, err
 	}
 
-	orderValue, err := order.TotalInputsUSDValue(h.orderPricer)
+	unlockHash, err := signature.UnlockHash(typedData)
 	if err != nil {
-		data.ErrChn <- err
 		return nil, err
 	}
+	return &signature.Preview{
+		Calldata:              calldata,
+		UnlockHash:            unlockHash,
+		TypedData:             typedData,
+		Confirmations:         confirmations,
+		RequiredConfirmations: requiredConfirmations,
+	}, nil
+}
 
-	borrowToken, destChainID, err := h.borrowToken(order)
+func (h *LifiEscrowMessageHandler) digest(id string, data *LifiEscrowData) ([]byte, error) {
+	h.statuses.Update(id, cache.VerifyingState)
+	order, orderValue, err := h.verify(data)
 	if err != nil {
 		data.ErrChn <- err
 		return nil, err
@@ -129,6 +211,7 @@ func (h *LifiEscrowMessageHandler) HandleMessage(m *message.Message) (*proposal.
 
 	err = h.confirmationWatcher.WaitForOrderConfirmations(
 		context.Background(),
+		id,
 		h.chainID,
 		*order.Meta.OrderInitiatedTxHash,
 		orderValue,
@@ -139,10 +222,54 @@ func (h *LifiEscrowMessageHandler) HandleMessage(m *message.Message) (*proposal.
 	}
 	data.ErrChn <- nil
 
-	calldata, err := h.calldata(order)
+	_, typedData, err := h.typedData(order, data)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockHash, err := signature.UnlockHash(typedData)
 	if err != nil {
 		return nil, err
 	}
+	return unlockHash, nil
+}
+
+// verify fetches the lifi order and validates it, returning the order
+// with its total input value in USD
+func (h *LifiEscrowMessageHandler) verify(data *LifiEscrowData) (*lifi.LifiOrder, float64, error) {
+	order, err := h.orderFetcher.Order(
+		context.Background(),
+		common.HexToHash(data.DepositTxHash),
+		common.HexToHash(data.OrderID))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = h.verifyOrder(order, data.BorrowAmount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orderValue, err := order.TotalInputsUSDValue(h.orderPricer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return order, orderValue, nil
+}
+
+// typedData returns the calldata and the typed data of the borrow
+// that fills the lifi order outputs
+func (h *LifiEscrowMessageHandler) typedData(order *lifi.LifiOrder, data *LifiEscrowData) ([]byte, apitypes.TypedData, error) {
+	borrowToken, destChainID, err := h.borrowToken(order)
+	if err != nil {
+		return nil, apitypes.TypedData{}, err
+	}
+
+	calldata, err := h.calldata(order)
+	if err != nil {
+		return nil, apitypes.TypedData{}, err
+	}
 
 	log.Debug().Msgf(`
 		Singing lifi unlock hash.
@@ -161,7 +288,7 @@ func (h *LifiEscrowMessageHandler) HandleMessage(m *message.Message) (*proposal.
 		data.Deadline,
 	)
 
-	unlockHash, err := signature.BorrowUnlockHash(
+	return calldata, signature.BorrowTypedData(
 		calldata,
 		data.BorrowAmount,
 		borrowToken,
@@ -171,28 +298,25 @@ func (h *LifiEscrowMessageHandler) HandleMessage(m *message.Message) (*proposal.
 		data.Caller,
 		data.LiquidityPool,
 		data.Nonce,
-	)
-	if err != nil {
-		return nil, err
-	}
+	), nil
+}
 
-	sessionID := fmt.Sprintf("%d-%s", h.chainID, data.OrderID)
+func (h *LifiEscrowMessageHandler) sign(id string, unlockHash []byte, data *LifiEscrowData) error {
 	signing, err := signing.NewSigning(
 		new(big.Int).SetBytes(unlockHash),
-		sessionID,
-		sessionID,
+		id,
+		id,
 		h.host,
 		h.comm,
 		h.fetcher)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	signing.Audit(h.auditLog, audit.NewRequest(comm.LifiEscrowMsg.String(), data, data.Coordinator))
+	signing.UsePresignatures(h.presignatures)
 
-	err = h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
-	if err != nil {
-		return nil, err
-	}
-	return nil, nil
+	h.statuses.Sign(id, unlockHash)
+	return h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
 }
 
 func (h *LifiEscrowMessageHandler) borrowToken(order *lifi.LifiOrder) (common.Address, uint64, error) {
@@ -256,11 +380,13 @@ func (h *LifiEscrowMessageHandler) calldata(order *lifi.LifiOrder) ([]byte, erro
 // verifyOrder verifies order based on these instructions https://docs.catalyst.exchange/solver/orderflow/#order-validation
 func (h *LifiEscrowMessageHandler) verifyOrder(order *lifi.LifiOrder, borrowAmount *big.Int) error {
 	if len(order.Order.Inputs) > 1 || len(order.Order.Inputs) == 0 {
-		return fmt.Errorf("orders with multiple inputs not supported")
+		return errcode.New(errcode.PolicyViolation, "orders with multiple inputs not supported").
+			WithDetail("inputs", len(order.Order.Inputs))
 	}
 
 	if len(order.Order.Outputs) > 1 {
-		return fmt.Errorf("orders with multiple outputs not supported")
+		return errcode.New(errcode.PolicyViolation, "orders with multiple outputs not supported").
+			WithDetail("outputs", len(order.Order.Outputs))
 	}
 
 	tokenIn := common.BytesToAddress(order.GenericInputs[0].TokenAddress[:])
@@ -274,14 +400,16 @@ func (h *LifiEscrowMessageHandler) verifyOrder(order *lifi.LifiOrder, borrowAmou
 	}
 	scaledInputAmount := chains.ScaleTokenAmount(order.GenericInputs[0].Amount, int64(srcToken.Decimals), int64(dstToken.Decimals))
 	if scaledInputAmount.Cmp(borrowAmount) == -1 {
-		return fmt.Errorf("order input is less than requested borrow amount")
+		return errcode.New(errcode.AmountExceeded, "order input is less than requested borrow amount").
+			WithDetail("borrowAmount", borrowAmount.String()).
+			WithDetail("inputAmount", scaledInputAmount.String())
 	}
 
 	augmentedOrder, err := order.AugmentedOrder(h.orderPricer, h.router)
 	if err != nil {
 		return err
 	}
-	return h.validator.Validate(augmentedOrder)
+	return errcode.Wrap(errcode.PolicyViolation, h.validator.Validate(augmentedOrder))
 }
 
 func (h *LifiEscrowMessageHandler) Listen(ctx context.Context) {
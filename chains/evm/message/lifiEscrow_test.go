@@ -11,7 +11,8 @@ type LifiEscrowMessageHandlerTestSuite struct {
 	mockOrder         *lifiTypes.LifiOrder
 	mockWatcher       *mock_message.MockConfirmationWatcher
 
-	sigChn chan interface{}
+	sigChn   chan interface{}
+	statuses *cache.StatusCache
 
 	mockOrderFetcher   *mock_message.MockOrderFetcher
 	mockOrderPricer    *mock_message.MockOrderPricer
@@ -75,6 +76,7 @@ func (s *LifiEscrowMessageHandlerTestSuite) SetupTest() {
 	s.mockHost.EXPECT().Peerstore().Return(p)
 
 	s.sigChn = make(chan interface{}, 1)
+	s.statuses = cache.NewStatusCache()
 
 	s.handler = message.NewLifiEscrowMessageHandler(
 		8453,
@@ -84,12 +86,15 @@ func (s *LifiEscrowMessageHandlerTestSuite) SetupTest() {
 		s.mockHost,
 		s.mockCommunication,
 		s.mockFetcher,
+		nil,
+		nil,
 		s.mockWatcher,
 		tokenStore,
 		s.mockOrderFetcher,
 		s.mockOrderPricer,
 		nil,
 		s.mockOrderValidator,
+		s.statuses,
 		s.sigChn,
 	)
 }
@@ -187,7 +192,7 @@ func (s *LifiEscrowMessageHandlerTestSuite) Test_HandleMessage_ValidOrder() {
 	s.mockOrderFetcher.EXPECT().GetOrder("orderID").Return(s.mockOrder, nil)
 	s.mockOrderValidator.EXPECT().Validate(s.mockOrder).Return(nil)
 	s.mockOrderPricer.EXPECT().PriceInputs(gomock.Any()).Return(float64(1000), nil)
-	s.mockWatcher.EXPECT().WaitForOrderConfirmations(gomock.Any(), uint64(8453), gomock.Any(), float64(1000)).Return(nil)
+	s.mockWatcher.EXPECT().WaitForOrderConfirmations(gomock.Any(), "8453-"+ad.OrderID, uint64(8453), gomock.Any(), float64(1000)).Return(nil)
 	s.mockCoordinator.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 	m := &coreMessage.Message{
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/chains"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 )
@@ -25,12 +26,28 @@ type AcrossData struct {
 	LiquidityPool    common.Address
 	RepaymentChainID uint64
 	Caller           common.Address
-	Coordinator      peer.ID
+	Coordinator      peer.ID `json:",omitempty"`
 	Deadline         uint64
+	ClientID         string
 	Source           uint64
 	Destination      uint64
 }
 
+// ParamsHash identifies the signing parameters of the across request
+func (d *AcrossData) ParamsHash() string {
+	return chains.ParamsHash(
+		comm.AcrossMsg.String(),
+		d.DepositId,
+		d.DepositTxHash,
+		d.BorrowAmount,
+		d.RepaymentChainID,
+		d.LiquidityPool,
+		d.Caller,
+		d.Nonce,
+		d.Deadline,
+	)
+}
+
 func NewAcrossMessage(source, destination uint64, acrossData *AcrossData) *message.Message {
 	return &message.Message{
 		Source:      source,
@@ -45,17 +62,32 @@ type LifiEscrowData struct {
 	ErrChn chan error `json:"-"`
 
 	OrderID       string
-	Coordinator   peer.ID
+	Coordinator   peer.ID `json:",omitempty"`
 	LiquidityPool common.Address
 	Caller        common.Address
 	DepositTxHash string
 	BorrowAmount  *big.Int
 	Deadline      uint64
 	Nonce         *big.Int
+	ClientID      string
 	Source        uint64
 	Destination   uint64
 }
 
+// ParamsHash identifies the signing parameters of the lifi escrow request
+func (d *LifiEscrowData) ParamsHash() string {
+	return chains.ParamsHash(
+		comm.LifiEscrowMsg.String(),
+		d.OrderID,
+		common.HexToHash(d.DepositTxHash),
+		d.BorrowAmount,
+		d.LiquidityPool,
+		d.Caller,
+		d.Nonce,
+		d.Deadline,
+	)
+}
+
 func NewLifiEscrowMessage(source, destination uint64, lifiData *LifiEscrowData) *message.Message {
 	return &message.Message{
 		Source:      source,
@@ -76,12 +108,28 @@ type SprinterCreditData struct {
 	LiquidityPool common.Address
 	Caller        common.Address
 	Nonce         *big.Int
-	Coordinator   peer.ID
+	Coordinator   peer.ID `json:",omitempty"`
+	ClientID      string
 	Source        uint64
 	Destination   uint64
 	TokenOut      string
 }
 
+// ParamsHash identifies the signing parameters of the sprinter credit request
+func (d *SprinterCreditData) ParamsHash() string {
+	return chains.ParamsHash(
+		comm.SprinterCreditMsg.String(),
+		d.DepositID,
+		d.Calldata,
+		d.TokenOut,
+		d.BorrowAmount,
+		d.LiquidityPool,
+		d.Caller,
+		d.Nonce,
+		d.Deadline,
+	)
+}
+
 func NewSprinterCreditMessage(
 	source,
 	destination uint64,
@@ -102,6 +150,7 @@ type LifiUnlockData struct {
 	Settler common.Address
 
 	Coordinator peer.ID
+	ClientID    string
 	Source      uint64
 	Destination uint64
 }
@@ -18,6 +18,7 @@ import (
 	common "github.com/ethereum/go-ethereum/common"
 	types "github.com/ethereum/go-ethereum/core/types"
 	peer "github.com/libp2p/go-libp2p/core/peer"
+	cache "github.com/sprintertech/sprinter-signing/cache"
 	events "github.com/sprintertech/sprinter-signing/chains/evm/calls/events"
 	tss "github.com/sprintertech/sprinter-signing/tss"
 	gomock "go.uber.org/mock/gomock"
@@ -130,6 +131,92 @@ func (mr *MockCoordinatorMockRecorder) Execute(ctx, tssProcesses, resultChn, coo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockCoordinator)(nil).Execute), ctx, tssProcesses, resultChn, coordinator)
 }
 
+// MockStatusTracker is a mock of StatusTracker interface.
+type MockStatusTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusTrackerMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusTrackerMockRecorder is the mock recorder for MockStatusTracker.
+type MockStatusTrackerMockRecorder struct {
+	mock *MockStatusTracker
+}
+
+// NewMockStatusTracker creates a new mock instance.
+func NewMockStatusTracker(ctrl *gomock.Controller) *MockStatusTracker {
+	mock := &MockStatusTracker{ctrl: ctrl}
+	mock.recorder = &MockStatusTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusTracker) EXPECT() *MockStatusTrackerMockRecorder {
+	return m.recorder
+}
+
+// Fail mocks base method.
+func (m *MockStatusTracker) Fail(id string, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fail", id, err)
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockStatusTrackerMockRecorder) Fail(id, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockStatusTracker)(nil).Fail), id, err)
+}
+
+// Receive mocks base method.
+func (m *MockStatusTracker) Receive(id, caller, paramsHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", id, caller, paramsHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockStatusTrackerMockRecorder) Receive(id, caller, paramsHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockStatusTracker)(nil).Receive), id, caller, paramsHash)
+}
+
+// Sign mocks base method.
+func (m *MockStatusTracker) Sign(id string, digest []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Sign", id, digest)
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockStatusTrackerMockRecorder) Sign(id, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockStatusTracker)(nil).Sign), id, digest)
+}
+
+// Update mocks base method.
+func (m *MockStatusTracker) Update(id string, state cache.RequestState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update", id, state)
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusTrackerMockRecorder) Update(id, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatusTracker)(nil).Update), id, state)
+}
+
+// UpdateConfirmations mocks base method.
+func (m *MockStatusTracker) UpdateConfirmations(id string, confirmations, requiredConfirmations uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateConfirmations", id, confirmations, requiredConfirmations)
+}
+
+// UpdateConfirmations indicates an expected call of UpdateConfirmations.
+func (mr *MockStatusTrackerMockRecorder) UpdateConfirmations(id, confirmations, requiredConfirmations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfirmations", reflect.TypeOf((*MockStatusTracker)(nil).UpdateConfirmations), id, confirmations, requiredConfirmations)
+}
+
 // MockConfirmationWatcher is a mock of ConfirmationWatcher interface.
 type MockConfirmationWatcher struct {
 	ctrl     *gomock.Controller
@@ -154,32 +241,64 @@ func (m *MockConfirmationWatcher) EXPECT() *MockConfirmationWatcherMockRecorder
 	return m.recorder
 }
 
+// OrderConfirmations mocks base method.
+func (m *MockConfirmationWatcher) OrderConfirmations(ctx context.Context, chainID uint64, txHash common.Hash, orderValue float64) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderConfirmations", ctx, chainID, txHash, orderValue)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OrderConfirmations indicates an expected call of OrderConfirmations.
+func (mr *MockConfirmationWatcherMockRecorder) OrderConfirmations(ctx, chainID, txHash, orderValue any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderConfirmations", reflect.TypeOf((*MockConfirmationWatcher)(nil).OrderConfirmations), ctx, chainID, txHash, orderValue)
+}
+
+// TokenConfirmations mocks base method.
+func (m *MockConfirmationWatcher) TokenConfirmations(ctx context.Context, chainID uint64, txHash common.Hash, token common.Address, amount *big.Int) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenConfirmations", ctx, chainID, txHash, token, amount)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TokenConfirmations indicates an expected call of TokenConfirmations.
+func (mr *MockConfirmationWatcherMockRecorder) TokenConfirmations(ctx, chainID, txHash, token, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenConfirmations", reflect.TypeOf((*MockConfirmationWatcher)(nil).TokenConfirmations), ctx, chainID, txHash, token, amount)
+}
+
 // WaitForOrderConfirmations mocks base method.
-func (m *MockConfirmationWatcher) WaitForOrderConfirmations(ctx context.Context, chainID uint64, txHash common.Hash, orderValue float64) error {
+func (m *MockConfirmationWatcher) WaitForOrderConfirmations(ctx context.Context, id string, chainID uint64, txHash common.Hash, orderValue float64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WaitForOrderConfirmations", ctx, chainID, txHash, orderValue)
+	ret := m.ctrl.Call(m, "WaitForOrderConfirmations", ctx, id, chainID, txHash, orderValue)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WaitForOrderConfirmations indicates an expected call of WaitForOrderConfirmations.
-func (mr *MockConfirmationWatcherMockRecorder) WaitForOrderConfirmations(ctx, chainID, txHash, orderValue any) *gomock.Call {
+func (mr *MockConfirmationWatcherMockRecorder) WaitForOrderConfirmations(ctx, id, chainID, txHash, orderValue any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForOrderConfirmations", reflect.TypeOf((*MockConfirmationWatcher)(nil).WaitForOrderConfirmations), ctx, chainID, txHash, orderValue)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForOrderConfirmations", reflect.TypeOf((*MockConfirmationWatcher)(nil).WaitForOrderConfirmations), ctx, id, chainID, txHash, orderValue)
 }
 
 // WaitForTokenConfirmations mocks base method.
-func (m *MockConfirmationWatcher) WaitForTokenConfirmations(ctx context.Context, chainID uint64, txHash common.Hash, token common.Address, amount *big.Int) error {
+func (m *MockConfirmationWatcher) WaitForTokenConfirmations(ctx context.Context, id string, chainID uint64, txHash common.Hash, token common.Address, amount *big.Int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WaitForTokenConfirmations", ctx, chainID, txHash, token, amount)
+	ret := m.ctrl.Call(m, "WaitForTokenConfirmations", ctx, id, chainID, txHash, token, amount)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WaitForTokenConfirmations indicates an expected call of WaitForTokenConfirmations.
-func (mr *MockConfirmationWatcherMockRecorder) WaitForTokenConfirmations(ctx, chainID, txHash, token, amount any) *gomock.Call {
+func (mr *MockConfirmationWatcherMockRecorder) WaitForTokenConfirmations(ctx, id, chainID, txHash, token, amount any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForTokenConfirmations", reflect.TypeOf((*MockConfirmationWatcher)(nil).WaitForTokenConfirmations), ctx, chainID, txHash, token, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForTokenConfirmations", reflect.TypeOf((*MockConfirmationWatcher)(nil).WaitForTokenConfirmations), ctx, id, chainID, txHash, token, amount)
 }
 
 // MockDepositFetcher is a mock of DepositFetcher interface.
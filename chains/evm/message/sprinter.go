@@ -8,11 +8,15 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/audit"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
 	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/errcode"
 	"github.com/sprintertech/sprinter-signing/tss"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
@@ -24,11 +28,14 @@ type SprinterCreditMessageHandler struct {
 
 	liquidators map[common.Address]common.Address
 
-	coordinator Coordinator
-	host        host.Host
-	comm        comm.Communication
-	fetcher     signing.SaveDataFetcher
-	sigChn      chan any
+	coordinator   Coordinator
+	host          host.Host
+	comm          comm.Communication
+	fetcher       signing.SaveDataFetcher
+	auditLog      signing.AuditLogger
+	presignatures *signing.PresignaturePool
+	statuses      StatusTracker
+	sigChn        chan any
 }
 
 func NewSprinterCreditMessageHandler(
@@ -38,16 +45,22 @@ func NewSprinterCreditMessageHandler(
 	host host.Host,
 	comm comm.Communication,
 	fetcher signing.SaveDataFetcher,
+	auditLog signing.AuditLogger,
+	presignatures *signing.PresignaturePool,
+	statuses StatusTracker,
 	sigChn chan any,
 ) *SprinterCreditMessageHandler {
 	return &SprinterCreditMessageHandler{
-		chainID:     chainID,
-		coordinator: coordinator,
-		liquidators: liquidators,
-		host:        host,
-		comm:        comm,
-		fetcher:     fetcher,
-		sigChn:      sigChn,
+		chainID:       chainID,
+		coordinator:   coordinator,
+		liquidators:   liquidators,
+		host:          host,
+		comm:          comm,
+		fetcher:       fetcher,
+		auditLog:      auditLog,
+		presignatures: presignatures,
+		statuses:      statuses,
+		sigChn:        sigChn,
 	}
 }
 
@@ -55,29 +68,117 @@ func NewSprinterCreditMessageHandler(
 // is going to the Liquidator contract.
 func (h *SprinterCreditMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
 	data := m.Data.(*SprinterCreditData)
+	id := fmt.Sprintf("%d-%s", h.chainID, data.DepositID)
 
 	log.Info().Msgf("Handling sprinter remote collateral message %+v", data)
 
-	err := h.notify(data)
+	err := h.statuses.Receive(id, data.Caller.Hex(), data.ParamsHash())
+	if err != nil {
+		data.ErrChn <- err
+		return nil, err
+	}
+
+	err = h.notify(data)
 	if err != nil {
 		log.Warn().Msgf("Failed to notify relayers because of %s", err)
 	}
 
-	calldata, err := hex.DecodeString(data.Calldata)
+	unlockHash, err := h.digest(id, data)
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return nil, err
+	}
+
+	err = errcode.Wrap(errcode.SigningFailed, h.sign(id, unlockHash, data))
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// Digest verifies the JSON encoded message data and returns the request ID
+// and the unlock hash that should be signed for it.
+func (h *SprinterCreditMessageHandler) Digest(payload []byte) (string, []byte, error) {
+	data := &SprinterCreditData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return "", nil, err
+	}
+	data.ErrChn = make(chan error, 1)
+	id := fmt.Sprintf("%d-%s", h.chainID, data.DepositID)
+
+	err = h.statuses.Receive(id, data.Caller.Hex(), data.ParamsHash())
+	if err != nil {
+		return id, nil, err
+	}
+
+	unlockHash, err := h.digest(id, data)
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return id, nil, err
+	}
+	return id, unlockHash, nil
+}
+
+// Preview verifies the JSON encoded message data and returns the unlock hash
+// and the typed data that would be signed for it.
+func (h *SprinterCreditMessageHandler) Preview(payload []byte) (*signature.Preview, error) {
+	data := &SprinterCreditData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, typedData, err := h.typedData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockHash, err := signature.UnlockHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return &signature.Preview{
+		Calldata:   calldata,
+		UnlockHash: unlockHash,
+		TypedData:  typedData,
+	}, nil
+}
+
+func (h *SprinterCreditMessageHandler) digest(id string, data *SprinterCreditData) ([]byte, error) {
+	h.statuses.Update(id, cache.VerifyingState)
+	_, typedData, err := h.typedData(data)
+	if err != nil {
+		data.ErrChn <- err
+		return nil, err
+	}
+
+	unlockHash, err := signature.UnlockHash(typedData)
 	if err != nil {
 		data.ErrChn <- err
 		return nil, err
 	}
+	data.ErrChn <- nil
+	return unlockHash, nil
+}
+
+// typedData returns the calldata and the typed data of the borrow
+// if the transaction is going to the liquidator of the token
+func (h *SprinterCreditMessageHandler) typedData(data *SprinterCreditData) ([]byte, apitypes.TypedData, error) {
+	calldata, err := hex.DecodeString(data.Calldata)
+	if err != nil {
+		return nil, apitypes.TypedData{}, err
+	}
 
 	token := common.HexToAddress(data.TokenOut)
 	liquidator, ok := h.liquidators[token]
 	if !ok {
-		err := fmt.Errorf("no liquidator for token %s", data.TokenOut)
-		data.ErrChn <- err
-		return nil, err
+		return nil, apitypes.TypedData{}, errcode.New(errcode.TokenNotConfigured, "no liquidator for token %s", data.TokenOut).
+			WithDetail("token", data.TokenOut)
 	}
 
-	unlockHash, err := signature.BorrowUnlockHash(
+	return calldata, signature.BorrowTypedData(
 		calldata,
 		data.BorrowAmount,
 		token,
@@ -87,30 +188,25 @@ func (h *SprinterCreditMessageHandler) HandleMessage(m *message.Message) (*propo
 		data.Caller,
 		data.LiquidityPool,
 		data.Nonce,
-	)
-	if err != nil {
-		data.ErrChn <- err
-		return nil, err
-	}
-	data.ErrChn <- nil
+	), nil
+}
 
-	sessionID := fmt.Sprintf("%d-%s", h.chainID, data.DepositID)
+func (h *SprinterCreditMessageHandler) sign(id string, unlockHash []byte, data *SprinterCreditData) error {
 	signing, err := signing.NewSigning(
 		new(big.Int).SetBytes(unlockHash),
-		sessionID,
-		sessionID,
+		id,
+		id,
 		h.host,
 		h.comm,
 		h.fetcher)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	signing.Audit(h.auditLog, audit.NewRequest(comm.SprinterCreditMsg.String(), data, data.Coordinator))
+	signing.UsePresignatures(h.presignatures)
 
-	err = h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
-	if err != nil {
-		return nil, err
-	}
-	return nil, nil
+	h.statuses.Sign(id, unlockHash)
+	return h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
 }
 
 func (h *SprinterCreditMessageHandler) Listen(ctx context.Context) {
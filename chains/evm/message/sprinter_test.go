@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains/evm/message"
 	mock_message "github.com/sprintertech/sprinter-signing/chains/evm/message/mock"
 	"github.com/sprintertech/sprinter-signing/comm"
@@ -28,8 +29,9 @@ type SprinterCreditMessageHandlerTestSuite struct {
 	mockHost          *mock_host.MockHost
 	mockFetcher       *mock_tss.MockSaveDataFetcher
 
-	handler *message.SprinterCreditMessageHandler
-	sigChn  chan interface{}
+	handler  *message.SprinterCreditMessageHandler
+	sigChn   chan interface{}
+	statuses *cache.StatusCache
 }
 
 func TestRunSprinterCreditMessageHandlerTestSuite(t *testing.T) {
@@ -48,6 +50,7 @@ func (s *SprinterCreditMessageHandlerTestSuite) SetupTest() {
 	s.mockFetcher.EXPECT().LockKeyshare().AnyTimes()
 	s.mockFetcher.EXPECT().GetKeyshare().AnyTimes().Return(keyshare.ECDSAKeyshare{}, nil)
 	s.sigChn = make(chan interface{}, 1)
+	s.statuses = cache.NewStatusCache()
 
 	liquidators := make(map[common.Address]common.Address)
 	token := common.HexToAddress("0x0000000000000000000000000000000000000001")
@@ -61,6 +64,9 @@ func (s *SprinterCreditMessageHandlerTestSuite) SetupTest() {
 		s.mockHost,
 		s.mockCommunication,
 		s.mockFetcher,
+		nil,
+		nil,
+		s.statuses,
 		s.sigChn,
 	)
 }
@@ -7,12 +7,11 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/audit"
+	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sprintertech/sprinter-signing/tss"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
@@ -20,20 +19,17 @@ import (
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 )
 
-const (
-	DOMAIN_NAME = "OIFEscrowLIFI"
-	VERSION     = "1"
-)
-
 type LifiUnlockHandler struct {
 	chainID uint64
 
 	repayers map[uint64]common.Address
 
-	coordinator Coordinator
-	host        host.Host
-	comm        comm.Communication
-	fetcher     signing.SaveDataFetcher
+	coordinator   Coordinator
+	host          host.Host
+	comm          comm.Communication
+	fetcher       signing.SaveDataFetcher
+	auditLog      signing.AuditLogger
+	presignatures *signing.PresignaturePool
 }
 
 func NewLifiUnlockHandler(
@@ -43,14 +39,18 @@ func NewLifiUnlockHandler(
 	host host.Host,
 	comm comm.Communication,
 	fetcher signing.SaveDataFetcher,
+	auditLog signing.AuditLogger,
+	presignatures *signing.PresignaturePool,
 ) *LifiUnlockHandler {
 	return &LifiUnlockHandler{
-		chainID:     chainID,
-		repayers:    repayers,
-		coordinator: coordinator,
-		host:        host,
-		comm:        comm,
-		fetcher:     fetcher,
+		chainID:       chainID,
+		repayers:      repayers,
+		coordinator:   coordinator,
+		host:          host,
+		comm:          comm,
+		fetcher:       fetcher,
+		auditLog:      auditLog,
+		presignatures: presignatures,
 	}
 }
 
@@ -78,6 +78,8 @@ func (h *LifiUnlockHandler) HandleMessage(m *message.Message) (*proposal.Proposa
 	if err != nil {
 		return nil, err
 	}
+	signing.Audit(h.auditLog, audit.NewRequest(comm.LifiUnlockMsg.String(), data, data.Coordinator))
+	signing.UsePresignatures(h.presignatures)
 
 	err = h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, data.SigChn, data.Coordinator)
 	if err != nil {
@@ -142,46 +144,10 @@ func (h *LifiUnlockHandler) lifiUnlockHash(data *LifiUnlockData) ([]byte, error)
 		return nil, fmt.Errorf("invalid repayment chain %d", h.chainID)
 	}
 
-	msg := apitypes.TypedDataMessage{
-		"orderId":     common.HexToHash(data.OrderID),
-		"destination": common.HexToHash(repaymentAddress.Hex()),
-		"call":        "0x",
-	}
-	chainId := math.HexOrDecimal256(*new(big.Int).SetUint64(h.chainID))
-	typedData := apitypes.TypedData{
-		Types: apitypes.Types{
-			"EIP712Domain": []apitypes.Type{
-				{Name: "name", Type: "string"},
-				{Name: "version", Type: "string"},
-				{Name: "chainId", Type: "uint256"},
-				{Name: "verifyingContract", Type: "address"},
-			},
-			"AllowOpen": []apitypes.Type{
-				{Name: "orderId", Type: "bytes32"},
-				{Name: "destination", Type: "bytes32"},
-				{Name: "call", Type: "bytes"},
-			},
-		},
-		PrimaryType: "AllowOpen",
-		Domain: apitypes.TypedDataDomain{
-			Name:              DOMAIN_NAME,
-			ChainId:           &chainId,
-			Version:           VERSION,
-			VerifyingContract: data.Settler.Hex(),
-		},
-		Message: msg,
-	}
-
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
-	if err != nil {
-		return []byte{}, err
-	}
-
-	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(messageHash)))
-	return crypto.Keccak256(rawData), nil
+	return signature.AllowOpenUnlockHash(
+		h.chainID,
+		common.HexToHash(data.OrderID),
+		repaymentAddress,
+		data.Settler,
+	)
 }
@@ -64,6 +64,8 @@ func (s *LifiUnlockHandlerTestSuite) SetupTest() {
 		s.mockHost,
 		s.mockCommunication,
 		s.mockFetcher,
+		nil,
+		nil,
 	)
 }
 
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
@@ -14,8 +15,20 @@ import (
 const (
 	DOMAIN_NAME = "LiquidityPool"
 	VERSION     = "1.0.0"
+
+	LIFI_DOMAIN_NAME = "OIFEscrowLIFI"
+	LIFI_VERSION     = "1"
 )
 
+// Preview is the data the MPC committee would sign for a signing request
+type Preview struct {
+	Calldata              hexutil.Bytes      `json:"calldata"`
+	UnlockHash            hexutil.Bytes      `json:"unlockHash"`
+	TypedData             apitypes.TypedData `json:"typedData"`
+	Confirmations         uint64             `json:"confirmations"`
+	RequiredConfirmations uint64             `json:"requiredConfirmations"`
+}
+
 // BorrowUnlockHash calculates the hash that has to be signed and submitted on-chain to the liquidity
 // pool contract.
 func BorrowUnlockHash(
@@ -29,18 +42,46 @@ func BorrowUnlockHash(
 	liquidityPool common.Address,
 	nonce *big.Int,
 ) ([]byte, error) {
+	typedData := BorrowTypedData(
+		calldata,
+		outputAmount,
+		outputToken,
+		destinationChainId,
+		target,
+		deadline,
+		caller,
+		liquidityPool,
+		nonce,
+	)
+	return UnlockHash(typedData)
+}
+
+// BorrowTypedData returns the EIP-712 typed data of the liquidity pool borrow.
+// Numeric values are stored as decimal strings so the typed data can be
+// returned to users as JSON.
+func BorrowTypedData(
+	calldata []byte,
+	outputAmount *big.Int,
+	outputToken common.Address,
+	destinationChainId *big.Int,
+	target common.Address,
+	deadline uint64,
+	caller common.Address,
+	liquidityPool common.Address,
+	nonce *big.Int,
+) apitypes.TypedData {
 	msg := apitypes.TypedDataMessage{
 		"caller":         caller.Hex(),
 		"borrowToken":    outputToken.Hex(),
-		"amount":         outputAmount,
+		"amount":         outputAmount.String(),
 		"target":         target.Hex(),
-		"targetCallData": calldata,
-		"nonce":          nonce,
-		"deadline":       new(big.Int).SetUint64(deadline),
+		"targetCallData": hexutil.Bytes(calldata),
+		"nonce":          nonce.String(),
+		"deadline":       new(big.Int).SetUint64(deadline).String(),
 	}
 
 	chainId := math.HexOrDecimal256(*destinationChainId)
-	typedData := apitypes.TypedData{
+	return apitypes.TypedData{
 		Types: apitypes.Types{
 			"EIP712Domain": []apitypes.Type{
 				{Name: "name", Type: "string"},
@@ -67,7 +108,60 @@ func BorrowUnlockHash(
 		},
 		Message: msg,
 	}
+}
+
+// AllowOpenUnlockHash calculates the hash that allows the Lifi escrow order to be opened
+// with the funds sent to the destination.
+func AllowOpenUnlockHash(
+	chainID uint64,
+	orderID common.Hash,
+	destination common.Address,
+	settler common.Address,
+) ([]byte, error) {
+	return UnlockHash(AllowOpenTypedData(chainID, orderID, destination, settler))
+}
+
+// AllowOpenTypedData returns the EIP-712 typed data of the Lifi escrow AllowOpen message
+func AllowOpenTypedData(
+	chainID uint64,
+	orderID common.Hash,
+	destination common.Address,
+	settler common.Address,
+) apitypes.TypedData {
+	msg := apitypes.TypedDataMessage{
+		"orderId":     orderID,
+		"destination": common.HexToHash(destination.Hex()),
+		"call":        "0x",
+	}
+
+	chainId := math.HexOrDecimal256(*new(big.Int).SetUint64(chainID))
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"AllowOpen": []apitypes.Type{
+				{Name: "orderId", Type: "bytes32"},
+				{Name: "destination", Type: "bytes32"},
+				{Name: "call", Type: "bytes"},
+			},
+		},
+		PrimaryType: "AllowOpen",
+		Domain: apitypes.TypedDataDomain{
+			Name:              LIFI_DOMAIN_NAME,
+			ChainId:           &chainId,
+			Version:           LIFI_VERSION,
+			VerifyingContract: settler.Hex(),
+		},
+		Message: msg,
+	}
+}
 
+// UnlockHash calculates the EIP-712 digest of the typed data
+func UnlockHash(typedData apitypes.TypedData) ([]byte, error) {
 	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
 		return []byte{}, err
@@ -80,7 +174,7 @@ func BorrowUnlockHash(
 
 	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(messageHash)))
 	unlockHash := crypto.Keccak256(rawData)
-	log.Debug().Msgf("Created unlock hash %s with data: %+v", common.Bytes2Hex(unlockHash), msg)
+	log.Debug().Msgf("Created unlock hash %s with data: %+v", common.Bytes2Hex(unlockHash), typedData.Message)
 	return unlockHash, nil
 }
 
@@ -141,16 +235,5 @@ func BorrowManyUnlockHash(
 		Message: msg,
 	}
 
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
-	if err != nil {
-		return []byte{}, err
-	}
-
-	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(messageHash)))
-	return crypto.Keccak256(rawData), nil
+	return UnlockHash(typedData)
 }
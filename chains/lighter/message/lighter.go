@@ -3,7 +3,6 @@ package message
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"maps"
 	"math"
@@ -12,13 +11,17 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/audit"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains/evm/calls/consts"
 	"github.com/sprintertech/sprinter-signing/chains/evm/signature"
 	lighterChain "github.com/sprintertech/sprinter-signing/chains/lighter"
 	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/errcode"
 	"github.com/sprintertech/sprinter-signing/protocol/lighter"
 	"github.com/sprintertech/sprinter-signing/tss"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
@@ -36,16 +39,26 @@ type Coordinator interface {
 	Execute(ctx context.Context, tssProcesses []tss.TssProcess, resultChn chan interface{}, coordinator peer.ID) error
 }
 
+type StatusTracker interface {
+	Receive(id string, caller string, paramsHash string) error
+	Update(id string, state cache.RequestState)
+	Sign(id string, digest []byte)
+	Fail(id string, err error)
+}
+
 type TxFetcher interface {
 	GetTx(hash string) (*lighter.LighterTx, error)
 }
 
 type LighterMessageHandler struct {
-	coordinator Coordinator
-	host        host.Host
-	comm        comm.Communication
-	fetcher     signing.SaveDataFetcher
-	sigChn      chan any
+	coordinator   Coordinator
+	host          host.Host
+	comm          comm.Communication
+	fetcher       signing.SaveDataFetcher
+	auditLog      signing.AuditLogger
+	presignatures *signing.PresignaturePool
+	statuses      StatusTracker
+	sigChn        chan any
 
 	lighterAddress   common.Address
 	usdcAddress      common.Address
@@ -64,6 +77,9 @@ func NewLighterMessageHandler(
 	host host.Host,
 	comm comm.Communication,
 	fetcher signing.SaveDataFetcher,
+	auditLog signing.AuditLogger,
+	presignatures *signing.PresignaturePool,
+	statuses StatusTracker,
 	sigChn chan any,
 ) *LighterMessageHandler {
 	return &LighterMessageHandler{
@@ -75,6 +91,9 @@ func NewLighterMessageHandler(
 		host:             host,
 		comm:             comm,
 		fetcher:          fetcher,
+		auditLog:         auditLog,
+		presignatures:    presignatures,
+		statuses:         statuses,
 		sigChn:           sigChn,
 		confirmations:    confirmations,
 	}
@@ -85,31 +104,132 @@ func NewLighterMessageHandler(
 // cache through the result channel.
 func (h *LighterMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
 	data := m.Data.(*LighterData)
+	id := fmt.Sprintf("%d-%s", lighterChain.LIGHTER_DOMAIN_ID, data.OrderHash)
+
+	err := h.statuses.Receive(id, "", data.ParamsHash())
+	if err != nil {
+		data.ErrChn <- err
+		return nil, err
+	}
 
-	err := h.notify(data)
+	err = h.notify(data)
 	if err != nil {
 		log.Warn().Msgf("Failed to notify relayers because of %s", err)
 	}
 
-	tx, err := h.txFetcher.GetTx(data.DepositTxHash)
+	unlockHash, err := h.digest(id, data)
 	if err != nil {
-		data.ErrChn <- err
+		h.statuses.Fail(id, err)
 		return nil, err
 	}
 
-	if err = h.verifyWithdrawal(tx); err != nil {
+	err = errcode.Wrap(errcode.SigningFailed, h.sign(id, unlockHash, data))
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// Digest verifies the JSON encoded message data and returns the request ID
+// and the unlock hash that should be signed for it.
+func (h *LighterMessageHandler) Digest(payload []byte) (string, []byte, error) {
+	data := &LighterData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return "", nil, err
+	}
+	data.ErrChn = make(chan error, 1)
+	id := fmt.Sprintf("%d-%s", lighterChain.LIGHTER_DOMAIN_ID, data.OrderHash)
+
+	err = h.statuses.Receive(id, "", data.ParamsHash())
+	if err != nil {
+		return id, nil, err
+	}
+
+	unlockHash, err := h.digest(id, data)
+	if err != nil {
+		h.statuses.Fail(id, err)
+		return id, nil, err
+	}
+	return id, unlockHash, nil
+}
+
+// Preview verifies the JSON encoded message data and returns the unlock hash
+// and the typed data that would be signed for it.
+func (h *LighterMessageHandler) Preview(payload []byte) (*signature.Preview, error) {
+	data := &LighterData{}
+	err := json.Unmarshal(payload, data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := h.verify(data)
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, typedData, err := h.typedData(tx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockHash, err := signature.UnlockHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return &signature.Preview{
+		Calldata:   calldata,
+		UnlockHash: unlockHash,
+		TypedData:  typedData,
+	}, nil
+}
+
+func (h *LighterMessageHandler) digest(id string, data *LighterData) ([]byte, error) {
+	h.statuses.Update(id, cache.VerifyingState)
+	tx, err := h.verify(data)
+	if err != nil {
 		data.ErrChn <- err
 		return nil, err
 	}
 
 	data.ErrChn <- nil
 
-	calldata, err := h.calldata(tx)
+	_, typedData, err := h.typedData(tx, data)
 	if err != nil {
 		return nil, err
 	}
 
-	unlockHash, err := signature.BorrowUnlockHash(
+	unlockHash, err := signature.UnlockHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return unlockHash, nil
+}
+
+// verify fetches the lighter transaction and checks that it is a valid withdrawal
+func (h *LighterMessageHandler) verify(data *LighterData) (*lighter.LighterTx, error) {
+	tx, err := h.txFetcher.GetTx(data.DepositTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.verifyWithdrawal(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// typedData returns the calldata and the typed data of the borrow
+// that fulfills the lighter withdrawal
+func (h *LighterMessageHandler) typedData(tx *lighter.LighterTx, data *LighterData) ([]byte, apitypes.TypedData, error) {
+	calldata, err := h.calldata(tx)
+	if err != nil {
+		return nil, apitypes.TypedData{}, err
+	}
+
+	return calldata, signature.BorrowTypedData(
 		calldata,
 		new(big.Int).SetUint64(tx.Transfer.Amount),
 		h.usdcAddress,
@@ -118,42 +238,41 @@ func (h *LighterMessageHandler) HandleMessage(m *message.Message) (*proposal.Pro
 		data.Deadline,
 		h.lighterAddress,
 		data.LiquidityPool,
-		data.Nonce)
-	if err != nil {
-		data.ErrChn <- err
-		return nil, err
-	}
+		data.Nonce), nil
+}
 
-	sessionID := fmt.Sprintf("%d-%s", lighterChain.LIGHTER_DOMAIN_ID, data.OrderHash)
+func (h *LighterMessageHandler) sign(id string, unlockHash []byte, data *LighterData) error {
 	signing, err := signing.NewSigning(
 		new(big.Int).SetBytes(unlockHash),
-		sessionID,
-		sessionID,
+		id,
+		id,
 		h.host,
 		h.comm,
 		h.fetcher)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	signing.Audit(h.auditLog, audit.NewRequest(comm.LighterMsg.String(), data, data.Coordinator))
+	signing.UsePresignatures(h.presignatures)
 
-	err = h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
-	if err != nil {
-		return nil, err
-	}
-	return nil, nil
+	h.statuses.Sign(id, unlockHash)
+	return h.coordinator.Execute(context.Background(), []tss.TssProcess{signing}, h.sigChn, data.Coordinator)
 }
 
 func (h *LighterMessageHandler) verifyWithdrawal(tx *lighter.LighterTx) error {
 	if tx.Type != lighter.TxTypeL2Transfer {
-		return errors.New("invalid transaction type")
+		return errcode.New(errcode.PolicyViolation, "invalid transaction type").
+			WithDetail("type", tx.Type)
 	}
 
 	if strconv.Itoa(tx.Transfer.ToAccountIndex) != h.repaymentAccount {
-		return errors.New("transfer account index invalid")
+		return errcode.New(errcode.PolicyViolation, "transfer account index invalid").
+			WithDetail("accountIndex", tx.Transfer.ToAccountIndex)
 	}
 
 	if tx.Transfer.AssetIndex != USDC_ACCOUNT_INDEX {
-		return errors.New("only usdc asset supported on lighter")
+		return errcode.New(errcode.PolicyViolation, "only usdc asset supported on lighter").
+			WithDetail("assetIndex", tx.Transfer.AssetIndex)
 	}
 
 	if err := h.verifyOrderSize(tx.Transfer.Amount / uint64(math.Pow(10, USDC_DECIMALS))); err != nil {
@@ -172,7 +291,8 @@ func (h *LighterMessageHandler) verifyOrderSize(orderValue uint64) error {
 		}
 	}
 
-	return fmt.Errorf("order value %d exceeds confirmation buckets", orderValue)
+	return errcode.New(errcode.AmountExceeded, "order value %d exceeds confirmation buckets", orderValue).
+		WithDetail("orderValue", orderValue)
 }
 
 func (h *LighterMessageHandler) calldata(tx *lighter.LighterTx) ([]byte, error) {
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	"github.com/sprintertech/sprinter-signing/cache"
 	"github.com/sprintertech/sprinter-signing/chains/lighter/message"
 	mock_message "github.com/sprintertech/sprinter-signing/chains/lighter/message/mock"
 	"github.com/sprintertech/sprinter-signing/comm"
@@ -30,8 +31,9 @@ type LighterMessageHandlerTestSuite struct {
 	mockFetcher       *mock_tss.MockSaveDataFetcher
 	mockTxFetcher     *mock_message.MockTxFetcher
 
-	handler *message.LighterMessageHandler
-	sigChn  chan interface{}
+	handler  *message.LighterMessageHandler
+	sigChn   chan interface{}
+	statuses *cache.StatusCache
 }
 
 func TestRunLighterMessageHandlerTestSuite(t *testing.T) {
@@ -54,6 +56,7 @@ func (s *LighterMessageHandlerTestSuite) SetupTest() {
 	s.mockTxFetcher = mock_message.NewMockTxFetcher(ctrl)
 
 	s.sigChn = make(chan interface{}, 1)
+	s.statuses = cache.NewStatusCache()
 	confirmations := make(map[uint64]uint64)
 	confirmations[200] = 0
 
@@ -67,6 +70,9 @@ func (s *LighterMessageHandlerTestSuite) SetupTest() {
 		s.mockHost,
 		s.mockCommunication,
 		s.mockFetcher,
+		nil,
+		nil,
+		s.statuses,
 		s.sigChn,
 	)
 }
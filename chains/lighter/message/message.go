@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/chains"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 )
@@ -14,16 +15,29 @@ type LighterData struct {
 	ErrChn chan error `json:"-"`
 
 	OrderHash     string
-	Coordinator   peer.ID
+	Coordinator   peer.ID `json:",omitempty"`
 	LiquidityPool common.Address
 	DepositTxHash string
 	Calldata      string
 	Nonce         *big.Int
 	Deadline      uint64
+	ClientID      string
 	Source        uint64
 	Destination   uint64
 }
 
+// ParamsHash identifies the signing parameters of the lighter request
+func (d *LighterData) ParamsHash() string {
+	return chains.ParamsHash(
+		comm.LighterMsg.String(),
+		d.OrderHash,
+		d.DepositTxHash,
+		d.LiquidityPool,
+		d.Nonce,
+		d.Deadline,
+	)
+}
+
 func NewLighterMessage(source, destination uint64, lighterData *LighterData) *message.Message {
 	return &message.Message{
 		Source:      source,
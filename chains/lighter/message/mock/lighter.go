@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	peer "github.com/libp2p/go-libp2p/core/peer"
+	cache "github.com/sprintertech/sprinter-signing/cache"
 	lighter "github.com/sprintertech/sprinter-signing/protocol/lighter"
 	tss "github.com/sprintertech/sprinter-signing/tss"
 	gomock "go.uber.org/mock/gomock"
@@ -57,6 +58,80 @@ func (mr *MockCoordinatorMockRecorder) Execute(ctx, tssProcesses, resultChn, coo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockCoordinator)(nil).Execute), ctx, tssProcesses, resultChn, coordinator)
 }
 
+// MockStatusTracker is a mock of StatusTracker interface.
+type MockStatusTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusTrackerMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusTrackerMockRecorder is the mock recorder for MockStatusTracker.
+type MockStatusTrackerMockRecorder struct {
+	mock *MockStatusTracker
+}
+
+// NewMockStatusTracker creates a new mock instance.
+func NewMockStatusTracker(ctrl *gomock.Controller) *MockStatusTracker {
+	mock := &MockStatusTracker{ctrl: ctrl}
+	mock.recorder = &MockStatusTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusTracker) EXPECT() *MockStatusTrackerMockRecorder {
+	return m.recorder
+}
+
+// Fail mocks base method.
+func (m *MockStatusTracker) Fail(id string, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fail", id, err)
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockStatusTrackerMockRecorder) Fail(id, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockStatusTracker)(nil).Fail), id, err)
+}
+
+// Receive mocks base method.
+func (m *MockStatusTracker) Receive(id, caller, paramsHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", id, caller, paramsHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockStatusTrackerMockRecorder) Receive(id, caller, paramsHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockStatusTracker)(nil).Receive), id, caller, paramsHash)
+}
+
+// Sign mocks base method.
+func (m *MockStatusTracker) Sign(id string, digest []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Sign", id, digest)
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockStatusTrackerMockRecorder) Sign(id, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockStatusTracker)(nil).Sign), id, digest)
+}
+
+// Update mocks base method.
+func (m *MockStatusTracker) Update(id string, state cache.RequestState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update", id, state)
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusTrackerMockRecorder) Update(id, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatusTracker)(nil).Update), id, state)
+}
+
 // MockTxFetcher is a mock of TxFetcher interface.
 type MockTxFetcher struct {
 	ctrl     *gomock.Controller
@@ -4,8 +4,12 @@
 package chains
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // CalculateStartingBlock returns first block number (smaller or equal) that is dividable with block confirmations
@@ -33,3 +37,10 @@ func ScaleTokenAmount(amount *big.Int, srcDecimals, dstDecimals int64) *big.Int
 	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(dstDecimals-srcDecimals), nil)
 	return new(big.Int).Mul(amount, scale)
 }
+
+// ParamsHash returns the hex encoded keccak256 hash of the JSON encoded parameters.
+// It is used to identify retries of a signing request with the same parameters.
+func ParamsHash(params ...any) string {
+	data, _ := json.Marshal(params)
+	return common.Bytes2Hex(crypto.Keccak256(data))
+}
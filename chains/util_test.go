@@ -74,3 +74,10 @@ func (s *UtilTestSuite) TestScaleTokenAmount() {
 		})
 	}
 }
+
+func (s *UtilTestSuite) Test_ParamsHash() {
+	hash := ParamsHash("across", big.NewInt(1000), uint64(100))
+
+	s.Equal(hash, ParamsHash("across", big.NewInt(1000), uint64(100)))
+	s.NotEqual(hash, ParamsHash("across", big.NewInt(1001), uint64(100)))
+}
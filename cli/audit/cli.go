@@ -0,0 +1,14 @@
+package audit
+
+import "github.com/spf13/cobra"
+
+var AuditCLI = &cobra.Command{
+	Use:   "audit",
+	Short: "commands to export, verify and compare signing audit logs",
+}
+
+func init() {
+	AuditCLI.AddCommand(exportCMD)
+	AuditCLI.AddCommand(verifyCMD)
+	AuditCLI.AddCommand(compareCMD)
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sprintertech/sprinter-signing/audit"
+)
+
+var (
+	compareCMD = &cobra.Command{
+		Use:   "compare",
+		Short: "Compare audit logs of multiple relayers",
+		Long: "Verifies the audit logs and reports digests that were recorded by some " +
+			"relayers of the signing subset but are missing from the logs of others",
+		RunE: compare,
+	}
+)
+
+var (
+	comparePaths []string
+)
+
+func init() {
+	compareCMD.PersistentFlags().StringSliceVar(&comparePaths, "paths", nil, "comma separated paths to the audit logs")
+	_ = compareCMD.MarkFlagRequired("paths")
+}
+
+func compare(cmd *cobra.Command, args []string) error {
+	logs := make([][]audit.Entry, len(comparePaths))
+	for i, path := range comparePaths {
+		entries, err := audit.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		err = audit.Verify(entries)
+		if err != nil {
+			return fmt.Errorf("audit log %s is corrupted: %w", path, err)
+		}
+		logs[i] = entries
+	}
+
+	discrepancies := audit.Compare(logs)
+	for _, d := range discrepancies {
+		fmt.Printf("Digest %s of session %s recorded by %v is missing from %v\n", d.Digest, d.SessionID, d.RecordedBy, d.MissingFrom)
+	}
+	if len(discrepancies) > 0 {
+		return fmt.Errorf("found %d discrepancies between audit logs", len(discrepancies))
+	}
+
+	fmt.Printf("Audit logs are consistent\n")
+	return nil
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sprintertech/sprinter-signing/audit"
+)
+
+var (
+	exportCMD = &cobra.Command{
+		Use:   "export",
+		Short: "Export audit log as JSON",
+		Long:  "Verifies the audit log and exports its entries as a JSON array to the output file or stdout",
+		RunE:  export,
+	}
+)
+
+var (
+	exportPath string
+	outputPath string
+)
+
+func init() {
+	exportCMD.PersistentFlags().StringVar(&exportPath, "path", "", "path to the audit log")
+	_ = exportCMD.MarkFlagRequired("path")
+	exportCMD.PersistentFlags().StringVar(&outputPath, "output", "", "output file, defaults to stdout")
+}
+
+func export(cmd *cobra.Command, args []string) error {
+	entries, err := audit.ReadFile(exportPath)
+	if err != nil {
+		return err
+	}
+	err = audit.Verify(entries)
+	if err != nil {
+		return err
+	}
+
+	entriesJSON, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	entriesJSON = append(entriesJSON, '\n')
+	if outputPath == "" {
+		_, err = os.Stdout.Write(entriesJSON)
+		return err
+	}
+	return os.WriteFile(outputPath, entriesJSON, 0600)
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sprintertech/sprinter-signing/audit"
+)
+
+var (
+	verifyCMD = &cobra.Command{
+		Use:   "verify",
+		Short: "Verify audit log hash chain",
+		Long:  "Verifies that no entry of the audit log was modified, removed or reordered",
+		RunE:  verify,
+	}
+)
+
+var (
+	verifyPath string
+)
+
+func init() {
+	verifyCMD.PersistentFlags().StringVar(&verifyPath, "path", "", "path to the audit log")
+	_ = verifyCMD.MarkFlagRequired("path")
+}
+
+func verify(cmd *cobra.Command, args []string) error {
+	entries, err := audit.ReadFile(verifyPath)
+	if err != nil {
+		return err
+	}
+	err = audit.Verify(entries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Audit log is intact with %d entries\n", len(entries))
+	return nil
+}
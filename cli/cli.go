@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/sprintertech/sprinter-signing/cli/audit"
 	"github.com/sprintertech/sprinter-signing/cli/keygen"
 	"github.com/sprintertech/sprinter-signing/cli/peer"
 	"github.com/sprintertech/sprinter-signing/cli/topology"
@@ -28,7 +29,7 @@ func init() {
 }
 
 func Execute() {
-	rootCMD.AddCommand(runCMD, peer.PeerCLI, topology.TopologyCLI, utils.UtilsCLI, keygen.KeygenCLI)
+	rootCMD.AddCommand(runCMD, peer.PeerCLI, topology.TopologyCLI, utils.UtilsCLI, keygen.KeygenCLI, audit.AuditCLI)
 	if err := rootCMD.Execute(); err != nil {
 		log.Fatal().Err(err).Msg("failed to execute root cmd")
 	}
@@ -0,0 +1,324 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package elector
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/sprintertech/sprinter-signing/tss/util"
+)
+
+const (
+	messageBufferSize = 16
+	// maxElectionRestarts is the number of times the election is restarted if a higher
+	// priority peer is alive but no coordinator is selected in the bully wait time
+	maxElectionRestarts = 3
+)
+
+// errNoSelection is returned if a higher priority peer is alive but no coordinator is selected
+var errNoSelection = errors.New("no coordinator selected")
+
+// bullyCoordinatorElector elects the highest priority peer that responds to election messages
+// as the session coordinator. Peers are prioritized by the static session ordering so the
+// bully elector selects the same coordinator as the static elector while all peers are alive.
+type bullyCoordinatorElector struct {
+	sessionID string
+	hostID    peer.ID
+	comm      comm.Communication
+	conf      relayer.BullyConfig
+
+	aliveChn        chan *comm.WrappedMessage
+	selectChn       chan *comm.WrappedMessage
+	pingResponseChn chan *comm.WrappedMessage
+
+	lock        sync.Mutex
+	coordinator peer.ID
+}
+
+func NewBullyCoordinatorElector(
+	sessionID string,
+	hostID peer.ID,
+	communication comm.Communication,
+	conf relayer.BullyConfig,
+) CoordinatorElector {
+	return &bullyCoordinatorElector{
+		sessionID:       sessionID,
+		hostID:          hostID,
+		comm:            communication,
+		conf:            conf,
+		aliveChn:        make(chan *comm.WrappedMessage, messageBufferSize),
+		selectChn:       make(chan *comm.WrappedMessage, messageBufferSize),
+		pingResponseChn: make(chan *comm.WrappedMessage, messageBufferSize),
+	}
+}
+
+// Coordinator sends the election message to all peers with a higher priority. The peer
+// pronounces itself as the coordinator if none of them responds in the election wait time,
+// otherwise it waits for the coordinator selected by one of the higher priority peers.
+// The election is restarted if no coordinator is selected in the bully wait time and the
+// peer pronounces itself as the coordinator once maxElectionRestarts is reached.
+// Listen has to be called beforehand so responses of other peers are received.
+func (bc *bullyCoordinatorElector) Coordinator(ctx context.Context, peers peer.IDSlice) (peer.ID, error) {
+	higherPeers := bc.higherPeers(peers)
+	if len(higherPeers) == 0 {
+		return bc.selectSelf(peers)
+	}
+
+	for restarts := 0; ; restarts++ {
+		coordinator, err := bc.elect(ctx, peers, higherPeers)
+		if !errors.Is(err, errNoSelection) {
+			return coordinator, err
+		}
+		if restarts == maxElectionRestarts {
+			log.Warn().Str("SessionID", bc.sessionID).Msgf("No coordinator selected after %d election restarts", restarts)
+			return bc.selectSelf(peers)
+		}
+
+		log.Warn().Str("SessionID", bc.sessionID).Msgf("No coordinator selected, restarting election")
+	}
+}
+
+// elect runs a single election round with the higher priority peers
+func (bc *bullyCoordinatorElector) elect(ctx context.Context, peers peer.IDSlice, higherPeers peer.IDSlice) (peer.ID, error) {
+	log.Debug().Str("SessionID", bc.sessionID).Msgf("Starting coordinator election")
+	_ = bc.comm.Broadcast(higherPeers, []byte{}, comm.CoordinatorElectionMsg, bc.sessionID)
+
+	timer := time.NewTimer(bc.conf.ElectionWaitTime)
+	defer timer.Stop()
+	alive := false
+	for {
+		select {
+		case msg := <-bc.selectChn:
+			{
+				if !slices.Contains(higherPeers, msg.From) {
+					continue
+				}
+
+				bc.setCoordinator(msg.From)
+				return msg.From, nil
+			}
+		case msg := <-bc.aliveChn:
+			{
+				if alive || !slices.Contains(higherPeers, msg.From) {
+					continue
+				}
+
+				alive = true
+				timer.Reset(bc.conf.BullyWaitTime)
+			}
+		case <-timer.C:
+			{
+				if alive {
+					return peer.ID(""), errNoSelection
+				}
+
+				return bc.selectSelf(peers)
+			}
+		case <-ctx.Done():
+			{
+				return peer.ID(""), ctx.Err()
+			}
+		}
+	}
+}
+
+// Listen responds with the alive message to election messages of lower priority peers
+// and to ping messages of all peers. Peers that started an election after this peer
+// pronounced itself as the coordinator are sent the select message as well.
+func (bc *bullyCoordinatorElector) Listen(ctx context.Context) {
+	electionChn := make(chan *comm.WrappedMessage)
+	pingChn := make(chan *comm.WrappedMessage)
+	msgChn := make(chan *comm.WrappedMessage)
+	subIDs := []comm.SubscriptionID{
+		bc.comm.Subscribe(bc.sessionID, comm.CoordinatorElectionMsg, electionChn),
+		bc.comm.Subscribe(bc.sessionID, comm.CoordinatorPingMsg, pingChn),
+		bc.comm.Subscribe(bc.sessionID, comm.CoordinatorAliveMsg, msgChn),
+		bc.comm.Subscribe(bc.sessionID, comm.CoordinatorSelectMsg, msgChn),
+		bc.comm.Subscribe(bc.sessionID, comm.CoordinatorPingResponseMsg, msgChn),
+	}
+
+	go func() {
+		defer func() {
+			for _, subID := range subIDs {
+				bc.comm.UnSubscribe(subID)
+			}
+		}()
+
+		for {
+			select {
+			case msg := <-electionChn:
+				{
+					if !bc.higher(bc.hostID, msg.From) {
+						continue
+					}
+
+					_ = bc.comm.Broadcast(peer.IDSlice{msg.From}, []byte{}, comm.CoordinatorAliveMsg, bc.sessionID)
+					if bc.getCoordinator() == bc.hostID {
+						_ = bc.comm.Broadcast(peer.IDSlice{msg.From}, []byte{}, comm.CoordinatorSelectMsg, bc.sessionID)
+					}
+				}
+			case msg := <-pingChn:
+				{
+					_ = bc.comm.Broadcast(peer.IDSlice{msg.From}, []byte{}, comm.CoordinatorPingResponseMsg, bc.sessionID)
+				}
+			case msg := <-msgChn:
+				{
+					bc.forward(msg)
+				}
+			case <-ctx.Done():
+				{
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Watch pings the coordinator every ping interval. The coordinator is unresponsive
+// if it does not respond to a ping and a retried ping after the ping back off.
+func (bc *bullyCoordinatorElector) Watch(ctx context.Context, coordinator peer.ID) error {
+	if coordinator == bc.hostID {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(bc.conf.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			{
+				if bc.ping(ctx, coordinator) {
+					continue
+				}
+
+				log.Warn().Str("SessionID", bc.sessionID).Msgf("Coordinator %s did not respond to ping", coordinator)
+				select {
+				case <-time.After(bc.conf.PingBackOff):
+				case <-ctx.Done():
+					return nil
+				}
+				if !bc.ping(ctx, coordinator) {
+					return ErrCoordinatorUnresponsive
+				}
+			}
+		case <-ctx.Done():
+			{
+				return nil
+			}
+		}
+	}
+}
+
+// ping returns true if the coordinator responds in the ping wait time
+func (bc *bullyCoordinatorElector) ping(ctx context.Context, coordinator peer.ID) bool {
+	err := bc.comm.Broadcast(peer.IDSlice{coordinator}, []byte{}, comm.CoordinatorPingMsg, bc.sessionID)
+	if err != nil {
+		return false
+	}
+
+	timer := time.NewTimer(bc.conf.PingWaitTime)
+	defer timer.Stop()
+	for {
+		select {
+		case msg := <-bc.pingResponseChn:
+			{
+				if msg.From == coordinator {
+					return true
+				}
+			}
+		case <-timer.C:
+			{
+				return false
+			}
+		case <-ctx.Done():
+			{
+				return true
+			}
+		}
+	}
+}
+
+// forward passes responses to the election or ping currently in progress. Responses
+// are dropped if the buffer is full as they are only relevant for the latest requests.
+func (bc *bullyCoordinatorElector) forward(msg *comm.WrappedMessage) {
+	var msgChn chan *comm.WrappedMessage
+	switch msg.MessageType {
+	case comm.CoordinatorAliveMsg:
+		msgChn = bc.aliveChn
+	case comm.CoordinatorSelectMsg:
+		msgChn = bc.selectChn
+	case comm.CoordinatorPingResponseMsg:
+		msgChn = bc.pingResponseChn
+	default:
+		return
+	}
+
+	select {
+	case msgChn <- msg:
+	default:
+	}
+}
+
+// selectSelf pronounces the peer as the coordinator to all other peers
+// if it is one of the peers that can be elected
+func (bc *bullyCoordinatorElector) selectSelf(peers peer.IDSlice) (peer.ID, error) {
+	if !slices.Contains(peers, bc.hostID) {
+		return peer.ID(""), ErrCoordinatorNotSelected
+	}
+
+	log.Info().Str("SessionID", bc.sessionID).Msgf("Pronouncing self as coordinator")
+	bc.setCoordinator(bc.hostID)
+
+	otherPeers := make(peer.IDSlice, 0, len(peers))
+	for _, p := range peers {
+		if p != bc.hostID {
+			otherPeers = append(otherPeers, p)
+		}
+	}
+	_ = bc.comm.Broadcast(otherPeers, []byte{}, comm.CoordinatorSelectMsg, bc.sessionID)
+	return bc.hostID, nil
+}
+
+// higherPeers returns peers with a higher priority than the host. All peers
+// have a higher priority if the host can not be elected.
+func (bc *bullyCoordinatorElector) higherPeers(peers peer.IDSlice) peer.IDSlice {
+	higherPeers := make(peer.IDSlice, 0)
+	for _, p := range peers {
+		if !slices.Contains(peers, bc.hostID) || bc.higher(p, bc.hostID) {
+			higherPeers = append(higherPeers, p)
+		}
+	}
+	return higherPeers
+}
+
+// higher returns true if the first peer has a higher priority than the second one
+func (bc *bullyCoordinatorElector) higher(a peer.ID, b peer.ID) bool {
+	if a == b {
+		return false
+	}
+	return util.SortPeersForSession([]peer.ID{a, b}, bc.sessionID)[0].ID == a
+}
+
+func (bc *bullyCoordinatorElector) setCoordinator(coordinator peer.ID) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	bc.coordinator = coordinator
+}
+
+func (bc *bullyCoordinatorElector) getCoordinator() peer.ID {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return bc.coordinator
+}
@@ -0,0 +1,147 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package elector_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/sprintertech/sprinter-signing/comm/elector"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+)
+
+var bullyConfig = relayer.BullyConfig{
+	PingWaitTime:     200 * time.Millisecond,
+	PingBackOff:      200 * time.Millisecond,
+	PingInterval:     200 * time.Millisecond,
+	ElectionWaitTime: time.Second,
+	BullyWaitTime:    5 * time.Second,
+}
+
+func (s *CoordinatorElectorTestSuite) bullyElectors(sessionID string) []elector.CoordinatorElector {
+	electors := make([]elector.CoordinatorElector, len(s.testHosts))
+	for i, testHost := range s.testHosts {
+		electors[i] = elector.NewCoordinatorElectorFactory(testHost, bullyConfig).CoordinatorElector(sessionID, elector.Bully)
+	}
+	return electors
+}
+
+func (s *CoordinatorElectorTestSuite) elect(ctx context.Context, electors []elector.CoordinatorElector, peers peer.IDSlice) []peer.ID {
+	coordinators := make([]peer.ID, len(electors))
+	p := pool.New().WithErrors()
+	for i, e := range electors {
+		p.Go(func() error {
+			coordinator, err := e.Coordinator(ctx, peers)
+			coordinators[i] = coordinator
+			return err
+		})
+	}
+	s.Nil(p.Wait())
+	return coordinators
+}
+
+func (s *CoordinatorElectorTestSuite) TestBullyCoordinatorElector_AllPeersAlive_ElectsStaticCoordinator() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	electors := s.bullyElectors("1")
+	for _, e := range electors {
+		e.Listen(ctx)
+	}
+
+	coordinators := s.elect(ctx, electors, s.testPeers)
+
+	staticCoordinator, _ := elector.NewCoordinatorElector("1").Coordinator(ctx, s.testPeers)
+	for _, coordinator := range coordinators {
+		s.Equal(staticCoordinator, coordinator)
+	}
+}
+
+func (s *CoordinatorElectorTestSuite) TestBullyCoordinatorElector_CoordinatorOffline_ElectsNextPeer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	staticCoordinator, _ := elector.NewCoordinatorElector("1").Coordinator(ctx, s.testPeers)
+	electors := make([]elector.CoordinatorElector, 0)
+	alivePeers := peer.IDSlice{}
+	for i, e := range s.bullyElectors("1") {
+		if s.testPeers[i] == staticCoordinator {
+			continue
+		}
+
+		e.Listen(ctx)
+		electors = append(electors, e)
+		alivePeers = append(alivePeers, s.testPeers[i])
+	}
+
+	coordinators := s.elect(ctx, electors, s.testPeers)
+
+	expectedCoordinator, _ := elector.NewCoordinatorElector("1").Coordinator(ctx, alivePeers)
+	for _, coordinator := range coordinators {
+		s.Equal(expectedCoordinator, coordinator)
+	}
+}
+
+func (s *CoordinatorElectorTestSuite) TestBullyCoordinatorElector_NoSelection_SelectsSelfAfterRestarts() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	conf := bullyConfig
+	conf.BullyWaitTime = 200 * time.Millisecond
+	peers := s.testPeers[:2]
+	staticCoordinator, _ := elector.NewCoordinatorElector("1").Coordinator(ctx, peers)
+	var candidate elector.CoordinatorElector
+	var candidatePeer peer.ID
+	for i, p := range peers {
+		// the higher priority peer only responds to elections without selecting itself
+		e := elector.NewCoordinatorElectorFactory(s.testHosts[i], conf).CoordinatorElector("1", elector.Bully)
+		e.Listen(ctx)
+		if p != staticCoordinator {
+			candidate = e
+			candidatePeer = p
+		}
+	}
+
+	coordinator, err := candidate.Coordinator(ctx, peers)
+
+	s.Nil(err)
+	s.Equal(candidatePeer, coordinator)
+}
+
+func (s *CoordinatorElectorTestSuite) TestBullyCoordinatorElector_Watch_CoordinatorResponsive() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	electors := s.bullyElectors("1")
+	for _, e := range electors {
+		e.Listen(ctx)
+	}
+
+	err := electors[0].Watch(ctx, s.testPeers[1])
+
+	s.Nil(err)
+}
+
+func (s *CoordinatorElectorTestSuite) TestBullyCoordinatorElector_Watch_CoordinatorUnresponsive() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	electors := s.bullyElectors("1")
+	electors[0].Listen(ctx)
+
+	err := electors[0].Watch(ctx, s.testPeers[1])
+
+	s.ErrorIs(err, elector.ErrCoordinatorUnresponsive)
+}
+
+func (s *CoordinatorElectorTestSuite) TestStaticCoordinatorElector_Watch_NeverUnresponsive() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	err := elector.NewCoordinatorElector("1").Watch(ctx, s.testPeers[1])
+
+	s.Nil(err)
+}
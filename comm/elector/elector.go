@@ -5,6 +5,7 @@ package elector
 
 import (
 	"context"
+	"errors"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -18,12 +19,24 @@ type CoordinatorElectorType int
 
 const (
 	Static CoordinatorElectorType = iota
+	Bully
 )
 
 const ProtocolID protocol.ID = "/sygma/coordinator/1.0.0"
 
+var (
+	ErrCoordinatorUnresponsive = errors.New("coordinator unresponsive")
+	ErrCoordinatorNotSelected  = errors.New("coordinator not selected")
+)
+
 type CoordinatorElector interface {
+	// Coordinator returns the coordinator of the session from the provided peers
 	Coordinator(ctx context.Context, peers peer.IDSlice) (peer.ID, error)
+	// Listen responds to election messages of other peers until the context is done
+	Listen(ctx context.Context)
+	// Watch blocks until the context is done and returns ErrCoordinatorUnresponsive
+	// if the coordinator stops responding before that
+	Watch(ctx context.Context, coordinator peer.ID) error
 }
 
 // CoordinatorElectorFactory is used to create multiple instances of CoordinatorElector
@@ -52,6 +65,8 @@ func (c *CoordinatorElectorFactory) CoordinatorElector(
 	switch electorType {
 	case Static:
 		return NewCoordinatorElector(sessionID)
+	case Bully:
+		return NewBullyCoordinatorElector(sessionID, c.h.ID(), c.comm, c.config)
 	default:
 		return nil
 	}
@@ -25,3 +25,12 @@ func (s *staticCoordinatorElector) Coordinator(ctx context.Context, peers peer.I
 	}
 	return util.SortPeersForSession(peers, s.sessionID)[0].ID, nil
 }
+
+// Listen is a noop as static coordinators are calculated without communication
+func (s *staticCoordinatorElector) Listen(ctx context.Context) {}
+
+// Watch never reports the static coordinator as unresponsive
+func (s *staticCoordinatorElector) Watch(ctx context.Context, coordinator peer.ID) error {
+	<-ctx.Done()
+	return nil
+}
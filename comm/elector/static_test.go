@@ -38,13 +38,24 @@ func (s *CoordinatorElectorTestSuite) SetupTest() {
 	s.mockController = gomock.NewController(s.T())
 
 	peers := peer.IDSlice{}
-	// create test hosts
+	s.testHosts = []host.Host{}
+	topology := &topology.NetworkTopology{
+		Peers: []*peer.AddrInfo{},
+	}
+	privateKeys := []crypto.PrivKey{}
 	for i := range numberOfTestHosts {
 		privKeyForHost, _, _ := crypto.GenerateKeyPair(crypto.ECDSA, 1)
-		topology := &topology.NetworkTopology{
-			Peers: []*peer.AddrInfo{},
-		}
-		newHost, _ := p2p.NewHost(privKeyForHost, topology, p2p.NewConnectionGate(topology), 4000+i)
+		privateKeys = append(privateKeys, privKeyForHost)
+		peerID, _ := peer.IDFromPrivateKey(privKeyForHost)
+		addrInfoForHost, _ := peer.AddrInfoFromString(fmt.Sprintf(
+			"/ip4/127.0.0.1/tcp/%d/p2p/%s", 4000+i, peerID.String(),
+		))
+		topology.Peers = append(topology.Peers, addrInfoForHost)
+	}
+
+	// create test hosts
+	for i := range numberOfTestHosts {
+		newHost, _ := p2p.NewHost(privateKeys[i], topology, p2p.NewConnectionGate(topology), 4000+i)
 		s.testHosts = append(s.testHosts, newHost)
 		peers = append(peers, newHost.ID())
 	}
@@ -45,6 +45,14 @@ const (
 	LifiEscrowMsg
 	// LifiUnlockMsg message type is used for the process coordinator to share lifi unlock data
 	LifiUnlockMsg
+	// BatchMsg message type is used for the process coordinator to share batch signing data
+	BatchMsg
+	// SignatureRequestMsg message type is used to request a missing signature from peers
+	SignatureRequestMsg
+	// SignatureResponseMsg message type is used to respond with the requested signature
+	SignatureResponseMsg
+	// PresignMsg message type is used to announce a new presigning session to peers
+	PresignMsg
 	// Unknown message type
 	Unknown
 )
@@ -56,6 +64,9 @@ const (
 	LifiEscrowSessionID     = "lifi-escrow"
 	LighterSessionID        = "lighter"
 	LifiUnlockSessionID     = "lifi-unlock"
+	BatchSessionID          = "batch"
+	LeaveSessionID          = "leave"
+	PresignSessionID        = "presign"
 )
 
 // String implements fmt.Stringer
@@ -97,6 +108,14 @@ func (msgType MessageType) String() string {
 		return "LighterMsg"
 	case SprinterCreditMsg:
 		return "SprinterCreditMsg"
+	case BatchMsg:
+		return "BatchMsg"
+	case SignatureRequestMsg:
+		return "SignatureRequestMsg"
+	case SignatureResponseMsg:
+		return "SignatureResponseMsg"
+	case PresignMsg:
+		return "PresignMsg"
 	default:
 		return "UnknownMsg"
 	}
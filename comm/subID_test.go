@@ -47,7 +47,7 @@ func (s *SubscriptionIDTestSuite) TestSubscriptionID_UnwrapInvalidID_Success() {
 	invalidSubIDs := []SubscriptionID{
 		"not-id",
 		"almost-sub-id",
-		"1-23-1212", // invalid message type
+		"1-99-1212", // invalid message type
 	}
 
 	for _, id := range invalidSubIDs {
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/sprintertech/sprinter-signing/config"
 	"github.com/sprintertech/sprinter-signing/config/relayer"
 	"github.com/stretchr/testify/suite"
@@ -69,6 +70,10 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_ENCRYPTIONKEY", "test-enc-key")
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_URL", "http://test.com")
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_PATH", "path")
+	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_HISTORICALADDRESSES", "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5,0x1c3A03D04c026b1f4B4208D2ce053c5686E6FB8d")
+	_ = os.Setenv("SYG_RELAYER_TLSCONFIG_CERTFILE", "/cfg/tls/api.crt")
+	_ = os.Setenv("SYG_RELAYER_TLSCONFIG_KEYFILE", "/cfg/tls/api.key")
+	_ = os.Setenv("SYG_RELAYER_TLSCONFIG_CLIENTCAFILE", "/cfg/tls/clients.crt")
 	_ = os.Setenv("SYG_RELAYER_SOLVERCONFIG_ACCESSKEY", "solverAccessKey")
 	_ = os.Setenv("SYG_RELAYER_SOLVERCONFIG_SECRETKEY", "solverSecretKey")
 	_ = os.Setenv("SYG_RELAYER_ENV", "TEST")
@@ -89,12 +94,21 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 
 	s.Equal(config.Config{
 		RelayerConfig: relayer.RelayerConfig{
-			LogLevel:   1,
-			LogFile:    "out.log",
-			Env:        "TEST",
-			Id:         "123",
-			HealthPort: 9001,
-			ApiAddr:    "0.0.0.0:3000",
+			LogLevel:           1,
+			LogFile:            "out.log",
+			Env:                "TEST",
+			Id:                 "123",
+			HealthPort:         9001,
+			ApiAddr:            "0.0.0.0:3000",
+			AdminAddr:          "127.0.0.1:9002",
+			DrainTimeout:       time.Minute,
+			SignatureRetention: 720 * time.Hour,
+			AuditLogPath:       "audit.log",
+			TLSConfig: relayer.TLSConfig{
+				CertFile:     "/cfg/tls/api.crt",
+				KeyFile:      "/cfg/tls/api.key",
+				ClientCAFile: "/cfg/tls/clients.crt",
+			},
 			MpcConfig: relayer.MpcRelayerConfig{
 				TopologyConfiguration: relayer.TopologyConfiguration{
 					EncryptionKey: "test-enc-key",
@@ -106,6 +120,10 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 				FrostKeysharePath:       "/cfg/keyshares/0-frost.keyshare",
 				Key:                     "test-pk",
 				CommHealthCheckInterval: 5 * time.Minute,
+				HistoricalAddresses: []common.Address{
+					common.HexToAddress("0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5"),
+					common.HexToAddress("0x1c3A03D04c026b1f4B4208D2ce053c5686E6FB8d"),
+				},
 			},
 			BullyConfig: relayer.BullyConfig{
 				PingWaitTime:     1 * time.Second,
@@ -117,6 +135,17 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 			CoinmarketcapConfig: relayer.CoinmarketcapConfig{
 				Url: "https://pro-api.coinmarketcap.com",
 			},
+			RateLimitConfig: relayer.RateLimitConfig{
+				ClientRate:    5,
+				ClientBurst:   20,
+				ProtocolRate:  20,
+				ProtocolBurst: 50,
+				MaxInFlight:   100,
+			},
+			WebhookConfig: relayer.WebhookConfig{
+				Retries:        5,
+				DeadLetterPath: "webhooks-dead-letter.log",
+			},
 			SolverConfig: relayer.SolverConfig{
 				AccessKey: "solverAccessKey",
 				SecretKey: "solverSecretKey",
@@ -211,12 +240,16 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV_RandomOrder() {
 
 	s.Equal(config.Config{
 		RelayerConfig: relayer.RelayerConfig{
-			LogLevel:   1,
-			LogFile:    "out.log",
-			Env:        "TEST",
-			Id:         "123",
-			HealthPort: 9001,
-			ApiAddr:    "0.0.0.0:3000",
+			LogLevel:           1,
+			LogFile:            "out.log",
+			Env:                "TEST",
+			Id:                 "123",
+			HealthPort:         9001,
+			ApiAddr:            "0.0.0.0:3000",
+			AdminAddr:          "127.0.0.1:9002",
+			DrainTimeout:       time.Minute,
+			SignatureRetention: 720 * time.Hour,
+			AuditLogPath:       "audit.log",
 			MpcConfig: relayer.MpcRelayerConfig{
 				TopologyConfiguration: relayer.TopologyConfiguration{
 					EncryptionKey: "test-enc-key",
@@ -240,6 +273,17 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV_RandomOrder() {
 				Url:    "https://pro-api.coinmarketcap.com",
 				ApiKey: "cmckey",
 			},
+			RateLimitConfig: relayer.RateLimitConfig{
+				ClientRate:    5,
+				ClientBurst:   20,
+				ProtocolRate:  20,
+				ProtocolBurst: 50,
+				MaxInFlight:   100,
+			},
+			WebhookConfig: relayer.WebhookConfig{
+				Retries:        5,
+				DeadLetterPath: "webhooks-dead-letter.log",
+			},
 		},
 		ChainConfigs: []map[string]interface{}{
 			{
@@ -416,6 +460,33 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 			errorMsg:   "unable to parse bully ping wait time: time: unknown unit \"z\" in duration \"2z\"",
 			outConfig:  config.Config{},
 		},
+		{
+			name: "invalid bully process",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					BullyConfig: relayer.RawBullyConfig{
+						Processes: "signing,invalid",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "bully elector not supported for process invalid",
+			outConfig:  config.Config{},
+		},
 		{
 			name: "missing encryption key",
 			inConfig: config.RawConfig{
@@ -437,6 +508,120 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 			errorMsg:   "topology configuration encryption key not provided",
 			outConfig:  config.Config{},
 		},
+		{
+			name: "invalid api credential address",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					ApiCredentials: map[string]relayer.ApiCredential{
+						"solver": {
+							Address: "invalid",
+						},
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "api credential for client solver has invalid address invalid",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "invalid historical mpc address",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port:                "2020",
+						HistoricalAddresses: "0x5C7BCd6E7De5423a257D81B442095A1a6ced35C5, invalid",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "historical mpc address invalid invalid",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "tls key without certificate",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					TLSConfig: relayer.TLSConfig{
+						KeyFile: "api.key",
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "tls certificate and key files must be provided together",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "api credential common name without client ca",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port: "2020",
+					},
+					TLSConfig: relayer.TLSConfig{
+						CertFile: "api.crt",
+						KeyFile:  "api.key",
+					},
+					ApiCredentials: map[string]relayer.ApiCredential{
+						"solver": {
+							CommonName: "solver",
+						},
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "api credential for client solver has common name but no tls client ca configured",
+			outConfig:  config.Config{},
+		},
 		{
 			name: "set default values in config",
 			inConfig: config.RawConfig{
@@ -467,6 +652,10 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 					OpenTelemetryCollectorURL: "",
 					HealthPort:                9001,
 					ApiAddr:                   "0.0.0.0:3000",
+					AdminAddr:                 "127.0.0.1:9002",
+					DrainTimeout:              time.Minute,
+					SignatureRetention:        720 * time.Hour,
+					AuditLogPath:              "audit.log",
 					MpcConfig: relayer.MpcRelayerConfig{
 						Port: 9000,
 						TopologyConfiguration: relayer.TopologyConfiguration{
@@ -486,6 +675,17 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 					CoinmarketcapConfig: relayer.CoinmarketcapConfig{
 						Url: "https://pro-api.coinmarketcap.com",
 					},
+					RateLimitConfig: relayer.RateLimitConfig{
+						ClientRate:    5,
+						ClientBurst:   20,
+						ProtocolRate:  20,
+						ProtocolBurst: 50,
+						MaxInFlight:   100,
+					},
+					WebhookConfig: relayer.WebhookConfig{
+						Retries:        5,
+						DeadLetterPath: "webhooks-dead-letter.log",
+					},
 				},
 				ChainConfigs: []map[string]interface{}{{
 					"id":   float64(1),
@@ -498,10 +698,15 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 			name: "valid config",
 			inConfig: config.RawConfig{
 				RelayerConfig: relayer.RawRelayerConfig{
-					LogLevel:   "debug",
-					LogFile:    "custom.log",
-					HealthPort: "9002",
-					ApiAddr:    "0.0.0.0:3001",
+					LogLevel:             "debug",
+					LogFile:              "custom.log",
+					HealthPort:           "9002",
+					ApiAddr:              "0.0.0.0:3001",
+					AdminAddr:            "127.0.0.1:9003",
+					DrainTimeout:         "30s",
+					SignatureRetention:   "168h",
+					AuditLogPath:         "./audit.log",
+					PresignaturePoolSize: "5",
 					MpcConfig: relayer.RawMpcRelayerConfig{
 						TopologyConfiguration: relayer.TopologyConfiguration{
 							EncryptionKey: "enc-key",
@@ -519,6 +724,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 						PingInterval:     "1s",
 						ElectionWaitTime: "1s",
 						BullyWaitTime:    "1s",
+						Processes:        "signing, keygen",
 					},
 				},
 				ChainConfigs: []map[string]interface{}{{
@@ -536,6 +742,11 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 					OpenTelemetryCollectorURL: "",
 					HealthPort:                9002,
 					ApiAddr:                   "0.0.0.0:3001",
+					AdminAddr:                 "127.0.0.1:9003",
+					DrainTimeout:              30 * time.Second,
+					SignatureRetention:        168 * time.Hour,
+					AuditLogPath:              "./audit.log",
+					PresignaturePoolSize:      5,
 					MpcConfig: relayer.MpcRelayerConfig{
 						Port:         2020,
 						KeysharePath: "./share.key",
@@ -553,10 +764,22 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 						PingInterval:     time.Second,
 						ElectionWaitTime: time.Second,
 						BullyWaitTime:    time.Second,
+						Processes:        []string{"signing", "keygen"},
 					},
 					CoinmarketcapConfig: relayer.CoinmarketcapConfig{
 						Url: "https://pro-api.coinmarketcap.com",
 					},
+					RateLimitConfig: relayer.RateLimitConfig{
+						ClientRate:    5,
+						ClientBurst:   20,
+						ProtocolRate:  20,
+						ProtocolBurst: 50,
+						MaxInFlight:   100,
+					},
+					WebhookConfig: relayer.WebhookConfig{
+						Retries:        5,
+						DeadLetterPath: "webhooks-dead-letter.log",
+					},
 				},
 				ChainConfigs: []map[string]interface{}{{
 					"id":   float64(1),
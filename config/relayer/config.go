@@ -7,12 +7,18 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
 )
 
+// bullyProcesses are tss process types that support the bully coordinator elector
+var bullyProcesses = []string{"keygen", "resharing", "signing"}
+
 type RelayerConfig struct {
 	OpenTelemetryCollectorURL string
 	LogLevel                  zerolog.Level
@@ -25,6 +31,15 @@ type RelayerConfig struct {
 	CoinmarketcapConfig       CoinmarketcapConfig
 	SolverConfig              SolverConfig
 	ApiAddr                   string
+	AdminAddr                 string
+	TLSConfig                 TLSConfig
+	DrainTimeout              time.Duration
+	SignatureRetention        time.Duration
+	AuditLogPath              string
+	PresignaturePoolSize      int
+	ApiCredentials            map[string]ApiCredential
+	RateLimitConfig           RateLimitConfig
+	WebhookConfig             WebhookConfig
 }
 
 type CoinmarketcapConfig struct {
@@ -37,6 +52,52 @@ type SolverConfig struct {
 	AccessKey string
 }
 
+// ApiCredential authenticates a single API client either with a shared HMAC key,
+// with EIP-191 signatures from the registered solver address or with a TLS client
+// certificate issued for the common name. Signature history is only available to
+// clients with a registered solver address and limited to its signatures.
+type ApiCredential struct {
+	HmacKey    string `mapstructure:"HmacKey" json:"hmacKey"`
+	Address    string `mapstructure:"Address" json:"address"`
+	CommonName string `mapstructure:"CommonName" json:"commonName"`
+}
+
+// TLSConfig enables TLS on the signing API. Files are reloaded once they change
+// on disk. Clients with a certificate signed by the client CA are authenticated
+// by the certificate common name without signing requests.
+type TLSConfig struct {
+	CertFile     string `mapstructure:"CertFile" json:"certFile"`
+	KeyFile      string `mapstructure:"KeyFile" json:"keyFile"`
+	ClientCAFile string `mapstructure:"ClientCAFile" json:"clientCAFile"`
+}
+
+// Enabled returns true if the signing API should be served over TLS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// RateLimitConfig limits the request rate per API client and per protocol
+// as well as the number of requests handled at the same time. MaxInFlight counts
+// signing requests until they are signed or failed. Zero values disable the limit.
+type RateLimitConfig struct {
+	ClientRate    float64
+	ClientBurst   int
+	ProtocolRate  float64
+	ProtocolBurst int
+	MaxInFlight   int
+}
+
+// WebhookConfig configures delivery of signing request callbacks. Payloads are
+// signed with the secret so receivers can verify they were sent by the committee.
+// Callbacks are only accepted if the secret is set. Callbacks to private, loopback
+// and link-local addresses are rejected unless private hosts are allowed.
+type WebhookConfig struct {
+	Secret            string
+	Retries           int
+	DeadLetterPath    string
+	AllowPrivateHosts bool
+}
+
 type MpcRelayerConfig struct {
 	TopologyConfiguration   TopologyConfiguration
 	Port                    uint16
@@ -44,6 +105,9 @@ type MpcRelayerConfig struct {
 	FrostKeysharePath       string
 	Key                     string
 	CommHealthCheckInterval time.Duration
+	// HistoricalAddresses are MPC addresses of previous keyshares whose
+	// signatures are still reported as valid by the verification endpoint
+	HistoricalAddresses []common.Address
 }
 
 type BullyConfig struct {
@@ -52,6 +116,9 @@ type BullyConfig struct {
 	PingInterval     time.Duration
 	ElectionWaitTime time.Duration
 	BullyWaitTime    time.Duration
+	// Processes are tss process types that elect their coordinator with
+	// the bully elector instead of the static one
+	Processes []string
 }
 
 type TopologyConfiguration struct {
@@ -61,17 +128,26 @@ type TopologyConfiguration struct {
 }
 
 type RawRelayerConfig struct {
-	OpenTelemetryCollectorURL string              `mapstructure:"OpenTelemetryCollectorURL" json:"opentelemetryCollectorURL"`
-	LogLevel                  string              `mapstructure:"LogLevel" json:"logLevel" default:"info"`
-	LogFile                   string              `mapstructure:"LogFile" json:"logFile" default:"out.log"`
-	HealthPort                string              `mapstructure:"HealthPort" json:"healthPort" default:"9001"`
-	Env                       string              `mapstructure:"Env" json:"env"`
-	Id                        string              `mapstructure:"Id" json:"id"`
-	MpcConfig                 RawMpcRelayerConfig `mapstructure:"MpcConfig" json:"mpcConfig"`
-	BullyConfig               RawBullyConfig      `mapstructure:"BullyConfig" json:"bullyConfig"`
-	CoinmarketcapConfig       CoinmarketcapConfig `mapstructure:"CoinmarketcapConfig" json:"coinmarketcapConfig"`
-	SolverConfig              SolverConfig        `mapstructure:"SolverConfig" json:"solverConfig"`
-	ApiAddr                   string              `mapstructure:"apiAddr" default:"0.0.0.0:3000"`
+	OpenTelemetryCollectorURL string                   `mapstructure:"OpenTelemetryCollectorURL" json:"opentelemetryCollectorURL"`
+	LogLevel                  string                   `mapstructure:"LogLevel" json:"logLevel" default:"info"`
+	LogFile                   string                   `mapstructure:"LogFile" json:"logFile" default:"out.log"`
+	HealthPort                string                   `mapstructure:"HealthPort" json:"healthPort" default:"9001"`
+	Env                       string                   `mapstructure:"Env" json:"env"`
+	Id                        string                   `mapstructure:"Id" json:"id"`
+	MpcConfig                 RawMpcRelayerConfig      `mapstructure:"MpcConfig" json:"mpcConfig"`
+	BullyConfig               RawBullyConfig           `mapstructure:"BullyConfig" json:"bullyConfig"`
+	CoinmarketcapConfig       CoinmarketcapConfig      `mapstructure:"CoinmarketcapConfig" json:"coinmarketcapConfig"`
+	SolverConfig              SolverConfig             `mapstructure:"SolverConfig" json:"solverConfig"`
+	ApiAddr                   string                   `mapstructure:"apiAddr" default:"0.0.0.0:3000"`
+	AdminAddr                 string                   `mapstructure:"AdminAddr" json:"adminAddr" default:"127.0.0.1:9002"`
+	TLSConfig                 TLSConfig                `mapstructure:"TLSConfig" json:"tlsConfig"`
+	DrainTimeout              string                   `mapstructure:"DrainTimeout" json:"drainTimeout" default:"1m"`
+	SignatureRetention        string                   `mapstructure:"SignatureRetention" json:"signatureRetention" default:"720h"`
+	AuditLogPath              string                   `mapstructure:"AuditLogPath" json:"auditLogPath" default:"audit.log"`
+	PresignaturePoolSize      string                   `mapstructure:"PresignaturePoolSize" json:"presignaturePoolSize" default:"0"`
+	ApiCredentials            map[string]ApiCredential `mapstructure:"ApiCredentials" json:"apiCredentials"`
+	RateLimitConfig           RawRateLimitConfig       `mapstructure:"RateLimitConfig" json:"rateLimitConfig"`
+	WebhookConfig             RawWebhookConfig         `mapstructure:"WebhookConfig" json:"webhookConfig"`
 }
 
 type RawMpcRelayerConfig struct {
@@ -81,6 +157,7 @@ type RawMpcRelayerConfig struct {
 	Port                    string                `mapstructure:"Port" json:"port" default:"9000"`
 	TopologyConfiguration   TopologyConfiguration `mapstructure:"TopologyConfiguration" json:"topologyConfiguration"`
 	CommHealthCheckInterval string                `mapstructure:"CommHealthCheckInterval" json:"commHealthCheckInterval" default:"5m"`
+	HistoricalAddresses     string                `mapstructure:"HistoricalAddresses" json:"historicalAddresses"`
 }
 
 type RawBullyConfig struct {
@@ -89,6 +166,22 @@ type RawBullyConfig struct {
 	PingInterval     string `mapstructure:"PingInterval" json:"pingInterval" default:"1s"`
 	ElectionWaitTime string `mapstructure:"ElectionWaitTime" json:"electionWaitTime" default:"2s"`
 	BullyWaitTime    string `mapstructure:"BullyWaitTime" json:"bullyWaitTime" default:"3m"`
+	Processes        string `mapstructure:"Processes" json:"processes"`
+}
+
+type RawRateLimitConfig struct {
+	ClientRate    string `mapstructure:"ClientRate" json:"clientRate" default:"5"`
+	ClientBurst   string `mapstructure:"ClientBurst" json:"clientBurst" default:"20"`
+	ProtocolRate  string `mapstructure:"ProtocolRate" json:"protocolRate" default:"20"`
+	ProtocolBurst string `mapstructure:"ProtocolBurst" json:"protocolBurst" default:"50"`
+	MaxInFlight   string `mapstructure:"MaxInFlight" json:"maxInFlight" default:"100"`
+}
+
+type RawWebhookConfig struct {
+	Secret            string `mapstructure:"Secret" json:"secret"`
+	Retries           string `mapstructure:"Retries" json:"retries" default:"5"`
+	DeadLetterPath    string `mapstructure:"DeadLetterPath" json:"deadLetterPath" default:"webhooks-dead-letter.log"`
+	AllowPrivateHosts string `mapstructure:"AllowPrivateHosts" json:"allowPrivateHosts" default:"false"`
 }
 
 func (c *RawRelayerConfig) Validate() error {
@@ -101,6 +194,38 @@ func (c *RawRelayerConfig) Validate() error {
 	if c.MpcConfig.TopologyConfiguration.Path == "" {
 		return errors.New("topology configuration path not provided")
 	}
+	for _, address := range splitList(c.MpcConfig.HistoricalAddresses) {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("historical mpc address %s invalid", address)
+		}
+	}
+	for _, process := range splitList(c.BullyConfig.Processes) {
+		if !slices.Contains(bullyProcesses, process) {
+			return fmt.Errorf("bully elector not supported for process %s", process)
+		}
+	}
+	if (c.TLSConfig.CertFile == "") != (c.TLSConfig.KeyFile == "") {
+		return errors.New("tls certificate and key files must be provided together")
+	}
+	if c.TLSConfig.ClientCAFile != "" && c.TLSConfig.CertFile == "" {
+		return errors.New("tls client ca requires tls certificate")
+	}
+	commonNames := make(map[string]string)
+	for client, credential := range c.ApiCredentials {
+		if credential.HmacKey == "" && credential.Address == "" && credential.CommonName == "" {
+			return fmt.Errorf("api credential for client %s missing hmac key, address or common name", client)
+		}
+		if credential.CommonName != "" && c.TLSConfig.ClientCAFile == "" {
+			return fmt.Errorf("api credential for client %s has common name but no tls client ca configured", client)
+		}
+		if other, ok := commonNames[credential.CommonName]; ok && credential.CommonName != "" {
+			return fmt.Errorf("api credentials for clients %s and %s have the same common name", other, client)
+		}
+		commonNames[credential.CommonName] = client
+		if credential.Address != "" && !common.IsHexAddress(credential.Address) {
+			return fmt.Errorf("api credential for client %s has invalid address %s", client, credential.Address)
+		}
+	}
 	return nil
 }
 
@@ -130,6 +255,25 @@ func NewRelayerConfig(rawConfig RawRelayerConfig) (RelayerConfig, error) {
 	}
 	config.HealthPort = uint16(healthPort)
 
+	drainTimeout, err := time.ParseDuration(rawConfig.DrainTimeout)
+	if err != nil {
+		return RelayerConfig{}, fmt.Errorf("unable to parse drain timeout: %w", err)
+	}
+	config.DrainTimeout = drainTimeout
+
+	signatureRetention, err := time.ParseDuration(rawConfig.SignatureRetention)
+	if err != nil {
+		return RelayerConfig{}, fmt.Errorf("unable to parse signature retention: %w", err)
+	}
+	config.SignatureRetention = signatureRetention
+	config.AuditLogPath = rawConfig.AuditLogPath
+
+	presignaturePoolSize, err := strconv.Atoi(rawConfig.PresignaturePoolSize)
+	if err != nil {
+		return RelayerConfig{}, fmt.Errorf("unable to parse presignature pool size: %w", err)
+	}
+	config.PresignaturePoolSize = presignaturePoolSize
+
 	mpcConfig, err := parseMpcConfig(rawConfig)
 	if err != nil {
 		return RelayerConfig{}, err
@@ -141,12 +285,36 @@ func NewRelayerConfig(rawConfig RawRelayerConfig) (RelayerConfig, error) {
 		return RelayerConfig{}, err
 	}
 
+	rateLimitConfig, err := parseRateLimitConfig(rawConfig)
+	if err != nil {
+		return RelayerConfig{}, err
+	}
+	config.RateLimitConfig = rateLimitConfig
+
+	webhookRetries, err := strconv.Atoi(rawConfig.WebhookConfig.Retries)
+	if err != nil {
+		return RelayerConfig{}, fmt.Errorf("unable to parse webhook retries: %w", err)
+	}
+	allowPrivateHosts, err := strconv.ParseBool(rawConfig.WebhookConfig.AllowPrivateHosts)
+	if err != nil {
+		return RelayerConfig{}, fmt.Errorf("unable to parse webhook allow private hosts: %w", err)
+	}
+	config.WebhookConfig = WebhookConfig{
+		Secret:            rawConfig.WebhookConfig.Secret,
+		Retries:           webhookRetries,
+		DeadLetterPath:    rawConfig.WebhookConfig.DeadLetterPath,
+		AllowPrivateHosts: allowPrivateHosts,
+	}
+
 	config.CoinmarketcapConfig = rawConfig.CoinmarketcapConfig
 	config.BullyConfig = bullyConfig
 	config.Env = rawConfig.Env
 	config.Id = rawConfig.Id
 	config.ApiAddr = rawConfig.ApiAddr
+	config.AdminAddr = rawConfig.AdminAddr
+	config.TLSConfig = rawConfig.TLSConfig
 	config.SolverConfig = rawConfig.SolverConfig
+	config.ApiCredentials = rawConfig.ApiCredentials
 	return config, nil
 }
 
@@ -173,9 +341,25 @@ func parseMpcConfig(rawConfig RawRelayerConfig) (MpcRelayerConfig, error) {
 	}
 	mpcConfig.CommHealthCheckInterval = duration
 
+	for _, address := range splitList(rawConfig.MpcConfig.HistoricalAddresses) {
+		mpcConfig.HistoricalAddresses = append(mpcConfig.HistoricalAddresses, common.HexToAddress(address))
+	}
+
 	return mpcConfig, nil
 }
 
+// splitList splits the comma separated list of config values
+func splitList(raw string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func parseBullyConfig(rawConfig RawRelayerConfig) (BullyConfig, error) {
 	electionWaitTime, err := time.ParseDuration(rawConfig.BullyConfig.ElectionWaitTime)
 	if err != nil {
@@ -202,11 +386,50 @@ func parseBullyConfig(rawConfig RawRelayerConfig) (BullyConfig, error) {
 		return BullyConfig{}, fmt.Errorf("unable to parse bully wait time: %w", err)
 	}
 
-	return BullyConfig{
+	bullyConfig := BullyConfig{
 		PingWaitTime:     pingWaitTime,
 		PingBackOff:      pingBackOff,
 		PingInterval:     pingInterval,
 		ElectionWaitTime: electionWaitTime,
 		BullyWaitTime:    bullyWaitTime,
+	}
+	for _, process := range splitList(rawConfig.BullyConfig.Processes) {
+		bullyConfig.Processes = append(bullyConfig.Processes, process)
+	}
+	return bullyConfig, nil
+}
+
+func parseRateLimitConfig(rawConfig RawRelayerConfig) (RateLimitConfig, error) {
+	clientRate, err := strconv.ParseFloat(rawConfig.RateLimitConfig.ClientRate, 64)
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("unable to parse client rate limit: %w", err)
+	}
+
+	clientBurst, err := strconv.Atoi(rawConfig.RateLimitConfig.ClientBurst)
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("unable to parse client burst limit: %w", err)
+	}
+
+	protocolRate, err := strconv.ParseFloat(rawConfig.RateLimitConfig.ProtocolRate, 64)
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("unable to parse protocol rate limit: %w", err)
+	}
+
+	protocolBurst, err := strconv.Atoi(rawConfig.RateLimitConfig.ProtocolBurst)
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("unable to parse protocol burst limit: %w", err)
+	}
+
+	maxInFlight, err := strconv.Atoi(rawConfig.RateLimitConfig.MaxInFlight)
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("unable to parse max in-flight requests: %w", err)
+	}
+
+	return RateLimitConfig{
+		ClientRate:    clientRate,
+		ClientBurst:   clientBurst,
+		ProtocolRate:  protocolRate,
+		ProtocolBurst: protocolBurst,
+		MaxInFlight:   maxInFlight,
 	}, nil
 }
@@ -1,9 +1,8 @@
 package config
 
 import (
-	"fmt"
-
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/sprintertech/sprinter-signing/errcode"
 )
 
 type TokenConfig struct {
@@ -18,7 +17,8 @@ type TokenStore struct {
 func (s *TokenStore) ConfigByAddress(chainID uint64, address common.Address) (string, TokenConfig, error) {
 	tokens, ok := s.Tokens[chainID]
 	if !ok {
-		return "", TokenConfig{}, fmt.Errorf("no tokens for chain %d", chainID)
+		return "", TokenConfig{}, errcode.New(errcode.TokenNotConfigured, "no tokens for chain %d", chainID).
+			WithDetail("chainId", chainID)
 	}
 
 	for symbol, c := range tokens {
@@ -27,18 +27,23 @@ func (s *TokenStore) ConfigByAddress(chainID uint64, address common.Address) (st
 		}
 	}
 
-	return "", TokenConfig{}, fmt.Errorf("no symbol for address %s", address.Hex())
+	return "", TokenConfig{}, errcode.New(errcode.TokenNotConfigured, "no symbol for address %s", address.Hex()).
+		WithDetail("chainId", chainID).
+		WithDetail("token", address.Hex())
 }
 
 func (s *TokenStore) ConfigBySymbol(chainID uint64, symbol string) (TokenConfig, error) {
 	tokens, ok := s.Tokens[chainID]
 	if !ok {
-		return TokenConfig{}, fmt.Errorf("no tokens for chain %d", chainID)
+		return TokenConfig{}, errcode.New(errcode.TokenNotConfigured, "no tokens for chain %d", chainID).
+			WithDetail("chainId", chainID)
 	}
 
 	c, ok := tokens[symbol]
 	if !ok {
-		return TokenConfig{}, fmt.Errorf("no config for token %s", symbol)
+		return TokenConfig{}, errcode.New(errcode.TokenNotConfigured, "no config for token %s", symbol).
+			WithDetail("chainId", chainID).
+			WithDetail("symbol", symbol)
 	}
 
 	return c, nil
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -16,13 +17,33 @@ const (
 	ConfigWatcherInterval = time.Minute * 1
 )
 
+// ConfigWatcher notifies about solver config changes and tracks
+// when the solver config was last fetched successfully
+type ConfigWatcher struct {
+	changed   chan struct{}
+	lastFetch atomic.Int64
+}
+
+// Changed returns the channel notified once the config has changed
+func (w *ConfigWatcher) Changed() <-chan struct{} {
+	return w.changed
+}
+
+// LastFetched returns the time of the last successful config fetch
+func (w *ConfigWatcher) LastFetched() time.Time {
+	return time.Unix(0, w.lastFetch.Load())
+}
+
 // StartConfigWatcher starts a goroutine that periodically checks for config changes.
 // Panics to induce a restart if the config has changed
-func StartConfigWatcher(ctx context.Context, config *solverConfig.SolverConfig, opts []solverConfig.Option) (<-chan struct{}, error) {
-	configChanged := make(chan struct{}, 1)
+func StartConfigWatcher(ctx context.Context, config *solverConfig.SolverConfig, opts []solverConfig.Option) (*ConfigWatcher, error) {
+	watcher := &ConfigWatcher{
+		changed: make(chan struct{}, 1),
+	}
+	watcher.lastFetch.Store(time.Now().UnixNano())
 	configHash, err := calculateConfigHash(config)
 	if err != nil {
-		return configChanged, err
+		return watcher, err
 	}
 
 	go func() {
@@ -37,9 +58,10 @@ func StartConfigWatcher(ctx context.Context, config *solverConfig.SolverConfig,
 					log.Warn().Msgf("Failed checking has config changed: %s", err)
 					continue
 				}
+				watcher.lastFetch.Store(time.Now().UnixNano())
 
 				if hasChanged {
-					configChanged <- struct{}{}
+					watcher.changed <- struct{}{}
 				}
 			case <-ctx.Done():
 				return
@@ -47,7 +69,7 @@ func StartConfigWatcher(ctx context.Context, config *solverConfig.SolverConfig,
 		}
 	}()
 
-	return configChanged, nil
+	return watcher, nil
 }
 
 // hasConfigChanged fetches config and returns true if it has changed
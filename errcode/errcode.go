@@ -0,0 +1,136 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Code is a stable machine-readable identifier of a signing API error
+type Code string
+
+const (
+	InvalidRequest            Code = "INVALID_REQUEST"
+	Unauthorized              Code = "UNAUTHORIZED"
+	RateLimited               Code = "RATE_LIMITED"
+	NotFound                  Code = "NOT_FOUND"
+	RequestConflict           Code = "REQUEST_CONFLICT"
+	ChainNotSupported         Code = "CHAIN_NOT_SUPPORTED"
+	ProtocolNotSupported      Code = "PROTOCOL_NOT_SUPPORTED"
+	DepositNotFound           Code = "DEPOSIT_NOT_FOUND"
+	InsufficientConfirmations Code = "INSUFFICIENT_CONFIRMATIONS"
+	TokenNotConfigured        Code = "TOKEN_NOT_CONFIGURED"
+	PolicyViolation           Code = "POLICY_VIOLATION"
+	AmountExceeded            Code = "AMOUNT_EXCEEDED"
+	VerificationFailed        Code = "VERIFICATION_FAILED"
+	SigningFailed             Code = "SIGNING_FAILED"
+	Timeout                   Code = "TIMEOUT"
+	Unavailable               Code = "UNAVAILABLE"
+	Internal                  Code = "INTERNAL"
+)
+
+// FieldError describes a single invalid request field
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (e FieldError) String() string {
+	if e.Reason == "missing" {
+		return fmt.Sprintf("missing field '%s'", e.Field)
+	}
+	return fmt.Sprintf("field '%s' %s", e.Field, e.Reason)
+}
+
+// Error is an error with a stable code and structured details that are
+// returned to API clients alongside the error message
+type Error struct {
+	Code    Code
+	Message string
+	Details map[string]any
+	Fields  []FieldError
+
+	err error
+}
+
+// New creates an error with the code and formatted message
+func New(code Code, format string, args ...any) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Wrap assigns the code to the error. Errors that already have a code keep it.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Error{}
+	if errors.As(err, &e) {
+		return err
+	}
+	return &Error{
+		Code:    code,
+		Message: err.Error(),
+		err:     err,
+	}
+}
+
+// Fields creates an invalid request error listing all invalid fields
+func Fields(fields []FieldError) *Error {
+	reasons := make([]string, len(fields))
+	for i, field := range fields {
+		reasons[i] = field.String()
+	}
+	return &Error{
+		Code:    InvalidRequest,
+		Message: strings.Join(reasons, ", "),
+		Fields:  fields,
+	}
+}
+
+// WithDetail adds a structured detail to the error
+func (e *Error) WithDetail(key string, value any) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]any)
+	}
+	e.Details[key] = value
+	return e
+}
+
+// WithCause sets the error wrapped by this error so it can be matched with errors.Is
+func (e *Error) WithCause(err error) *Error {
+	e.err = err
+	return e
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// CodeOf returns the code of the error or the fallback code if the error has no code
+func CodeOf(err error, fallback Code) Code {
+	e := &Error{}
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return fallback
+}
+
+// As returns the coded error in the error chain or nil if there is none
+func As(err error) *Error {
+	e := &Error{}
+	if errors.As(err, &e) {
+		return e
+	}
+	return nil
+}
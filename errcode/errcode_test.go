@@ -0,0 +1,65 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package errcode_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sprintertech/sprinter-signing/errcode"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrcodeTestSuite struct {
+	suite.Suite
+}
+
+func TestRunErrcodeTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrcodeTestSuite))
+}
+
+func (s *ErrcodeTestSuite) Test_CodeOf_WrappedError() {
+	err := fmt.Errorf("failed verifying deposit: %w", errcode.New(errcode.DepositNotFound, "deposit %d not found", 1))
+
+	s.Equal(errcode.DepositNotFound, errcode.CodeOf(err, errcode.Internal))
+	s.Equal("failed verifying deposit: deposit 1 not found", err.Error())
+}
+
+func (s *ErrcodeTestSuite) Test_CodeOf_Fallback() {
+	s.Equal(errcode.Internal, errcode.CodeOf(fmt.Errorf("error"), errcode.Internal))
+}
+
+func (s *ErrcodeTestSuite) Test_Wrap_KeepsExistingCode() {
+	err := errcode.Wrap(errcode.SigningFailed, fmt.Errorf("verification failed: %w", errcode.New(errcode.PolicyViolation, "invalid")))
+
+	s.Equal(errcode.PolicyViolation, errcode.CodeOf(err, errcode.Internal))
+}
+
+func (s *ErrcodeTestSuite) Test_Wrap_UncodedError() {
+	cause := fmt.Errorf("tss error")
+	err := errcode.Wrap(errcode.SigningFailed, cause)
+
+	s.Equal(errcode.SigningFailed, errcode.CodeOf(err, errcode.Internal))
+	s.True(errors.Is(err, cause))
+	s.Nil(errcode.Wrap(errcode.SigningFailed, nil))
+}
+
+func (s *ErrcodeTestSuite) Test_Fields() {
+	err := errcode.Fields([]errcode.FieldError{
+		{Field: "caller", Reason: "missing"},
+		{Field: "depositTxHash", Reason: "invalid"},
+	})
+
+	s.Equal(errcode.InvalidRequest, err.Code)
+	s.Equal("missing field 'caller', field 'depositTxHash' invalid", err.Error())
+}
+
+func (s *ErrcodeTestSuite) Test_WithCause() {
+	cause := fmt.Errorf("params conflict")
+	err := errcode.New(errcode.RequestConflict, "conflict").WithCause(cause).WithDetail("id", "1-1")
+
+	s.True(errors.Is(err, cause))
+	s.Equal(map[string]any{"id": "1-1"}, errcode.As(err).Details)
+}
@@ -0,0 +1,129 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/sprintertech/sprinter-signing/topology"
+)
+
+const (
+	// MAX_HEAD_AGE_BLOCKS is the number of block times after which the
+	// latest block of a chain RPC is considered stale
+	MAX_HEAD_AGE_BLOCKS = 20
+	MIN_HEAD_AGE        = time.Minute
+)
+
+type KeyshareFetcher interface {
+	GetKeyshare() (keyshare.ECDSAKeyshare, error)
+}
+
+type TopologyFetcher interface {
+	Topology() (*topology.NetworkTopology, error)
+}
+
+type PeerNetwork interface {
+	Connectedness(peer.ID) network.Connectedness
+}
+
+type HeaderFetcher interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type ConfigWatcher interface {
+	LastFetched() time.Time
+}
+
+type Drainer interface {
+	Draining() bool
+}
+
+// CheckerFunc converts a function into a Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// DrainCheck fails once the relayer started draining so no new requests are routed to it
+func DrainCheck(drainer Drainer) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		if drainer.Draining() {
+			return fmt.Errorf("relayer is draining")
+		}
+		return nil
+	})
+}
+
+// KeyshareCheck fails if the relayer has no keyshare and is not part of the MPC committee
+func KeyshareCheck(keyshares KeyshareFetcher) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		_, err := keyshares.GetKeyshare()
+		return err
+	})
+}
+
+// PeersCheck fails if the relayer is connected to less peers than
+// required to reach the signing threshold
+func PeersCheck(self peer.ID, topologies TopologyFetcher, peers PeerNetwork) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		t, err := topologies.Topology()
+		if err != nil {
+			return err
+		}
+
+		reachable := 1
+		for _, p := range t.Peers {
+			if p.ID == self {
+				continue
+			}
+			if peers.Connectedness(p.ID) == network.Connected {
+				reachable++
+			}
+		}
+
+		if reachable < t.Threshold+1 {
+			return fmt.Errorf("%d peers reachable, %d required", reachable, t.Threshold+1)
+		}
+		return nil
+	})
+}
+
+// ChainHeadCheck fails if the latest block of the chain RPC is older than
+// MAX_HEAD_AGE_BLOCKS block times
+func ChainHeadCheck(client HeaderFetcher, blocktime time.Duration) Checker {
+	maxAge := max(blocktime*MAX_HEAD_AGE_BLOCKS, MIN_HEAD_AGE)
+	return CheckerFunc(func(ctx context.Context) error {
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		// nolint:gosec
+		age := time.Since(time.Unix(int64(head.Time), 0))
+		if age > maxAge {
+			return fmt.Errorf("latest block %s is %s old", head.Number, age.Round(time.Second))
+		}
+		return nil
+	})
+}
+
+// SolverConfigCheck fails if the solver config was not fetched successfully within the max age
+func SolverConfigCheck(watcher ConfigWatcher, maxAge time.Duration) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		age := time.Since(watcher.LastFetched())
+		if age > maxAge {
+			return fmt.Errorf("solver config last fetched %s ago", age.Round(time.Second))
+		}
+		return nil
+	})
+}
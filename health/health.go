@@ -4,12 +4,144 @@
 package health
 
 import (
+	"context"
+	"encoding/json"
+	"maps"
 	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	CHECK_TIMEOUT = time.Second * 5
+)
+
+type Status string
+
+const (
+	StatusOK     Status = "ok"
+	StatusFailed Status = "failed"
 )
 
+// Checker verifies the health of a single relayer component
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+type ComponentStatus struct {
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type Report struct {
+	Status     Status                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
 // HealthHandler returns a handler function for the /health endpoint
 func HealthHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("ok"))
 	}
 }
+
+// Health aggregates component checks into liveness and readiness reports.
+// Liveness checks should only fail if the relayer needs to be restarted, while
+// readiness checks fail if the relayer is unable to sign requests.
+type Health struct {
+	lock      sync.Mutex
+	liveness  map[string]Checker
+	readiness map[string]Checker
+}
+
+func NewHealth() *Health {
+	return &Health{
+		liveness:  make(map[string]Checker),
+		readiness: make(map[string]Checker),
+	}
+}
+
+// AddLivenessCheck registers a check that is part of both liveness and readiness reports
+func (h *Health) AddLivenessCheck(name string, checker Checker) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.liveness[name] = checker
+	h.readiness[name] = checker
+}
+
+// AddReadinessCheck registers a check that is only part of the readiness report
+func (h *Health) AddReadinessCheck(name string, checker Checker) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.readiness[name] = checker
+}
+
+// Router returns the handler serving the /livez, /readyz and /health endpoints
+func (h *Health) Router() http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/livez", h.handler(func() map[string]Checker { return h.liveness })).Methods("GET")
+	r.HandleFunc("/readyz", h.handler(func() map[string]Checker { return h.readiness })).Methods("GET")
+	r.HandleFunc("/health", HealthHandler()).Methods("GET")
+	return r
+}
+
+func (h *Health) handler(checkers func() map[string]Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.lock.Lock()
+		checks := maps.Clone(checkers())
+		h.lock.Unlock()
+
+		report := check(r.Context(), checks)
+
+		data, _ := json.Marshal(report)
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status == StatusOK {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write(data)
+	}
+}
+
+// check runs all checks concurrently and fails the report if any of them fail
+func check(ctx context.Context, checkers map[string]Checker) Report {
+	ctx, cancel := context.WithTimeout(ctx, CHECK_TIMEOUT)
+	defer cancel()
+
+	report := Report{
+		Status:     StatusOK,
+		Components: make(map[string]ComponentStatus, len(checkers)),
+	}
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			status := ComponentStatus{Status: StatusOK}
+			err := checker.Check(ctx)
+			if err != nil {
+				status = ComponentStatus{
+					Status:  StatusFailed,
+					Message: err.Error(),
+				}
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			report.Components[name] = status
+			if err != nil {
+				report.Status = StatusFailed
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
@@ -0,0 +1,183 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/health"
+	mock_health "github.com/sprintertech/sprinter-signing/health/mock"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	"github.com/sprintertech/sprinter-signing/topology"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+
+	health *health.Health
+}
+
+func TestRunHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	s.health = health.NewHealth()
+	s.health.AddLivenessCheck("live", health.CheckerFunc(func(ctx context.Context) error {
+		return nil
+	}))
+	s.health.AddReadinessCheck("ready", health.CheckerFunc(func(ctx context.Context) error {
+		return fmt.Errorf("not ready")
+	}))
+}
+
+func (s *HealthTestSuite) report(path string) (int, health.Report) {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	recorder := httptest.NewRecorder()
+
+	s.health.Router().ServeHTTP(recorder, req)
+
+	report := health.Report{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &report)
+	s.Nil(err)
+	return recorder.Code, report
+}
+
+func (s *HealthTestSuite) Test_Livez_OnlyLivenessChecks() {
+	code, report := s.report("/livez")
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(health.Report{
+		Status: health.StatusOK,
+		Components: map[string]health.ComponentStatus{
+			"live": {Status: health.StatusOK},
+		},
+	}, report)
+}
+
+func (s *HealthTestSuite) Test_Readyz_FailedCheck() {
+	code, report := s.report("/readyz")
+
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(health.Report{
+		Status: health.StatusFailed,
+		Components: map[string]health.ComponentStatus{
+			"live":  {Status: health.StatusOK},
+			"ready": {Status: health.StatusFailed, Message: "not ready"},
+		},
+	}, report)
+}
+
+type ChecksTestSuite struct {
+	suite.Suite
+
+	mockKeyshares *mock_health.MockKeyshareFetcher
+	mockTopology  *mock_health.MockTopologyFetcher
+	mockNetwork   *mock_health.MockPeerNetwork
+	mockHeaders   *mock_health.MockHeaderFetcher
+	mockConfig    *mock_health.MockConfigWatcher
+	mockDrainer   *mock_health.MockDrainer
+}
+
+func TestRunChecksTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksTestSuite))
+}
+
+func (s *ChecksTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockKeyshares = mock_health.NewMockKeyshareFetcher(ctrl)
+	s.mockTopology = mock_health.NewMockTopologyFetcher(ctrl)
+	s.mockNetwork = mock_health.NewMockPeerNetwork(ctrl)
+	s.mockHeaders = mock_health.NewMockHeaderFetcher(ctrl)
+	s.mockConfig = mock_health.NewMockConfigWatcher(ctrl)
+	s.mockDrainer = mock_health.NewMockDrainer(ctrl)
+}
+
+func (s *ChecksTestSuite) Test_KeyshareCheck_MissingKeyshare() {
+	s.mockKeyshares.EXPECT().GetKeyshare().Return(keyshare.ECDSAKeyshare{}, fmt.Errorf("error on reading keyshare file"))
+
+	err := health.KeyshareCheck(s.mockKeyshares).Check(context.Background())
+
+	s.NotNil(err)
+}
+
+func (s *ChecksTestSuite) Test_PeersCheck() {
+	self, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	peer2, _ := peer.Decode("QmYayosTHxL2xa4jyrQ2PmbhGbrkSxsGM1kzXLTT8SsLVy")
+	s.mockTopology.EXPECT().Topology().Return(&topology.NetworkTopology{
+		Peers: []*peer.AddrInfo{
+			{ID: self},
+			{ID: peer1},
+			{ID: peer2},
+		},
+		Threshold: 2,
+	}, nil).Times(2)
+	s.mockNetwork.EXPECT().Connectedness(peer1).Return(network.Connected).Times(2)
+	s.mockNetwork.EXPECT().Connectedness(peer2).Return(network.NotConnected)
+	check := health.PeersCheck(self, s.mockTopology, s.mockNetwork)
+
+	err := check.Check(context.Background())
+	s.NotNil(err)
+	s.Equal("2 peers reachable, 3 required", err.Error())
+
+	s.mockNetwork.EXPECT().Connectedness(peer2).Return(network.Connected)
+	err = check.Check(context.Background())
+	s.Nil(err)
+}
+
+func (s *ChecksTestSuite) Test_ChainHeadCheck_StaleHead() {
+	s.mockHeaders.EXPECT().HeaderByNumber(gomock.Any(), nil).Return(&types.Header{
+		Number: big.NewInt(100),
+		// nolint:gosec
+		Time: uint64(time.Now().Add(-time.Minute * 2).Unix()),
+	}, nil)
+
+	err := health.ChainHeadCheck(s.mockHeaders, time.Second).Check(context.Background())
+
+	s.NotNil(err)
+	s.Contains(err.Error(), "latest block 100 is 2m")
+}
+
+func (s *ChecksTestSuite) Test_ChainHeadCheck_FreshHead() {
+	s.mockHeaders.EXPECT().HeaderByNumber(gomock.Any(), nil).Return(&types.Header{
+		Number: big.NewInt(100),
+		// nolint:gosec
+		Time: uint64(time.Now().Add(-time.Second * 30).Unix()),
+	}, nil)
+
+	err := health.ChainHeadCheck(s.mockHeaders, time.Second).Check(context.Background())
+
+	s.Nil(err)
+}
+
+func (s *ChecksTestSuite) Test_SolverConfigCheck_StaleConfig() {
+	s.mockConfig.EXPECT().LastFetched().Return(time.Now().Add(-time.Hour))
+
+	err := health.SolverConfigCheck(s.mockConfig, time.Minute).Check(context.Background())
+
+	s.NotNil(err)
+}
+
+func (s *ChecksTestSuite) Test_DrainCheck() {
+	check := health.DrainCheck(s.mockDrainer)
+
+	s.mockDrainer.EXPECT().Draining().Return(false)
+	s.Nil(check.Check(context.Background()))
+
+	s.mockDrainer.EXPECT().Draining().Return(true)
+	s.NotNil(check.Check(context.Background()))
+}
@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health/checks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health/checks.go -destination=health/mock/checks.go
+//
+
+// Package mock_health is a generated GoMock package.
+package mock_health
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+	time "time"
+
+	types "github.com/ethereum/go-ethereum/core/types"
+	network "github.com/libp2p/go-libp2p/core/network"
+	peer "github.com/libp2p/go-libp2p/core/peer"
+	keyshare "github.com/sprintertech/sprinter-signing/keyshare"
+	topology "github.com/sprintertech/sprinter-signing/topology"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeyshareFetcher is a mock of KeyshareFetcher interface.
+type MockKeyshareFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyshareFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyshareFetcherMockRecorder is the mock recorder for MockKeyshareFetcher.
+type MockKeyshareFetcherMockRecorder struct {
+	mock *MockKeyshareFetcher
+}
+
+// NewMockKeyshareFetcher creates a new mock instance.
+func NewMockKeyshareFetcher(ctrl *gomock.Controller) *MockKeyshareFetcher {
+	mock := &MockKeyshareFetcher{ctrl: ctrl}
+	mock.recorder = &MockKeyshareFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyshareFetcher) EXPECT() *MockKeyshareFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetKeyshare mocks base method.
+func (m *MockKeyshareFetcher) GetKeyshare() (keyshare.ECDSAKeyshare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyshare")
+	ret0, _ := ret[0].(keyshare.ECDSAKeyshare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyshare indicates an expected call of GetKeyshare.
+func (mr *MockKeyshareFetcherMockRecorder) GetKeyshare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockKeyshareFetcher)(nil).GetKeyshare))
+}
+
+// MockTopologyFetcher is a mock of TopologyFetcher interface.
+type MockTopologyFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopologyFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockTopologyFetcherMockRecorder is the mock recorder for MockTopologyFetcher.
+type MockTopologyFetcherMockRecorder struct {
+	mock *MockTopologyFetcher
+}
+
+// NewMockTopologyFetcher creates a new mock instance.
+func NewMockTopologyFetcher(ctrl *gomock.Controller) *MockTopologyFetcher {
+	mock := &MockTopologyFetcher{ctrl: ctrl}
+	mock.recorder = &MockTopologyFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopologyFetcher) EXPECT() *MockTopologyFetcherMockRecorder {
+	return m.recorder
+}
+
+// Topology mocks base method.
+func (m *MockTopologyFetcher) Topology() (*topology.NetworkTopology, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Topology")
+	ret0, _ := ret[0].(*topology.NetworkTopology)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Topology indicates an expected call of Topology.
+func (mr *MockTopologyFetcherMockRecorder) Topology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Topology", reflect.TypeOf((*MockTopologyFetcher)(nil).Topology))
+}
+
+// MockPeerNetwork is a mock of PeerNetwork interface.
+type MockPeerNetwork struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeerNetworkMockRecorder
+	isgomock struct{}
+}
+
+// MockPeerNetworkMockRecorder is the mock recorder for MockPeerNetwork.
+type MockPeerNetworkMockRecorder struct {
+	mock *MockPeerNetwork
+}
+
+// NewMockPeerNetwork creates a new mock instance.
+func NewMockPeerNetwork(ctrl *gomock.Controller) *MockPeerNetwork {
+	mock := &MockPeerNetwork{ctrl: ctrl}
+	mock.recorder = &MockPeerNetworkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeerNetwork) EXPECT() *MockPeerNetworkMockRecorder {
+	return m.recorder
+}
+
+// Connectedness mocks base method.
+func (m *MockPeerNetwork) Connectedness(arg0 peer.ID) network.Connectedness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connectedness", arg0)
+	ret0, _ := ret[0].(network.Connectedness)
+	return ret0
+}
+
+// Connectedness indicates an expected call of Connectedness.
+func (mr *MockPeerNetworkMockRecorder) Connectedness(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connectedness", reflect.TypeOf((*MockPeerNetwork)(nil).Connectedness), arg0)
+}
+
+// MockHeaderFetcher is a mock of HeaderFetcher interface.
+type MockHeaderFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeaderFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockHeaderFetcherMockRecorder is the mock recorder for MockHeaderFetcher.
+type MockHeaderFetcherMockRecorder struct {
+	mock *MockHeaderFetcher
+}
+
+// NewMockHeaderFetcher creates a new mock instance.
+func NewMockHeaderFetcher(ctrl *gomock.Controller) *MockHeaderFetcher {
+	mock := &MockHeaderFetcher{ctrl: ctrl}
+	mock.recorder = &MockHeaderFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeaderFetcher) EXPECT() *MockHeaderFetcherMockRecorder {
+	return m.recorder
+}
+
+// HeaderByNumber mocks base method.
+func (m *MockHeaderFetcher) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeaderByNumber", ctx, number)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeaderByNumber indicates an expected call of HeaderByNumber.
+func (mr *MockHeaderFetcherMockRecorder) HeaderByNumber(ctx, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderByNumber", reflect.TypeOf((*MockHeaderFetcher)(nil).HeaderByNumber), ctx, number)
+}
+
+// MockConfigWatcher is a mock of ConfigWatcher interface.
+type MockConfigWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigWatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockConfigWatcherMockRecorder is the mock recorder for MockConfigWatcher.
+type MockConfigWatcherMockRecorder struct {
+	mock *MockConfigWatcher
+}
+
+// NewMockConfigWatcher creates a new mock instance.
+func NewMockConfigWatcher(ctrl *gomock.Controller) *MockConfigWatcher {
+	mock := &MockConfigWatcher{ctrl: ctrl}
+	mock.recorder = &MockConfigWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigWatcher) EXPECT() *MockConfigWatcherMockRecorder {
+	return m.recorder
+}
+
+// LastFetched mocks base method.
+func (m *MockConfigWatcher) LastFetched() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastFetched")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastFetched indicates an expected call of LastFetched.
+func (mr *MockConfigWatcherMockRecorder) LastFetched() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastFetched", reflect.TypeOf((*MockConfigWatcher)(nil).LastFetched))
+}
+
+// MockDrainer is a mock of Drainer interface.
+type MockDrainer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDrainerMockRecorder
+	isgomock struct{}
+}
+
+// MockDrainerMockRecorder is the mock recorder for MockDrainer.
+type MockDrainerMockRecorder struct {
+	mock *MockDrainer
+}
+
+// NewMockDrainer creates a new mock instance.
+func NewMockDrainer(ctrl *gomock.Controller) *MockDrainer {
+	mock := &MockDrainer{ctrl: ctrl}
+	mock.recorder = &MockDrainerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDrainer) EXPECT() *MockDrainerMockRecorder {
+	return m.recorder
+}
+
+// Draining mocks base method.
+func (m *MockDrainer) Draining() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Draining")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Draining indicates an expected call of Draining.
+func (mr *MockDrainerMockRecorder) Draining() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Draining", reflect.TypeOf((*MockDrainer)(nil).Draining))
+}
@@ -0,0 +1,52 @@
+package lvldb
+
+import (
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// LVLDB is a LevelDB key value store shared by the blockstore and the signature store
+type LVLDB struct {
+	db *leveldb.DB
+}
+
+func NewLvlDB(path string) (*LVLDB, error) {
+	ldb, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening leveldb: %w", err)
+	}
+	return &LVLDB{db: ldb}, nil
+}
+
+func (db *LVLDB) GetByKey(key []byte) ([]byte, error) {
+	return db.db.Get(key, nil)
+}
+
+func (db *LVLDB) SetByKey(key []byte, value []byte) error {
+	return db.db.Put(key, value, nil)
+}
+
+// Write applies all operations of the batch atomically
+func (db *LVLDB) Write(batch *leveldb.Batch) error {
+	return db.db.Write(batch, nil)
+}
+
+// Iterate calls fn with each key and value in the range [start, limit) in key order
+// until fn returns false. Keys and values must be copied to be used after fn returns.
+func (db *LVLDB) Iterate(start []byte, limit []byte, fn func(key []byte, value []byte) bool) error {
+	iter := db.db.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+func (db *LVLDB) Close() error {
+	return db.db.Close()
+}
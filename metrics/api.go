@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type ApiMetrics struct {
+	rateLimitedCounter  metric.Int64Counter
+	inFlightGauge       metric.Int64ObservableGauge
+	inFlightRequests    *atomic.Int64
+	maxInFlightGauge    metric.Int64ObservableGauge
+	maxInFlightRequests *atomic.Int64
+	opts                metric.MeasurementOption
+}
+
+// NewApiMetrics initializes metrics related to the HTTP API
+func NewApiMetrics(ctx context.Context, meter metric.Meter, opts metric.MeasurementOption) (*ApiMetrics, error) {
+	inFlightRequests := new(atomic.Int64)
+	maxInFlightRequests := new(atomic.Int64)
+	rateLimitedCounter, err := meter.Int64Counter(
+		"relayer.ApiRateLimitedRequests",
+		metric.WithDescription("Number of API requests rejected because of rate limits"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inFlightGauge, err := meter.Int64ObservableGauge(
+		"relayer.ApiInFlightRequests",
+		metric.WithInt64Callback(func(context context.Context, result metric.Int64Observer) error {
+			result.Observe(inFlightRequests.Load(), opts)
+			return nil
+		}),
+		metric.WithDescription("Number of API requests currently being handled"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	maxInFlightGauge, err := meter.Int64ObservableGauge(
+		"relayer.ApiMaxInFlightRequests",
+		metric.WithInt64Callback(func(context context.Context, result metric.Int64Observer) error {
+			result.Observe(maxInFlightRequests.Load(), opts)
+			return nil
+		}),
+		metric.WithDescription("Configured limit of API requests handled at the same time"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApiMetrics{
+		rateLimitedCounter:  rateLimitedCounter,
+		inFlightGauge:       inFlightGauge,
+		inFlightRequests:    inFlightRequests,
+		maxInFlightGauge:    maxInFlightGauge,
+		maxInFlightRequests: maxInFlightRequests,
+		opts:                opts,
+	}, nil
+}
+
+func (m *ApiMetrics) TrackRateLimited(limit string) {
+	m.rateLimitedCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		metric.WithAttributes(attribute.String("limit", limit)),
+	)
+}
+
+func (m *ApiMetrics) TrackInFlightRequests(inFlight int64, maxInFlight int64) {
+	m.inFlightRequests.Store(inFlight)
+	m.maxInFlightRequests.Store(maxInFlight)
+}
@@ -15,6 +15,7 @@ type SprinterMetrics struct {
 	*observability.RelayerMetrics
 	*MpcMetrics
 	*HostMetrics
+	*ApiMetrics
 }
 
 // NewSygmaMetrics creates an instance of metrics
@@ -36,9 +37,15 @@ func NewSprinterMetrics(ctx context.Context, meter api.Meter, env, relayerID, ve
 		return nil, err
 	}
 
+	apiMetrics, err := NewApiMetrics(ctx, meter, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	return &SprinterMetrics{
 		RelayerMetrics: relayerMetrics,
 		MpcMetrics:     mpcMetrics,
 		HostMetrics:    hostMetrics,
+		ApiMetrics:     apiMetrics,
 	}, nil
 }
@@ -21,10 +21,18 @@ type MpcMetrics struct {
 	totalRelayerCount      *int64
 	availableRelayerCount  *int64
 
+	presignaturesGauge metric.Int64ObservableGauge
+	presignatureCount  *int64
+
 	sessionTimeHistogram        metric.Float64Histogram
 	initiateTimeHistogram       metric.Float64Histogram
 	commSendTimeHistogram       metric.Float64Histogram
 	commDnsResolveTimeHistogram metric.Float64Histogram
+	invalidSignatureCounter     metric.Int64Counter
+	processRetryCounter         metric.Int64Counter
+	peerBlameCounter            metric.Int64Counter
+	presignatureUsageCounter    metric.Int64Counter
+	presignatureInvalidCounter  metric.Int64Counter
 	sessionStartTimeCache       *ttlcache.Cache[string, time.Time]
 	opts                        metric.MeasurementOption
 }
@@ -56,6 +64,19 @@ func NewMpcMetrics(ctx context.Context, meter metric.Meter, opts metric.Measurem
 		return nil, err
 	}
 
+	presignatureCount := new(int64)
+	presignaturesGauge, err := meter.Int64ObservableGauge(
+		"relayer.Presignatures",
+		metric.WithInt64Callback(func(context context.Context, result metric.Int64Observer) error {
+			result.Observe(*presignatureCount, opts)
+			return nil
+		}),
+		metric.WithDescription("Number of presignatures currently in the presignature pool"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionTimeHistogram, err := meter.Float64Histogram(
 		"relayer.SessionTime",
 		metric.WithUnit("s"),
@@ -91,6 +112,46 @@ func NewMpcMetrics(ctx context.Context, meter metric.Meter, opts metric.Measurem
 		return nil, err
 	}
 
+	invalidSignatureCounter, err := meter.Int64Counter(
+		"relayer.InvalidSignatures",
+		metric.WithDescription("Number of signatures received from peers that were not signed by the MPC key, labelled by sending peer"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	processRetryCounter, err := meter.Int64Counter(
+		"relayer.ProcessRetries",
+		metric.WithDescription("Number of tss process retries, labelled by process type and retry attempt"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	peerBlameCounter, err := meter.Int64Counter(
+		"relayer.PeerBlames",
+		metric.WithDescription("Number of failed tss processes peers were blamed for, labelled by peer and blame reason"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	presignatureUsageCounter, err := meter.Int64Counter(
+		"relayer.PresignatureUsage",
+		metric.WithDescription("Number of coordinated signing processes, labelled by whether they used a presignature"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	presignatureInvalidCounter, err := meter.Int64Counter(
+		"relayer.PresignatureInvalidations",
+		metric.WithDescription("Number of presignatures removed from the pool because they expired or the key share changed"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &MpcMetrics{
 		totalRelayersGauge:          totalRelayersGauge,
 		availableRelayersGauge:      availableRelayersGauge,
@@ -100,6 +161,13 @@ func NewMpcMetrics(ctx context.Context, meter metric.Meter, opts metric.Measurem
 		initiateTimeHistogram:       initiateTimeHistogram,
 		commSendTimeHistogram:       commSendTimeHistogram,
 		commDnsResolveTimeHistogram: commDnsResolveTimeHistogram,
+		invalidSignatureCounter:     invalidSignatureCounter,
+		processRetryCounter:         processRetryCounter,
+		peerBlameCounter:            peerBlameCounter,
+		presignaturesGauge:          presignaturesGauge,
+		presignatureCount:           presignatureCount,
+		presignatureUsageCounter:    presignatureUsageCounter,
+		presignatureInvalidCounter:  presignatureInvalidCounter,
 		sessionStartTimeCache: ttlcache.New(
 			ttlcache.WithTTL[string, time.Time](SESSION_TTL),
 		),
@@ -142,3 +210,47 @@ func (m *MpcMetrics) RecordCommSend(peerID string, d time.Duration) {
 func (m *MpcMetrics) RecordCommDnsResolve(d time.Duration) {
 	m.commDnsResolveTimeHistogram.Record(context.Background(), d.Seconds(), m.opts)
 }
+
+func (m *MpcMetrics) TrackInvalidSignature(peerID string) {
+	m.invalidSignatureCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		metric.WithAttributes(attribute.String("peer", peerID)),
+	)
+}
+
+func (m *MpcMetrics) TrackProcessRetry(processType string, attempt int) {
+	m.processRetryCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		metric.WithAttributes(attribute.String("process", processType), attribute.Int("attempt", attempt)),
+	)
+}
+
+func (m *MpcMetrics) TrackPeerBlame(peerID string, reason string) {
+	m.peerBlameCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		metric.WithAttributes(attribute.String("peer", peerID), attribute.String("reason", reason)),
+	)
+}
+
+func (m *MpcMetrics) TrackPresignatures(available int) {
+	*m.presignatureCount = int64(available)
+}
+
+func (m *MpcMetrics) TrackPresignatureUsage(used bool) {
+	m.presignatureUsageCounter.Add(
+		context.Background(),
+		1,
+		m.opts,
+		metric.WithAttributes(attribute.Bool("used", used)),
+	)
+}
+
+func (m *MpcMetrics) TrackPresignatureInvalidation(count int) {
+	m.presignatureInvalidCounter.Add(context.Background(), int64(count), m.opts)
+}
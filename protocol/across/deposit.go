@@ -2,6 +2,7 @@ package across
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/sprintertech/sprinter-signing/chains/evm/calls/consts"
 	"github.com/sprintertech/sprinter-signing/chains/evm/calls/events"
 	"github.com/sprintertech/sprinter-signing/config"
+	"github.com/sprintertech/sprinter-signing/errcode"
 )
 
 const (
@@ -59,6 +61,10 @@ func (h *AcrossDepositFetcher) Deposit(ctx context.Context, hash common.Hash, de
 
 func (h *AcrossDepositFetcher) fetchDepositByHash(ctx context.Context, hash common.Hash, depositID *big.Int) (*events.AcrossDeposit, error) {
 	receipt, err := h.client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, errcode.New(errcode.DepositNotFound, "deposit transaction %s not found", hash.Hex()).
+			WithDetail("depositTxHash", hash.Hex())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +93,9 @@ func (h *AcrossDepositFetcher) fetchDepositByHash(ctx context.Context, hash comm
 		return d, nil
 	}
 
-	return nil, fmt.Errorf("deposit with id %s not found", depositID)
+	return nil, errcode.New(errcode.DepositNotFound, "deposit with id %s not found", depositID).
+		WithDetail("depositId", depositID.String()).
+		WithDetail("depositTxHash", hash.Hex())
 }
 
 func (h *AcrossDepositFetcher) parseDeposit(l types.Log) (*events.AcrossDeposit, error) {
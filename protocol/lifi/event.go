@@ -2,13 +2,16 @@ package lifi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/sprintertech/lifi-solver/pkg/protocols/lifi"
 	contracts "github.com/sprintertech/lifi-solver/pkg/protocols/lifi/contracts"
+	"github.com/sprintertech/sprinter-signing/errcode"
 )
 
 const (
@@ -49,6 +52,10 @@ func (h *LifiEventFetcher) Order(ctx context.Context, hash common.Hash, orderID
 
 func (h *LifiEventFetcher) fetchOpenEvent(ctx context.Context, hash common.Hash, orderID common.Hash) (*types.Log, error) {
 	receipt, err := h.client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, errcode.New(errcode.DepositNotFound, "no receipt found for hash %s", hash.Hex()).
+			WithDetail("depositTxHash", hash.Hex())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("no receipt found for hash %s, %w", hash.Hex(), err)
 	}
@@ -77,5 +84,7 @@ func (h *LifiEventFetcher) fetchOpenEvent(ctx context.Context, hash common.Hash,
 		return l, nil
 	}
 
-	return nil, fmt.Errorf("order with id %s not found", orderID.Hex())
+	return nil, errcode.New(errcode.DepositNotFound, "order with id %s not found", orderID.Hex()).
+		WithDetail("orderId", orderID.Hex()).
+		WithDetail("depositTxHash", hash.Hex())
 }
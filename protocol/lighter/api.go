@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/sprintertech/sprinter-signing/errcode"
 )
 
 const (
@@ -83,6 +84,8 @@ func NewLighterAPI() *LighterAPI {
 	retryClient.RetryWaitMin = TX_NOT_FOUND_RETRY_WAIT
 	retryClient.RetryWaitMax = TX_NOT_FOUND_RETRY_WAIT
 	retryClient.CheckRetry = LighterCheckRetry
+	// return the last response once retries are exhausted so missing transactions can be reported
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
 	retryClient.Logger = nil
 
 	return &LighterAPI{
@@ -148,6 +151,12 @@ func (a *LighterAPI) GetTx(hash string) (*LighterTx, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	e := new(LighterError)
+	if err := json.Unmarshal(body, e); err == nil && e.Code == TX_NOT_FOUND_ERROR_CODE {
+		return nil, errcode.New(errcode.DepositNotFound, "transaction %s not found", hash).
+			WithDetail("depositTxHash", hash)
+	}
+
 	s := new(LighterTx)
 	if err := json.Unmarshal(body, s); err != nil {
 		return nil, err
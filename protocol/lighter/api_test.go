@@ -52,6 +52,13 @@ func Test_LighterAPI_GetTx(t *testing.T) {
 			statusCode:   http.StatusNotFound,
 			wantErr:      true,
 		},
+		{
+			name:         "transaction not found",
+			id:           "missinghash",
+			mockResponse: []byte(`{"code": 21500, "message": "transaction not found"}`),
+			statusCode:   http.StatusOK,
+			wantErr:      true,
+		},
 		{
 			name:         "invalid JSON",
 			id:           "badjson",
@@ -0,0 +1,158 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	blameThreshold   = 3
+	blameCoolDown    = 30 * time.Minute
+	maxSessionBlames = 100
+)
+
+type BlameReason string
+
+const (
+	// CulpritBlame is assigned to peers identified as culprits by tss-lib
+	CulpritBlame BlameReason = "culprit"
+	// StallBlame is assigned to peers the tss process stalled waiting for
+	StallBlame BlameReason = "stall"
+	// TimeoutBlame is assigned to peers the tss process was waiting for when it timed out
+	TimeoutBlame BlameReason = "timeout"
+	// UnresponsiveBlame is assigned to coordinators that stopped responding to pings
+	UnresponsiveBlame BlameReason = "unresponsive"
+)
+
+// SessionBlame records peers blamed for a failed tss session attempt
+type SessionBlame struct {
+	SessionID   string             `json:"sessionId"`
+	ProcessType common.ProcessType `json:"processType"`
+	Reason      BlameReason        `json:"reason"`
+	Peers       []peer.ID          `json:"peers"`
+	BlamedAt    time.Time          `json:"blamedAt"`
+}
+
+// PeerBlame summarizes failures a peer was blamed for in the cool-down period
+type PeerBlame struct {
+	Peer          peer.ID             `json:"peer"`
+	Failures      map[BlameReason]int `json:"failures"`
+	LastBlamedAt  time.Time           `json:"lastBlamedAt"`
+	Deprioritized bool                `json:"deprioritized"`
+}
+
+type peerFailure struct {
+	reason   BlameReason
+	blamedAt time.Time
+}
+
+// BlameTracker records peers blamed for failed tss sessions. Peers blamed for
+// at least Threshold failures in the cool-down period are deprioritized until
+// their failures are older than the cool-down period.
+type BlameTracker struct {
+	lock     sync.Mutex
+	sessions []SessionBlame
+	failures map[peer.ID][]peerFailure
+
+	Threshold int
+	CoolDown  time.Duration
+}
+
+func NewBlameTracker() *BlameTracker {
+	return &BlameTracker{
+		sessions:  make([]SessionBlame, 0),
+		failures:  make(map[peer.ID][]peerFailure),
+		Threshold: blameThreshold,
+		CoolDown:  blameCoolDown,
+	}
+}
+
+// Blame records the peers as the cause of the failed session attempt.
+// Only the latest session blames are kept.
+func (b *BlameTracker) Blame(blame SessionBlame) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.sessions = append(b.sessions, blame)
+	if len(b.sessions) > maxSessionBlames {
+		b.sessions = b.sessions[len(b.sessions)-maxSessionBlames:]
+	}
+	for _, p := range blame.Peers {
+		b.failures[p] = append(b.failures[p], peerFailure{
+			reason:   blame.Reason,
+			blamedAt: blame.BlamedAt,
+		})
+	}
+}
+
+// SessionBlames returns the latest session blames ordered by blame time
+func (b *BlameTracker) SessionBlames() []SessionBlame {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return slices.Clone(b.sessions)
+}
+
+// PeerBlames returns blame summaries of peers with failures in the cool-down period
+func (b *BlameTracker) PeerBlames() []PeerBlame {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.prune()
+	blames := make([]PeerBlame, 0, len(b.failures))
+	for p, failures := range b.failures {
+		blame := PeerBlame{
+			Peer:          p,
+			Failures:      make(map[BlameReason]int),
+			Deprioritized: len(failures) >= b.Threshold,
+		}
+		for _, failure := range failures {
+			blame.Failures[failure.reason]++
+			if failure.blamedAt.After(blame.LastBlamedAt) {
+				blame.LastBlamedAt = failure.blamedAt
+			}
+		}
+		blames = append(blames, blame)
+	}
+	slices.SortFunc(blames, func(a, b PeerBlame) int {
+		return b.LastBlamedAt.Compare(a.LastBlamedAt)
+	})
+	return blames
+}
+
+// Deprioritized returns peers that are blamed for too many failures in the cool-down period
+func (b *BlameTracker) Deprioritized() []peer.ID {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.prune()
+	peers := make([]peer.ID, 0)
+	for p, failures := range b.failures {
+		if len(failures) >= b.Threshold {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// prune removes failures older than the cool-down period
+func (b *BlameTracker) prune() {
+	expiry := time.Now().Add(-b.CoolDown)
+	for p, failures := range b.failures {
+		failures = slices.DeleteFunc(failures, func(f peerFailure) bool {
+			return f.blamedAt.Before(expiry)
+		})
+		if len(failures) == 0 {
+			delete(b.failures, p)
+			continue
+		}
+		b.failures[p] = failures
+	}
+}
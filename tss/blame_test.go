@@ -0,0 +1,91 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sprintertech/sprinter-signing/tss"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type BlameTrackerTestSuite struct {
+	suite.Suite
+
+	peer1 peer.ID
+	peer2 peer.ID
+
+	tracker *tss.BlameTracker
+}
+
+func TestRunBlameTrackerTestSuite(t *testing.T) {
+	suite.Run(t, new(BlameTrackerTestSuite))
+}
+
+func (s *BlameTrackerTestSuite) SetupTest() {
+	s.peer1, _ = peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	s.peer2, _ = peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	s.tracker = tss.NewBlameTracker()
+	s.tracker.Threshold = 2
+	s.tracker.CoolDown = time.Minute
+}
+
+func (s *BlameTrackerTestSuite) blame(reason tss.BlameReason, blamedAt time.Time, peers ...peer.ID) {
+	s.tracker.Blame(tss.SessionBlame{
+		SessionID:   "1-1",
+		ProcessType: common.SigningProcess,
+		Reason:      reason,
+		Peers:       peers,
+		BlamedAt:    blamedAt,
+	})
+}
+
+func (s *BlameTrackerTestSuite) Test_SessionBlames_RecordsBlamedPeers() {
+	s.blame(tss.CulpritBlame, time.Now(), s.peer1)
+	s.blame(tss.StallBlame, time.Now(), s.peer1, s.peer2)
+
+	blames := s.tracker.SessionBlames()
+
+	s.Len(blames, 2)
+	s.Equal(tss.CulpritBlame, blames[0].Reason)
+	s.Equal([]peer.ID{s.peer1, s.peer2}, blames[1].Peers)
+}
+
+func (s *BlameTrackerTestSuite) Test_PeerBlames_CountsFailuresByReason() {
+	s.blame(tss.CulpritBlame, time.Now(), s.peer1)
+	s.blame(tss.StallBlame, time.Now(), s.peer1, s.peer2)
+
+	blames := s.tracker.PeerBlames()
+
+	s.Len(blames, 2)
+	for _, blame := range blames {
+		switch blame.Peer {
+		case s.peer1:
+			s.Equal(map[tss.BlameReason]int{tss.CulpritBlame: 1, tss.StallBlame: 1}, blame.Failures)
+			s.True(blame.Deprioritized)
+		case s.peer2:
+			s.Equal(map[tss.BlameReason]int{tss.StallBlame: 1}, blame.Failures)
+			s.False(blame.Deprioritized)
+		}
+	}
+}
+
+func (s *BlameTrackerTestSuite) Test_Deprioritized_PeersOverThreshold() {
+	s.blame(tss.TimeoutBlame, time.Now(), s.peer1, s.peer2)
+	s.blame(tss.TimeoutBlame, time.Now(), s.peer1)
+
+	s.Equal([]peer.ID{s.peer1}, s.tracker.Deprioritized())
+}
+
+func (s *BlameTrackerTestSuite) Test_Deprioritized_FailuresExpireAfterCoolDown() {
+	s.blame(tss.TimeoutBlame, time.Now().Add(-time.Hour), s.peer1)
+	s.blame(tss.TimeoutBlame, time.Now(), s.peer1)
+
+	s.Empty(s.tracker.Deprioritized())
+	s.Len(s.tracker.PeerBlames(), 1)
+	s.Len(s.tracker.SessionBlames(), 2)
+}
@@ -10,7 +10,9 @@ import (
 	"sync"
 	"time"
 
+	tssLib "github.com/binance-chain/tss-lib/tss"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
 	"github.com/sourcegraph/conc/pool"
@@ -23,6 +25,8 @@ import (
 
 var (
 	initiatePeriod = 1 * time.Second
+	drainPeriod    = 500 * time.Millisecond
+	maxRetries     = 2
 )
 
 type TssProcess interface {
@@ -30,9 +34,11 @@ type TssProcess interface {
 	Stop()
 	Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error)
 	Retryable() bool
-	StartParams(readyPeers []peer.ID) []byte
+	ProcessType() common.ProcessType
+	StartParams(readyPeers []peer.ID, deprioritizedPeers []peer.ID) []byte
 	SessionID() string
 	ValidCoordinators() []peer.ID
+	WaitingFor() []peer.ID
 	Timeout() time.Duration
 }
 
@@ -40,6 +46,18 @@ type Metrics interface {
 	StartProcess(sessionID string)
 	EndProcess(sessionID string)
 	RecordInitiateDuration(d time.Duration)
+	TrackProcessRetry(processType string, attempt int)
+	TrackPeerBlame(peerID string, reason string)
+}
+
+// PendingProcess describes a tss process that is currently being executed
+type PendingProcess struct {
+	SessionID   string    `json:"sessionId"`
+	Coordinator peer.ID   `json:"coordinator"`
+	StartedAt   time.Time `json:"startedAt"`
+
+	cancel    context.CancelFunc
+	cancelled bool
 }
 
 type Coordinator struct {
@@ -47,12 +65,24 @@ type Coordinator struct {
 	communication  comm.Communication
 	electorFactory *elector.CoordinatorElectorFactory
 
-	pendingProcesses map[string]bool
+	pendingProcesses map[string]*PendingProcess
 	processLock      sync.Mutex
 	metrics          Metrics
 
+	draining bool
+	// leavingPeers maps peers that announced they are going offline
+	// to whether they disconnected since
+	leavingPeers map[peer.ID]bool
+
 	CoordinatorTimeout time.Duration
 	InitiatePeriod     time.Duration
+	// MaxRetries is the number of times a failed retryable process is restarted
+	MaxRetries int
+	// Blame records peers that caused process failures
+	Blame *BlameTracker
+	// Electors maps process types to the coordinator elector used for their
+	// sessions. Processes of types that are not mapped use the static elector.
+	Electors map[common.ProcessType]elector.CoordinatorElectorType
 }
 
 func NewCoordinator(
@@ -67,62 +97,135 @@ func NewCoordinator(
 		electorFactory: electorFactory,
 		metrics:        metrics,
 
-		pendingProcesses: make(map[string]bool),
+		pendingProcesses: make(map[string]*PendingProcess),
+		leavingPeers:     make(map[peer.ID]bool),
 
 		InitiatePeriod: initiatePeriod,
+		MaxRetries:     maxRetries,
+		Blame:          NewBlameTracker(),
+		Electors:       make(map[common.ProcessType]elector.CoordinatorElectorType),
 	}
 }
 
 // Execute calculates process leader and coordinates party readiness and start the tss processes.
 // Array of processes can be passed if all the processes have to have the same peer subset and
 // the result of all of them is needed. The processes should have an unique session ID for each one.
+// The readiness handshake is done once, through the session of the first process, and all
+// processes are started with the same start params.
+// Retryable processes are restarted up to MaxRetries times if they fail, stall or time out.
+// Peers that caused the failure are excluded from the retried process and a new coordinator
+// is elected if the coordinator elector reports the current coordinator as unresponsive.
 func (c *Coordinator) Execute(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}, coordinator peer.ID) error {
 	process := tssProcesses[0]
 	sessionID := process.SessionID()
 
+	ctx, cancel := context.WithCancel(ctx)
 	c.processLock.Lock()
-	value, ok := c.pendingProcesses[sessionID]
-	if ok && value {
+	if c.draining && coordinator != c.host.ID() {
 		c.processLock.Unlock()
-		log.Warn().Str("SessionID", sessionID).Msgf("Process already pending")
-		return fmt.Errorf("process already pending")
+		cancel()
+		log.Warn().Str("SessionID", sessionID).Msgf("Rejecting process while draining")
+		return ErrDraining
+	}
+	for _, process := range tssProcesses {
+		if _, ok := c.pendingProcesses[process.SessionID()]; ok {
+			c.processLock.Unlock()
+			cancel()
+			log.Warn().Str("SessionID", process.SessionID()).Msgf("Process already pending")
+			return fmt.Errorf("process already pending")
+		}
+	}
+	pendingProcesses := make([]*PendingProcess, len(tssProcesses))
+	for i, process := range tssProcesses {
+		pendingProcesses[i] = &PendingProcess{
+			SessionID: process.SessionID(),
+			StartedAt: time.Now(),
+			cancel:    cancel,
+		}
+		c.pendingProcesses[process.SessionID()] = pendingProcesses[i]
+		c.metrics.StartProcess(process.SessionID())
 	}
-	c.pendingProcesses[sessionID] = true
 	c.processLock.Unlock()
-	c.metrics.StartProcess(sessionID)
 
-	ctx, cancel := context.WithCancel(ctx)
-	p := pool.New().WithContext(ctx).WithCancelOnError()
 	defer func() {
 		cancel()
-		c.communication.CloseSession(sessionID)
 		c.processLock.Lock()
-		c.pendingProcesses[sessionID] = false
+		for _, process := range tssProcesses {
+			c.communication.CloseSession(process.SessionID())
+			delete(c.pendingProcesses, process.SessionID())
+		}
 		c.processLock.Unlock()
 		for _, process := range tssProcesses {
 			process.Stop()
 		}
 	}()
 
-	coordinatorElector := c.electorFactory.CoordinatorElector(sessionID, elector.Static)
+	coordinatorElector := c.electorFactory.CoordinatorElector(sessionID, c.electorType(process))
+	coordinatorElector.Listen(ctx)
 	if coordinator.String() == "" {
-		coordinator, _ = coordinatorElector.Coordinator(ctx, tssProcesses[0].ValidCoordinators())
+		coordinator, _ = coordinatorElector.Coordinator(ctx, c.activePeers(process.ValidCoordinators()))
 	}
 
-	log.Info().Str("SessionID", sessionID).Msgf("Starting process with coordinator %s", coordinator.String())
+	excludedPeers := []peer.ID{}
+	var err error
+	for attempt := 1; ; attempt++ {
+		c.processLock.Lock()
+		for _, pendingProcess := range pendingProcesses {
+			pendingProcess.Coordinator = coordinator
+		}
+		c.processLock.Unlock()
 
-	p.Go(func(ctx context.Context) error {
-		err := c.start(ctx, process, coordinator, resultChn, []peer.ID{})
-		if err == nil {
-			cancel()
+		log.Info().Str("SessionID", sessionID).Msgf("Starting process with coordinator %s", coordinator.String())
+
+		err = c.run(ctx, cancel, tssProcesses, coordinatorElector, coordinator, resultChn, excludedPeers)
+		if err == nil || ctx.Err() != nil {
+			break
 		}
-		return err
-	})
-	p.Go(func(ctx context.Context) error {
-		return c.watchExecution(ctx, process, coordinator, cancel)
-	})
-	err := p.Wait()
+		failedPeers, reason, retryable := c.failedPeers(err, tssProcesses, coordinator)
+		c.blame(process, reason, failedPeers)
+		if !retryable || !process.Retryable() || attempt > c.MaxRetries {
+			if coordinator == c.host.ID() && !errors.Is(err, ErrProcessTimeout) {
+				c.broadcastFailMsg(sessionID, false)
+			}
+			break
+		}
+
+		log.Warn().Str("SessionID", sessionID).Msgf("Retrying process after failure excluding peers %s: %s", failedPeers, err)
+		c.metrics.TrackProcessRetry(string(process.ProcessType()), attempt)
+		for _, process := range tssProcesses {
+			process.Stop()
+		}
+		for _, p := range failedPeers {
+			if p != c.host.ID() && !slices.Contains(excludedPeers, p) {
+				excludedPeers = append(excludedPeers, p)
+			}
+		}
+
+		if errors.Is(err, elector.ErrCoordinatorUnresponsive) {
+			log.Warn().Str("SessionID", sessionID).Msgf("Coordinator %s unresponsive, electing new coordinator", coordinator.String())
+			coordinator, err = coordinatorElector.Coordinator(
+				ctx, common.ExcludePeers(c.activePeers(process.ValidCoordinators()), excludedPeers),
+			)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if coordinator == c.host.ID() && !errors.Is(err, ErrProcessRetried) {
+			c.broadcastFailMsg(sessionID, true)
+		}
+	}
+	if errors.Is(err, ErrProcessTimeout) {
+		err = nil
+	}
 	if err == nil {
+		c.processLock.Lock()
+		defer c.processLock.Unlock()
+		for _, pendingProcess := range pendingProcesses {
+			if pendingProcess.cancelled {
+				return ErrProcessCancelled
+			}
+		}
 		return nil
 	}
 
@@ -134,7 +237,261 @@ func (c *Coordinator) Execute(ctx context.Context, tssProcesses []TssProcess, re
 	return err
 }
 
-func (c *Coordinator) watchExecution(ctx context.Context, tssProcess TssProcess, coordinator peer.ID, cancel context.CancelFunc) error {
+// run starts the tss processes with the coordinator and watches their execution
+// until they are finished or the coordinator becomes unresponsive
+func (c *Coordinator) run(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	tssProcesses []TssProcess,
+	coordinatorElector elector.CoordinatorElector,
+	coordinator peer.ID,
+	resultChn chan interface{},
+	excludedPeers []peer.ID,
+) error {
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	p.Go(func(ctx context.Context) error {
+		err := c.start(ctx, tssProcesses, coordinator, resultChn, excludedPeers)
+		// start also returns when the process is restarted with another coordinator
+		if err == nil && ctx.Err() == nil {
+			cancel()
+		}
+		return err
+	})
+	p.Go(func(ctx context.Context) error {
+		return c.watchExecution(ctx, tssProcesses[0], coordinator)
+	})
+	p.Go(func(ctx context.Context) error {
+		return coordinatorElector.Watch(ctx, coordinator)
+	})
+	return p.Wait()
+}
+
+// electorType returns the coordinator elector type configured for the process type
+func (c *Coordinator) electorType(process TssProcess) elector.CoordinatorElectorType {
+	electorType, ok := c.Electors[process.ProcessType()]
+	if !ok {
+		return elector.Static
+	}
+	return electorType
+}
+
+// PendingProcesses returns all tss processes that are currently being executed
+func (c *Coordinator) PendingProcesses() []PendingProcess {
+	c.processLock.Lock()
+	defer c.processLock.Unlock()
+
+	processes := make([]PendingProcess, 0, len(c.pendingProcesses))
+	for _, process := range c.pendingProcesses {
+		processes = append(processes, *process)
+	}
+	slices.SortFunc(processes, func(a, b PendingProcess) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return processes
+}
+
+// CancelProcess stops the pending tss process with the given session ID.
+// Processes executed together with it are stopped as well.
+func (c *Coordinator) CancelProcess(sessionID string) error {
+	c.processLock.Lock()
+	defer c.processLock.Unlock()
+
+	process, ok := c.pendingProcesses[sessionID]
+	if !ok {
+		return fmt.Errorf("process %s not pending", sessionID)
+	}
+
+	log.Warn().Str("SessionID", sessionID).Msgf("Cancelling process")
+	process.cancelled = true
+	process.cancel()
+	return nil
+}
+
+// Listen tracks peers that announced they are going offline. Leaving peers are not
+// elected as coordinators and are not included into new processes until they
+// reconnect after a restart.
+func (c *Coordinator) Listen(ctx context.Context) {
+	leaveChn := make(chan *comm.WrappedMessage)
+	subID := c.communication.Subscribe(comm.LeaveSessionID, comm.CoordinatorLeaveMsg, leaveChn)
+	defer c.communication.UnSubscribe(subID)
+
+	notifiee := &network.NotifyBundle{
+		ConnectedF: func(n network.Network, conn network.Conn) {
+			c.processLock.Lock()
+			defer c.processLock.Unlock()
+
+			if c.leavingPeers[conn.RemotePeer()] {
+				log.Info().Msgf("Leaving peer %s rejoined", conn.RemotePeer())
+				delete(c.leavingPeers, conn.RemotePeer())
+			}
+		},
+		DisconnectedF: func(n network.Network, conn network.Conn) {
+			c.processLock.Lock()
+			defer c.processLock.Unlock()
+
+			_, ok := c.leavingPeers[conn.RemotePeer()]
+			if ok && n.Connectedness(conn.RemotePeer()) != network.Connected {
+				c.leavingPeers[conn.RemotePeer()] = true
+			}
+		},
+	}
+	c.host.Network().Notify(notifiee)
+	defer c.host.Network().StopNotify(notifiee)
+
+	for {
+		select {
+		case msg := <-leaveChn:
+			{
+				log.Info().Msgf("Peer %s is leaving", msg.From)
+				c.processLock.Lock()
+				c.leavingPeers[msg.From] = false
+				c.processLock.Unlock()
+			}
+		case <-ctx.Done():
+			{
+				return
+			}
+		}
+	}
+}
+
+// Leave announces to all peers that the relayer is going offline. Afterwards the relayer
+// only executes processes it coordinates and rejects new processes with ErrDraining.
+func (c *Coordinator) Leave() {
+	c.processLock.Lock()
+	c.draining = true
+	c.leavingPeers[c.host.ID()] = false
+	c.processLock.Unlock()
+
+	log.Info().Msgf("Announcing leave to peers")
+	_ = c.communication.Broadcast(c.host.Peerstore().Peers(), []byte{}, comm.CoordinatorLeaveMsg, comm.LeaveSessionID)
+}
+
+// Draining returns true if the relayer announced it is going offline
+func (c *Coordinator) Draining() bool {
+	c.processLock.Lock()
+	defer c.processLock.Unlock()
+
+	return c.draining
+}
+
+// Drain waits until all pending processes are finished. Processes still pending
+// when the context is done are handed off by sending the fail message to participants
+// of processes coordinated by this relayer, so they stop waiting on it, and cancelled.
+func (c *Coordinator) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(drainPeriod)
+	defer ticker.Stop()
+
+	for {
+		pending := c.PendingProcesses()
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			{
+				for _, process := range pending {
+					if process.Coordinator == c.host.ID() {
+						_ = c.communication.Broadcast(c.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, process.SessionID)
+					}
+					_ = c.CancelProcess(process.SessionID)
+				}
+				return fmt.Errorf("handed off %d pending processes", len(pending))
+			}
+		}
+	}
+}
+
+// activePeers filters out peers that are leaving
+func (c *Coordinator) activePeers(peers []peer.ID) []peer.ID {
+	c.processLock.Lock()
+	defer c.processLock.Unlock()
+
+	active := make([]peer.ID, 0, len(peers))
+	for _, p := range peers {
+		if _, ok := c.leavingPeers[p]; !ok {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+func (c *Coordinator) leaving(p peer.ID) bool {
+	c.processLock.Lock()
+	defer c.processLock.Unlock()
+
+	_, ok := c.leavingPeers[p]
+	return ok
+}
+
+// failedPeers returns peers that caused the process failure, the reason they are
+// blamed for and whether the process can be retried without them
+func (c *Coordinator) failedPeers(err error, tssProcesses []TssProcess, coordinator peer.ID) ([]peer.ID, BlameReason, bool) {
+	var stallError *StallError
+	var tssError *tssLib.Error
+	switch {
+	case errors.Is(err, elector.ErrCoordinatorUnresponsive):
+		return []peer.ID{coordinator}, UnresponsiveBlame, true
+	case errors.Is(err, ErrProcessRetried):
+		return []peer.ID{}, "", true
+	case errors.Is(err, ErrProcessTimeout):
+		peers := []peer.ID{}
+		for _, process := range tssProcesses {
+			peers = append(peers, process.WaitingFor()...)
+		}
+		return peers, TimeoutBlame, true
+	case errors.As(err, &stallError):
+		return stallError.Peers, StallBlame, true
+	case errors.As(err, &tssError) && tssError != nil:
+		peers, err := common.PeersFromParties(tssError.Culprits())
+		if err != nil {
+			return nil, "", false
+		}
+		return peers, CulpritBlame, true
+	default:
+		return nil, "", false
+	}
+}
+
+// blame records the peers that caused the process failure
+func (c *Coordinator) blame(process TssProcess, reason BlameReason, failedPeers []peer.ID) {
+	peers := make([]peer.ID, 0, len(failedPeers))
+	for _, p := range failedPeers {
+		if p != c.host.ID() && !slices.Contains(peers, p) {
+			peers = append(peers, p)
+		}
+	}
+	if len(peers) == 0 {
+		return
+	}
+
+	log.Warn().Str("SessionID", process.SessionID()).Msgf("Blaming peers %s for process failure: %s", peers, reason)
+	c.Blame.Blame(SessionBlame{
+		SessionID:   process.SessionID(),
+		ProcessType: process.ProcessType(),
+		Reason:      reason,
+		Peers:       peers,
+		BlamedAt:    time.Now(),
+	})
+	for _, p := range peers {
+		c.metrics.TrackPeerBlame(p.String(), string(reason))
+	}
+}
+
+// broadcastFailMsg notifies participants that the process failed and
+// whether the coordinator is going to retry it
+func (c *Coordinator) broadcastFailMsg(sessionID string, retry bool) {
+	msgBytes, err := message.MarshalFailMessage(retry)
+	if err != nil {
+		return
+	}
+	_ = c.communication.Broadcast(c.host.Peerstore().Peers(), msgBytes, comm.TssFailMsg, sessionID)
+}
+
+func (c *Coordinator) watchExecution(ctx context.Context, tssProcess TssProcess, coordinator peer.ID) error {
 	failChn := make(chan *comm.WrappedMessage)
 	subscriptionID := c.communication.Subscribe(tssProcess.SessionID(), comm.TssFailMsg, failChn)
 	ticker := time.NewTicker(tssProcess.Timeout())
@@ -148,9 +505,7 @@ func (c *Coordinator) watchExecution(ctx context.Context, tssProcess TssProcess,
 		case <-ticker.C:
 			{
 				log.Error().Str("SessionID", tssProcess.SessionID()).Msgf("Process timed out")
-				tssProcess.Stop()
-				cancel()
-				return nil
+				return ErrProcessTimeout
 			}
 		case <-ctx.Done():
 			{
@@ -163,6 +518,10 @@ func (c *Coordinator) watchExecution(ctx context.Context, tssProcess TssProcess,
 					continue
 				}
 
+				failMsg, err := message.UnmarshalFailMessage(msg.Payload)
+				if err == nil && failMsg.Retry {
+					return ErrProcessRetried
+				}
 				return fmt.Errorf("tss fail message received for process %s", tssProcess.SessionID())
 			}
 		}
@@ -172,15 +531,15 @@ func (c *Coordinator) watchExecution(ctx context.Context, tssProcess TssProcess,
 // start initiates listeners for coordinator and participants with static calculated coordinator
 func (c *Coordinator) start(
 	ctx context.Context,
-	tssProcess TssProcess,
+	tssProcesses []TssProcess,
 	coordinator peer.ID,
 	resultChn chan interface{},
 	excludedPeers []peer.ID,
 ) error {
 	if coordinator.String() == c.host.ID().String() {
-		return c.initiate(ctx, tssProcess, resultChn, excludedPeers)
+		return c.initiate(ctx, tssProcesses, resultChn, excludedPeers)
 	} else {
-		return c.waitForStart(ctx, tssProcess, resultChn, coordinator)
+		return c.waitForStart(ctx, tssProcesses, resultChn, coordinator)
 	}
 }
 
@@ -195,12 +554,15 @@ func (c *Coordinator) broadcastInitiateMsg(sessionID string) {
 // initiate sends initiate message to all peers and waits
 // for ready response. After tss process declares that enough
 // peers are ready, start message is broadcasted and tss process is started.
+// Ready responses of deprioritized peers are only accepted after the first
+// initiate period so other peers are preferred if they are ready.
 func (c *Coordinator) initiate(
 	ctx context.Context,
-	tssProcess TssProcess,
+	tssProcesses []TssProcess,
 	resultChn chan interface{},
 	excludedPeers []peer.ID,
 ) error {
+	tssProcess := tssProcesses[0]
 	readyChan := make(chan *comm.WrappedMessage)
 	readyPeers := make([]peer.ID, 0)
 	readyPeers = append(readyPeers, c.host.ID())
@@ -212,6 +574,8 @@ func (c *Coordinator) initiate(
 	ticker := time.NewTicker(c.InitiatePeriod)
 	defer ticker.Stop()
 	initiateStart := time.Now()
+	deprioritizedPeers := c.Blame.Deprioritized()
+	started := false
 	c.broadcastInitiateMsg(tssProcess.SessionID())
 	for {
 		select {
@@ -220,7 +584,13 @@ func (c *Coordinator) initiate(
 		case wMsg := <-readyChan:
 			{
 				log.Debug().Str("SessionID", tssProcess.SessionID()).Msgf("received ready message from %s", wMsg.From)
-				if !slices.Contains(excludedPeers, wMsg.From) && !slices.Contains(readyPeers, wMsg.From) {
+				if started {
+					continue
+				}
+				if slices.Contains(deprioritizedPeers, wMsg.From) && time.Since(initiateStart) < c.InitiatePeriod {
+					continue
+				}
+				if !slices.Contains(excludedPeers, wMsg.From) && !slices.Contains(readyPeers, wMsg.From) && !c.leaving(wMsg.From) {
 					readyPeers = append(readyPeers, wMsg.From)
 				}
 				ready, err := tssProcess.Ready(readyPeers, excludedPeers)
@@ -231,7 +601,7 @@ func (c *Coordinator) initiate(
 					continue
 				}
 
-				startParams := tssProcess.StartParams(readyPeers)
+				startParams := tssProcess.StartParams(readyPeers, deprioritizedPeers)
 				startMsgBytes, err := message.MarshalStartMessage(startParams)
 				if err != nil {
 					return err
@@ -240,7 +610,8 @@ func (c *Coordinator) initiate(
 				_ = c.communication.Broadcast(c.host.Peerstore().Peers(), startMsgBytes, comm.TssStartMsg, tssProcess.SessionID())
 				c.metrics.RecordInitiateDuration(time.Since(initiateStart))
 				ticker.Stop()
-				go c.startProcess(ctx, tssProcess, true, startParams, resultChn, errChn)
+				started = true
+				go c.startProcesses(ctx, tssProcesses, true, startParams, resultChn, errChn)
 			}
 		case <-ticker.C:
 			{
@@ -258,10 +629,11 @@ func (c *Coordinator) initiate(
 // when it receives the start message.
 func (c *Coordinator) waitForStart(
 	ctx context.Context,
-	tssProcess TssProcess,
+	tssProcesses []TssProcess,
 	resultChn chan interface{},
 	coordinator peer.ID,
 ) error {
+	tssProcess := tssProcesses[0]
 	msgChan := make(chan *comm.WrappedMessage)
 	startMsgChn := make(chan *comm.WrappedMessage)
 
@@ -303,7 +675,7 @@ func (c *Coordinator) waitForStart(
 					return err
 				}
 
-				go c.startProcess(ctx, tssProcess, false, msg.Params, resultChn, errChn)
+				go c.startProcesses(ctx, tssProcesses, false, msg.Params, resultChn, errChn)
 			}
 		case <-ctx.Done():
 			{
@@ -313,14 +685,22 @@ func (c *Coordinator) waitForStart(
 	}
 }
 
-func (c *Coordinator) startProcess(
+// startProcesses runs all tss processes with the same start params and
+// reports the result once all of them are finished
+func (c *Coordinator) startProcesses(
 	ctx context.Context,
-	tssProcess TssProcess,
+	tssProcesses []TssProcess,
 	coordinator bool,
 	startParams []byte,
 	resultChn chan interface{},
 	errChn chan error) {
-	err := tssProcess.Run(ctx, coordinator, resultChn, startParams)
+	p := pool.New().WithErrors()
+	for _, tssProcess := range tssProcesses {
+		p.Go(func() error {
+			return tssProcess.Run(ctx, coordinator, resultChn, startParams)
+		})
+	}
+	err := p.Wait()
 	if errors.Is(err, common.ErrProcessStarted) {
 		return
 	}
@@ -0,0 +1,261 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tssLib "github.com/binance-chain/tss-lib/tss"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/comm/elector"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/sprintertech/sprinter-signing/tss"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
+	"github.com/sprintertech/sprinter-signing/tss/message"
+	mock_tss "github.com/sprintertech/sprinter-signing/tss/mock"
+	tsstest "github.com/sprintertech/sprinter-signing/tss/test"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const sessionID = "1-1"
+
+type CoordinatorTestSuite struct {
+	suite.Suite
+
+	mockMetrics    *mock_tss.MockMetrics
+	hosts          []host.Host
+	communications []*tsstest.TestCommunication
+	coordinators   []*tss.Coordinator
+	processes      []*mock_tss.MockTssProcess
+}
+
+func TestRunCoordinatorTestSuite(t *testing.T) {
+	suite.Run(t, new(CoordinatorTestSuite))
+}
+
+func (s *CoordinatorTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockMetrics = mock_tss.NewMockMetrics(ctrl)
+	s.mockMetrics.EXPECT().StartProcess(gomock.Any()).AnyTimes()
+	s.mockMetrics.EXPECT().RecordInitiateDuration(gomock.Any()).AnyTimes()
+	s.mockMetrics.EXPECT().TrackPeerBlame(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.hosts = []host.Host{}
+	for i := 0; i < 3; i++ {
+		h, err := libp2p.New(libp2p.DisableRelay())
+		s.Nil(err)
+		s.T().Cleanup(func() { _ = h.Close() })
+		s.hosts = append(s.hosts, h)
+	}
+	for _, h := range s.hosts {
+		for _, p := range s.hosts {
+			h.Peerstore().AddAddr(p.ID(), p.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	s.communications = []*tsstest.TestCommunication{}
+	s.coordinators = []*tss.Coordinator{}
+	s.processes = []*mock_tss.MockTssProcess{}
+	for _, h := range s.hosts {
+		communication := &tsstest.TestCommunication{
+			Host:          h,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[h.ID()] = communication
+		s.communications = append(s.communications, communication)
+
+		coordinator := tss.NewCoordinator(h, communication, s.mockMetrics, elector.NewCoordinatorElectorFactory(h, relayer.BullyConfig{}))
+		coordinator.InitiatePeriod = time.Millisecond * 500
+		s.coordinators = append(s.coordinators, coordinator)
+
+		process := mock_tss.NewMockTssProcess(ctrl)
+		process.EXPECT().SessionID().Return(sessionID).AnyTimes()
+		process.EXPECT().ProcessType().Return(common.SigningProcess).AnyTimes()
+		process.EXPECT().Retryable().Return(true).AnyTimes()
+		process.EXPECT().Timeout().Return(time.Minute).AnyTimes()
+		process.EXPECT().Stop().AnyTimes()
+		s.processes = append(s.processes, process)
+	}
+	tsstest.SetupCommunication(communicationMap)
+}
+
+// participate makes the process of a participant run until it is stopped
+// by the coordinator and returns the number of times it was started
+func (s *CoordinatorTestSuite) participate(process *mock_tss.MockTssProcess) *atomic.Int32 {
+	runs := &atomic.Int32{}
+	process.EXPECT().Run(gomock.Any(), false, gomock.Any(), []byte("params")).DoAndReturn(
+		func(ctx context.Context, coordinator bool, resultChn chan interface{}, params []byte) error {
+			runs.Add(1)
+			<-ctx.Done()
+			return nil
+		},
+	).AnyTimes()
+	return runs
+}
+
+// coordinate makes the coordinator process ready once all peers that are not excluded
+// are ready and sends the ready peers of each start to the returned channel
+func (s *CoordinatorTestSuite) coordinate(process *mock_tss.MockTssProcess, culprit peer.ID) chan []peer.ID {
+	startedPeers := make(chan []peer.ID, 2)
+	process.EXPECT().Ready(gomock.Any(), gomock.Any()).DoAndReturn(func(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+		if len(excludedPeers) > 0 {
+			s.Equal([]peer.ID{culprit}, excludedPeers)
+		}
+		return len(readyPeers) == len(s.hosts)-len(excludedPeers), nil
+	}).AnyTimes()
+	process.EXPECT().StartParams(gomock.Any(), gomock.Any()).DoAndReturn(func(readyPeers []peer.ID, deprioritizedPeers []peer.ID) []byte {
+		startedPeers <- readyPeers
+		return []byte("params")
+	}).Times(2)
+	return startedPeers
+}
+
+// execute runs the process on all relayers with the first host as the coordinator
+func (s *CoordinatorTestSuite) execute(ctx context.Context, resultChn chan interface{}) []chan error {
+	errChns := make([]chan error, len(s.coordinators))
+	for i, coordinator := range s.coordinators {
+		errChns[i] = make(chan error, 1)
+		go func() {
+			errChns[i] <- coordinator.Execute(ctx, []tss.TssProcess{s.processes[i]}, resultChn, s.hosts[0].ID())
+		}()
+	}
+	return errChns
+}
+
+func (s *CoordinatorTestSuite) culpritError(culprit peer.ID) error {
+	return tssLib.NewError(fmt.Errorf("invalid share"), "signing", 1, nil, common.CreatePartyID(culprit.String()))
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_RetriesWithoutCulprit() {
+	culprit := s.hosts[2].ID()
+	s.mockMetrics.EXPECT().TrackProcessRetry(string(common.SigningProcess), 1).Times(3)
+	startedPeers := s.coordinate(s.processes[0], culprit)
+	gomock.InOrder(
+		s.processes[0].EXPECT().Run(gomock.Any(), true, gomock.Any(), []byte("params")).DoAndReturn(
+			func(ctx context.Context, coordinator bool, resultChn chan interface{}, params []byte) error {
+				time.Sleep(time.Millisecond * 50)
+				return s.culpritError(culprit)
+			},
+		),
+		s.processes[0].EXPECT().Run(gomock.Any(), true, gomock.Any(), []byte("params")).DoAndReturn(
+			func(ctx context.Context, coordinator bool, resultChn chan interface{}, params []byte) error {
+				time.Sleep(time.Millisecond * 50)
+				resultChn <- "signature"
+				return nil
+			},
+		),
+	)
+	runs1 := s.participate(s.processes[1])
+	runs2 := s.participate(s.processes[2])
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	resultChn := make(chan interface{}, 1)
+	errChns := s.execute(ctx, resultChn)
+
+	s.Nil(<-errChns[0])
+	s.Equal("signature", <-resultChn)
+	s.Len(<-startedPeers, 3)
+	retriedPeers := <-startedPeers
+	s.Len(retriedPeers, 2)
+	s.NotContains(retriedPeers, culprit)
+
+	cancel()
+	s.Nil(<-errChns[1])
+	s.Nil(<-errChns[2])
+	s.Equal(int32(2), runs1.Load())
+	s.Equal(int32(2), runs2.Load())
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_MaxRetriesReached() {
+	s.coordinators[0].MaxRetries = 1
+	culprit := s.hosts[2].ID()
+	s.mockMetrics.EXPECT().TrackProcessRetry(string(common.SigningProcess), 1).Times(3)
+	s.coordinate(s.processes[0], culprit)
+	gomock.InOrder(
+		s.processes[0].EXPECT().Run(gomock.Any(), true, gomock.Any(), []byte("params")).DoAndReturn(
+			func(ctx context.Context, coordinator bool, resultChn chan interface{}, params []byte) error {
+				time.Sleep(time.Millisecond * 50)
+				return s.culpritError(culprit)
+			},
+		),
+		s.processes[0].EXPECT().Run(gomock.Any(), true, gomock.Any(), []byte("params")).DoAndReturn(
+			func(ctx context.Context, coordinator bool, resultChn chan interface{}, params []byte) error {
+				time.Sleep(time.Millisecond * 50)
+				return s.culpritError(s.hosts[1].ID())
+			},
+		),
+	)
+	s.participate(s.processes[1])
+	s.participate(s.processes[2])
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	errChns := s.execute(ctx, make(chan interface{}, 1))
+
+	s.NotNil(<-errChns[0])
+	// participants stop without retrying once they receive the final fail message
+	err := <-errChns[1]
+	s.NotNil(err)
+	s.ErrorContains(err, "tss fail message received")
+	err = <-errChns[2]
+	s.NotNil(err)
+	s.ErrorContains(err, "tss fail message received")
+}
+
+func (s *CoordinatorTestSuite) Test_Execute_ParticipantWaitsForStartAfterRetry() {
+	coordinator := s.hosts[0].ID()
+	s.mockMetrics.EXPECT().TrackProcessRetry(string(common.SigningProcess), 1)
+	gomock.InOrder(
+		s.processes[1].EXPECT().Run(gomock.Any(), false, gomock.Any(), []byte("params")).DoAndReturn(
+			func(ctx context.Context, coordinator bool, resultChn chan interface{}, params []byte) error {
+				<-ctx.Done()
+				return nil
+			},
+		),
+		s.processes[1].EXPECT().Run(gomock.Any(), false, gomock.Any(), []byte("params")).DoAndReturn(
+			func(ctx context.Context, coordinator bool, resultChn chan interface{}, params []byte) error {
+				resultChn <- "signature"
+				return nil
+			},
+		),
+	)
+
+	readyChn := make(chan *comm.WrappedMessage)
+	s.communications[0].Subscribe(sessionID, comm.TssReadyMsg, readyChn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	resultChn := make(chan interface{}, 1)
+	errChn := make(chan error, 1)
+	go func() {
+		errChn <- s.coordinators[1].Execute(ctx, []tss.TssProcess{s.processes[1]}, resultChn, coordinator)
+	}()
+
+	participant := peer.IDSlice{s.hosts[1].ID()}
+	startMsg, _ := message.MarshalStartMessage([]byte("params"))
+	retryMsg, _ := message.MarshalFailMessage(true)
+	_ = s.communications[0].Broadcast(participant, []byte{}, comm.TssInitiateMsg, sessionID)
+	s.Equal(s.hosts[1].ID(), (<-readyChn).From)
+	_ = s.communications[0].Broadcast(participant, startMsg, comm.TssStartMsg, sessionID)
+	_ = s.communications[0].Broadcast(participant, retryMsg, comm.TssFailMsg, sessionID)
+
+	// the participant responds to initiate messages of the retried process again
+	time.Sleep(time.Millisecond * 100)
+	_ = s.communications[0].Broadcast(participant, []byte{}, comm.TssInitiateMsg, sessionID)
+	s.Equal(s.hosts[1].ID(), (<-readyChn).From)
+	_ = s.communications[0].Broadcast(participant, startMsg, comm.TssStartMsg, sessionID)
+
+	s.Equal("signature", <-resultChn)
+	s.Nil(<-errChn)
+}
@@ -28,6 +28,20 @@ type Party interface {
 
 var ErrProcessStarted = errors.New("process already started")
 
+// ErrPartyHalted is returned by tss parties that are intentionally stopped
+// before advancing to the next round
+var ErrPartyHalted = errors.New("party halted")
+
+// ProcessType identifies the kind of a tss process
+type ProcessType string
+
+const (
+	KeygenProcess     ProcessType = "keygen"
+	ResharingProcess  ProcessType = "resharing"
+	SigningProcess    ProcessType = "signing"
+	PresigningProcess ProcessType = "presigning"
+)
+
 // BaseTss contains common variables and methods to
 // all tss processes.
 type BaseTss struct {
@@ -54,7 +68,10 @@ func (b *BaseTss) PopulatePartyStore(parties tss.SortedPartyIDs) {
 }
 
 // ProcessInboundMessages processes messages from tss parties and updates local party accordingly.
+// Messages that tss-lib rejects with culprits end the process with the tss error so the peers
+// can be excluded from retries.
 func (b *BaseTss) ProcessInboundMessages(ctx context.Context, msgChan chan *comm.WrappedMessage) (err error) {
+	errChn := make(chan error, 1)
 	for {
 		select {
 		case wMsg := <-msgChan:
@@ -81,12 +98,26 @@ func (b *BaseTss) ProcessInboundMessages(ctx context.Context, msgChan chan *comm
 						msg.IsBroadcast,
 						new(big.Int).SetBytes([]byte(b.SID)))
 					if !ok {
+						var tssError *tss.Error
+						if errors.As(err, &tssError) && tssError != nil && errors.Is(tssError.Cause(), ErrPartyHalted) {
+							b.Log.Debug().Msgf("Party halted after message from %s", wMsg.From)
+							return
+						}
+
 						b.Log.Error().Err(err).Msgf("Failed updating party with message from %s", wMsg.From)
+						if errors.As(err, &tssError) && tssError != nil && len(tssError.Culprits()) > 0 {
+							select {
+							case errChn <- tssError:
+							default:
+							}
+						}
 						return
 					}
 					b.Log.Debug().Msgf("Updated party with message from %s", wMsg.From)
 				}(wMsg)
 			}
+		case err := <-errChn:
+			return err
 		case <-ctx.Done():
 			return nil
 		}
@@ -149,6 +180,19 @@ func (b *BaseTss) SessionID() string {
 	return b.SID
 }
 
+// WaitingFor returns peers whose messages the tss party is waiting for
+func (b *BaseTss) WaitingFor() []peer.ID {
+	if b.Party == nil {
+		return []peer.ID{}
+	}
+
+	peers, err := PeersFromParties(b.Party.WaitingFor())
+	if err != nil {
+		return []peer.ID{}
+	}
+	return peers
+}
+
 func (b *BaseTss) Timeout() time.Duration {
 	return b.TssTimeout
 }
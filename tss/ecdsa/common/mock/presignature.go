@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./tss/ecdsa/signing/presignature.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./tss/ecdsa/common/mock/presignature.go -source=./tss/ecdsa/signing/presignature.go -package mock_tss
+//
+
+// Package mock_tss is a generated GoMock package.
+package mock_tss
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPresignatureMetrics is a mock of PresignatureMetrics interface.
+type MockPresignatureMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresignatureMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockPresignatureMetricsMockRecorder is the mock recorder for MockPresignatureMetrics.
+type MockPresignatureMetricsMockRecorder struct {
+	mock *MockPresignatureMetrics
+}
+
+// NewMockPresignatureMetrics creates a new mock instance.
+func NewMockPresignatureMetrics(ctrl *gomock.Controller) *MockPresignatureMetrics {
+	mock := &MockPresignatureMetrics{ctrl: ctrl}
+	mock.recorder = &MockPresignatureMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresignatureMetrics) EXPECT() *MockPresignatureMetricsMockRecorder {
+	return m.recorder
+}
+
+// TrackPresignatureInvalidation mocks base method.
+func (m *MockPresignatureMetrics) TrackPresignatureInvalidation(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackPresignatureInvalidation", count)
+}
+
+// TrackPresignatureInvalidation indicates an expected call of TrackPresignatureInvalidation.
+func (mr *MockPresignatureMetricsMockRecorder) TrackPresignatureInvalidation(count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPresignatureInvalidation", reflect.TypeOf((*MockPresignatureMetrics)(nil).TrackPresignatureInvalidation), count)
+}
+
+// TrackPresignatureUsage mocks base method.
+func (m *MockPresignatureMetrics) TrackPresignatureUsage(used bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackPresignatureUsage", used)
+}
+
+// TrackPresignatureUsage indicates an expected call of TrackPresignatureUsage.
+func (mr *MockPresignatureMetricsMockRecorder) TrackPresignatureUsage(used any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPresignatureUsage", reflect.TypeOf((*MockPresignatureMetrics)(nil).TrackPresignatureUsage), used)
+}
+
+// TrackPresignatures mocks base method.
+func (m *MockPresignatureMetrics) TrackPresignatures(available int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackPresignatures", available)
+}
+
+// TrackPresignatures indicates an expected call of TrackPresignatures.
+func (mr *MockPresignatureMetricsMockRecorder) TrackPresignatures(available any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPresignatures", reflect.TypeOf((*MockPresignatureMetrics)(nil).TrackPresignatures), available)
+}
@@ -140,7 +140,7 @@ func (k *Keygen) ValidCoordinators() []peer.ID {
 	return k.Host.Peerstore().Peers()
 }
 
-func (k *Keygen) StartParams(readyPeers []peer.ID) []byte {
+func (k *Keygen) StartParams(readyPeers []peer.ID, deprioritizedPeers []peer.ID) []byte {
 	return []byte{}
 }
 
@@ -172,3 +172,7 @@ func (k *Keygen) processEndMessage(ctx context.Context, endChn chan keygen.Local
 func (k *Keygen) Retryable() bool {
 	return false
 }
+
+func (k *Keygen) ProcessType() common.ProcessType {
+	return common.KeygenProcess
+}
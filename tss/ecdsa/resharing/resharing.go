@@ -178,7 +178,7 @@ func (r *Resharing) ValidCoordinators() []peer.ID {
 }
 
 // StartParams returns threshold and peer subset from the old key to share with new parties.
-func (r *Resharing) StartParams(readyPeers []peer.ID) []byte {
+func (r *Resharing) StartParams(readyPeers []peer.ID, deprioritizedPeers []peer.ID) []byte {
 	oldSubset := common.PeersIntersection(r.key.Peers, r.Host.Peerstore().Peers())
 	startParams := &startParams{
 		OldThreshold: r.key.Threshold,
@@ -265,3 +265,7 @@ func (r *Resharing) sortParties(parties tss.SortedPartyIDs, oldParties tss.Sorte
 func (r *Resharing) Retryable() bool {
 	return false
 }
+
+func (r *Resharing) ProcessType() common.ProcessType {
+	return common.ResharingProcess
+}
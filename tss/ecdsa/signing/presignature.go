@@ -0,0 +1,192 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/exp/slices"
+
+	"github.com/sprintertech/sprinter-signing/keyshare"
+)
+
+var presignatureTTL = time.Hour
+
+type PresignatureMetrics interface {
+	TrackPresignatures(available int)
+	TrackPresignatureUsage(used bool)
+	TrackPresignatureInvalidation(count int)
+}
+
+// Presignature is the state of the signing party after the offline presigning rounds.
+// Peers that generated the presignature can sign any message with it by running
+// only the online signing round.
+type Presignature struct {
+	ID          string
+	Coordinator peer.ID
+	Peers       []peer.ID
+	KeyID       string
+	State       string
+	CreatedAt   time.Time
+}
+
+// stateForMessage returns the party state with the message that should be signed
+func (p Presignature) stateForMessage(msg *big.Int) (string, error) {
+	data, err := signing.StringToMarshalledLocalTempData(p.State)
+	if err != nil {
+		return "", err
+	}
+
+	data.TheMarshalledLocalTempData.M = msg
+	state, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(state), nil
+}
+
+// KeyID identifies the key share presignatures were generated with. Key shares
+// change on resharing which invalidates presignatures of the previous key share.
+func KeyID(key keyshare.ECDSAKeyshare) string {
+	hash := sha256.New()
+	if key.Key.Xi != nil {
+		hash.Write(key.Key.Xi.Bytes())
+	}
+	for _, p := range key.Peers {
+		hash.Write([]byte(p))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// PresignaturePool keeps presignatures in memory until they are used. Presignatures
+// are removed from the pool when they are taken as signing two messages with the
+// same presignature would leak the key share.
+type PresignaturePool struct {
+	lock          sync.Mutex
+	presignatures map[string]Presignature
+	metrics       PresignatureMetrics
+}
+
+func NewPresignaturePool(metrics PresignatureMetrics) *PresignaturePool {
+	return &PresignaturePool{
+		presignatures: make(map[string]Presignature),
+		metrics:       metrics,
+	}
+}
+
+// Store adds the generated presignature to the pool
+func (p *PresignaturePool) Store(presignature Presignature) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.presignatures[presignature.ID] = presignature
+	p.metrics.TrackPresignatures(len(p.presignatures))
+}
+
+// Take removes the presignature with the given ID from the pool
+func (p *PresignaturePool) Take(id string, keyID string) (Presignature, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	presignature, ok := p.presignatures[id]
+	if !ok {
+		return Presignature{}, fmt.Errorf("no presignature found with id %s", id)
+	}
+	delete(p.presignatures, id)
+	p.metrics.TrackPresignatures(len(p.presignatures))
+
+	if !p.valid(presignature, keyID) {
+		return Presignature{}, fmt.Errorf("presignature %s invalidated", id)
+	}
+	return presignature, nil
+}
+
+// Reserve removes the oldest presignature coordinated by the coordinator
+// that can be used by the ready peers from the pool
+func (p *PresignaturePool) Reserve(coordinator peer.ID, readyPeers []peer.ID, keyID string) (Presignature, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	presignature, ok := p.find(coordinator, readyPeers, keyID)
+	if !ok {
+		return Presignature{}, false
+	}
+
+	delete(p.presignatures, presignature.ID)
+	p.metrics.TrackPresignatures(len(p.presignatures))
+	return presignature, true
+}
+
+// Count returns the number of presignatures coordinated by the coordinator
+func (p *PresignaturePool) Count(coordinator peer.ID) int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	count := 0
+	for _, presignature := range p.presignatures {
+		if presignature.Coordinator == coordinator {
+			count++
+		}
+	}
+	return count
+}
+
+// Invalidate removes expired presignatures and presignatures generated with another key share
+func (p *PresignaturePool) Invalidate(keyID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	count := 0
+	for id, presignature := range p.presignatures {
+		if !p.valid(presignature, keyID) {
+			delete(p.presignatures, id)
+			count++
+		}
+	}
+	if count == 0 {
+		return
+	}
+
+	p.metrics.TrackPresignatureInvalidation(count)
+	p.metrics.TrackPresignatures(len(p.presignatures))
+}
+
+// TrackUsage records whether a signing process coordinated by this relayer used a presignature
+func (p *PresignaturePool) TrackUsage(used bool) {
+	p.metrics.TrackPresignatureUsage(used)
+}
+
+func (p *PresignaturePool) find(coordinator peer.ID, readyPeers []peer.ID, keyID string) (Presignature, bool) {
+	var oldest *Presignature
+	for _, presignature := range p.presignatures {
+		if presignature.Coordinator != coordinator || !p.valid(presignature, keyID) {
+			continue
+		}
+		if slices.ContainsFunc(presignature.Peers, func(peer peer.ID) bool {
+			return !slices.Contains(readyPeers, peer)
+		}) {
+			continue
+		}
+
+		if oldest == nil || presignature.CreatedAt.Before(oldest.CreatedAt) {
+			oldest = &presignature
+		}
+	}
+	if oldest == nil {
+		return Presignature{}, false
+	}
+	return *oldest, true
+}
+
+func (p *PresignaturePool) valid(presignature Presignature, keyID string) bool {
+	return presignature.KeyID == keyID && time.Since(presignature.CreatedAt) < presignatureTTL
+}
@@ -0,0 +1,151 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+
+	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/tss"
+)
+
+var presignInterval = 10 * time.Second
+
+type Coordinator interface {
+	Execute(ctx context.Context, tssProcesses []tss.TssProcess, resultChn chan interface{}, coordinator peer.ID) error
+}
+
+// PresignatureGenerator keeps the presignature pool filled with presignatures
+// coordinated by this relayer so signing processes it coordinates only have to
+// run the online signing round.
+type PresignatureGenerator struct {
+	size        int
+	host        host.Host
+	comm        comm.Communication
+	fetcher     SaveDataFetcher
+	coordinator Coordinator
+	pool        *PresignaturePool
+}
+
+func NewPresignatureGenerator(
+	size int,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+	coordinator Coordinator,
+	pool *PresignaturePool,
+) *PresignatureGenerator {
+	return &PresignatureGenerator{
+		size:        size,
+		host:        host,
+		comm:        comm,
+		fetcher:     fetcher,
+		coordinator: coordinator,
+		pool:        pool,
+	}
+}
+
+// Run invalidates presignatures of previous key shares and generates
+// presignatures until the pool contains the configured number of them.
+func (g *PresignatureGenerator) Run(ctx context.Context) {
+	ticker := time.NewTicker(presignInterval)
+	defer ticker.Stop()
+
+	for {
+		g.fill(ctx)
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Listen joins presigning sessions announced by other relayers
+func (g *PresignatureGenerator) Listen(ctx context.Context) {
+	msgChn := make(chan *comm.WrappedMessage)
+	subID := g.comm.Subscribe(comm.PresignSessionID, comm.PresignMsg, msgChn)
+	defer g.comm.UnSubscribe(subID)
+
+	for {
+		select {
+		case wMsg := <-msgChn:
+			{
+				sessionID := string(wMsg.Payload)
+				if !strings.HasPrefix(sessionID, g.sessionPrefix(wMsg.From)) {
+					log.Warn().Msgf("Received invalid presigning session %s from %s", sessionID, wMsg.From)
+					continue
+				}
+
+				go func() {
+					err := g.execute(ctx, sessionID, wMsg.From)
+					if err != nil {
+						log.Warn().Str("SessionID", sessionID).Msgf("Failed presigning: %s", err)
+					}
+				}()
+			}
+		case <-ctx.Done():
+			{
+				return
+			}
+		}
+	}
+}
+
+func (g *PresignatureGenerator) fill(ctx context.Context) {
+	g.fetcher.LockKeyshare()
+	key, err := g.fetcher.GetKeyshare()
+	g.fetcher.UnlockKeyshare()
+	if err != nil {
+		log.Debug().Msgf("Skipping presigning without key share: %s", err)
+		return
+	}
+	g.pool.Invalidate(KeyID(key))
+
+	for ctx.Err() == nil {
+		count := g.pool.Count(g.host.ID())
+		if count >= g.size {
+			return
+		}
+
+		sessionID := fmt.Sprintf("%s%d", g.sessionPrefix(g.host.ID()), time.Now().UnixNano())
+		err := g.comm.Broadcast(g.host.Peerstore().Peers(), []byte(sessionID), comm.PresignMsg, comm.PresignSessionID)
+		if err != nil {
+			log.Warn().Msgf("Failed announcing presigning session: %s", err)
+			return
+		}
+
+		err = g.execute(ctx, sessionID, g.host.ID())
+		if err != nil {
+			log.Warn().Str("SessionID", sessionID).Msgf("Failed presigning: %s", err)
+			return
+		}
+		if g.pool.Count(g.host.ID()) <= count {
+			return
+		}
+	}
+}
+
+func (g *PresignatureGenerator) execute(ctx context.Context, sessionID string, coordinator peer.ID) error {
+	presigning, err := NewPresigning(sessionID, coordinator, g.host, g.comm, g.fetcher, g.pool)
+	if err != nil {
+		return err
+	}
+
+	return g.coordinator.Execute(ctx, []tss.TssProcess{presigning}, make(chan interface{}, 1), coordinator)
+}
+
+// sessionPrefix returns the prefix of presigning sessions coordinated by the peer
+func (g *PresignatureGenerator) sessionPrefix(coordinator peer.ID) string {
+	return fmt.Sprintf("%s-%s-", comm.PresignSessionID, coordinator)
+}
@@ -0,0 +1,107 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	mock_tss "github.com/sprintertech/sprinter-signing/tss/ecdsa/common/mock"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PresignaturePoolTestSuite struct {
+	suite.Suite
+
+	mockMetrics *mock_tss.MockPresignatureMetrics
+
+	coordinator peer.ID
+	peer        peer.ID
+	otherPeer   peer.ID
+
+	pool *signing.PresignaturePool
+}
+
+func TestRunPresignaturePoolTestSuite(t *testing.T) {
+	suite.Run(t, new(PresignaturePoolTestSuite))
+}
+
+func (s *PresignaturePoolTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockMetrics = mock_tss.NewMockPresignatureMetrics(ctrl)
+	s.mockMetrics.EXPECT().TrackPresignatures(gomock.Any()).AnyTimes()
+
+	s.coordinator, _ = peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	s.peer, _ = peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	s.otherPeer, _ = peer.Decode("QmYayosTHxL2xa4jyrQ2PmbhGbrkSxsGM1kzXLTT8SsLVy")
+	s.pool = signing.NewPresignaturePool(s.mockMetrics)
+}
+
+func (s *PresignaturePoolTestSuite) presignature(id string, keyID string, createdAt time.Time, peers ...peer.ID) signing.Presignature {
+	return signing.Presignature{
+		ID:          id,
+		Coordinator: s.coordinator,
+		Peers:       peers,
+		KeyID:       keyID,
+		State:       "state",
+		CreatedAt:   createdAt,
+	}
+}
+
+func (s *PresignaturePoolTestSuite) Test_Take_PresignatureUsedOnlyOnce() {
+	s.pool.Store(s.presignature("1", "key", time.Now(), s.coordinator, s.peer))
+
+	presignature, err := s.pool.Take("1", "key")
+	s.Nil(err)
+	s.Equal("1", presignature.ID)
+
+	_, err = s.pool.Take("1", "key")
+	s.NotNil(err)
+}
+
+func (s *PresignaturePoolTestSuite) Test_Take_PresignatureOfPreviousKey() {
+	s.pool.Store(s.presignature("1", "key", time.Now(), s.coordinator, s.peer))
+
+	_, err := s.pool.Take("1", "newKey")
+
+	s.NotNil(err)
+	s.Equal(0, s.pool.Count(s.coordinator))
+}
+
+func (s *PresignaturePoolTestSuite) Test_Reserve_OldestPresignatureOfReadyPeers() {
+	s.pool.Store(s.presignature("1", "key", time.Now().Add(-time.Minute), s.coordinator, s.otherPeer))
+	s.pool.Store(s.presignature("2", "key", time.Now().Add(-time.Second), s.coordinator, s.peer))
+	s.pool.Store(s.presignature("3", "key", time.Now(), s.coordinator, s.peer))
+
+	presignature, ok := s.pool.Reserve(s.coordinator, []peer.ID{s.coordinator, s.peer}, "key")
+
+	s.True(ok)
+	s.Equal("2", presignature.ID)
+	s.Equal(2, s.pool.Count(s.coordinator))
+}
+
+func (s *PresignaturePoolTestSuite) Test_Reserve_NoPresignatureOfCoordinator() {
+	s.pool.Store(s.presignature("1", "key", time.Now(), s.coordinator, s.peer))
+
+	_, ok := s.pool.Reserve(s.peer, []peer.ID{s.coordinator, s.peer}, "key")
+
+	s.False(ok)
+	s.Equal(1, s.pool.Count(s.coordinator))
+}
+
+func (s *PresignaturePoolTestSuite) Test_Invalidate_RemovesExpiredAndPreviousKeyPresignatures() {
+	s.mockMetrics.EXPECT().TrackPresignatureInvalidation(2)
+	s.pool.Store(s.presignature("1", "key", time.Now().Add(-2*time.Hour), s.coordinator, s.peer))
+	s.pool.Store(s.presignature("2", "previousKey", time.Now(), s.coordinator, s.peer))
+	s.pool.Store(s.presignature("3", "key", time.Now(), s.coordinator, s.peer))
+
+	s.pool.Invalidate("key")
+
+	s.Equal(1, s.pool.Count(s.coordinator))
+	_, err := s.pool.Take("3", "key")
+	s.Nil(err)
+}
@@ -0,0 +1,282 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	tssCommon "github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"golang.org/x/exp/slices"
+
+	"github.com/sprintertech/sprinter-signing/comm"
+	"github.com/sprintertech/sprinter-signing/keyshare"
+	errors "github.com/sprintertech/sprinter-signing/tss"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
+	"github.com/sprintertech/sprinter-signing/tss/util"
+)
+
+// presignRounds is the number of signing rounds that do not depend on the signed message
+const presignRounds = 3
+
+// Presigning runs the presigning rounds of the signing protocol and stores the
+// party state to the presignature pool instead of signing a message.
+type Presigning struct {
+	common.BaseTss
+	coordinator    peer.ID
+	key            keyshare.ECDSAKeyshare
+	presignatures  *PresignaturePool
+	subscriptionID comm.SubscriptionID
+}
+
+func NewPresigning(
+	sessionID string,
+	coordinator peer.ID,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+	presignatures *PresignaturePool,
+) (*Presigning, error) {
+	fetcher.LockKeyshare()
+	defer fetcher.UnlockKeyshare()
+	key, err := fetcher.GetKeyshare()
+	if err != nil {
+		return nil, err
+	}
+
+	partyStore := make(map[string]*tss.PartyID)
+	return &Presigning{
+		BaseTss: common.BaseTss{
+			PartyStore:    partyStore,
+			Host:          host,
+			Communication: comm,
+			Peers:         key.Peers,
+			SID:           sessionID,
+			Started:       false,
+			Mux:           &sync.Mutex{},
+			Log:           log.With().Str("SessionID", sessionID).Str("Process", "presigning").Logger(),
+			Cancel:        func() {},
+			TssTimeout:    time.Second * 8,
+		},
+		coordinator:   coordinator,
+		key:           key,
+		presignatures: presignatures,
+	}, nil
+}
+
+// Run initializes the signing party and runs the presigning rounds.
+// The party is halted before the first round that depends on the signed message.
+func (p *Presigning) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	p.Mux.Lock()
+	if p.Started {
+		p.Mux.Unlock()
+		p.Log.Warn().Msgf("Presigning already started")
+		return common.ErrProcessStarted
+	}
+	p.Started = true
+	p.Mux.Unlock()
+
+	ctx, p.Cancel = context.WithCancel(ctx)
+
+	var peerSubset []peer.ID
+	err := json.Unmarshal(params, &peerSubset)
+	if err != nil {
+		return err
+	}
+	if !util.IsParticipant(p.Host.ID(), peerSubset) {
+		return &errors.SubsetError{Peer: p.Host.ID()}
+	}
+
+	p.Peers = peerSubset
+	parties := common.PartiesFromPeers(p.Peers)
+	p.PopulatePartyStore(parties)
+	pCtx := tss.NewPeerContext(parties)
+	tssParams, err := tss.NewParameters(tss.S256(), pCtx, p.PartyStore[p.Host.ID().String()], len(parties), p.key.Threshold)
+	if err != nil {
+		return err
+	}
+
+	stateChn := make(chan string, 1)
+	outChn := make(chan tss.Message)
+	party, err := signing.NewLocalStatefulParty(
+		big.NewInt(0),
+		tssParams,
+		p.key.Key,
+		big.NewInt(0),
+		outChn,
+		make(chan tssCommon.SignatureData),
+		func(party tss.StatefulParty, msg tss.ParsedMessage) (bool, *tss.Error) {
+			if party.Round().RoundNumber() < presignRounds {
+				return true, nil
+			}
+
+			state, err := party.Dehydrate()
+			if err != nil {
+				return false, err
+			}
+			select {
+			case stateChn <- state:
+			default:
+			}
+			return false, party.WrapError(common.ErrPartyHalted)
+		},
+		new(big.Int).SetBytes([]byte(p.SID)))
+	if err != nil {
+		return err
+	}
+	p.Party = &presigningParty{StatefulParty: party}
+
+	msgChn := make(chan *comm.WrappedMessage)
+	p.subscriptionID = p.Communication.Subscribe(p.SessionID(), comm.TssKeySignMsg, msgChn)
+
+	g := pool.New().WithContext(ctx).WithCancelOnError()
+	g.Go(func(ctx context.Context) error { return p.ProcessOutboundMessages(ctx, outChn, comm.TssKeySignMsg) })
+	g.Go(func(ctx context.Context) error { return p.ProcessInboundMessages(ctx, msgChn) })
+	g.Go(func(ctx context.Context) error { return p.processPresignature(ctx, stateChn) })
+
+	p.Log.Info().Msgf("Started presigning process")
+
+	tssError := party.Start()
+	if tssError != nil {
+		return tssError
+	}
+
+	return g.Wait()
+}
+
+// Stop ends all subscriptions created when starting the tss process.
+func (p *Presigning) Stop() {
+	p.Log.Info().Msgf("Stopping tss process.")
+	p.Communication.UnSubscribe(p.subscriptionID)
+	p.Cancel()
+}
+
+// Ready returns true if threshold+1 parties are ready to start the presigning process.
+func (p *Presigning) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+	return len(readyParticipants(p.key, readyPeers)) == p.key.Threshold+1, nil
+}
+
+// ValidCoordinators returns only peers that have a valid keyshare
+func (p *Presigning) ValidCoordinators() []peer.ID {
+	return p.key.Peers
+}
+
+// StartParams returns peer subset for this tss process. The coordinator is always
+// part of the subset as presignatures can only be used by processes it coordinates.
+func (p *Presigning) StartParams(readyPeers []peer.ID, deprioritizedPeers []peer.ID) []byte {
+	readyPeers = slices.DeleteFunc(readyParticipants(p.key, readyPeers), func(peer peer.ID) bool {
+		return peer == p.Host.ID()
+	})
+	peerSubset := append(
+		[]peer.ID{p.Host.ID()},
+		selectPeers(readyPeers, deprioritizedPeers, p.SessionID(), p.key.Threshold)...,
+	)
+
+	paramBytes, _ := json.Marshal(peerSubset)
+	return paramBytes
+}
+
+func (p *Presigning) Retryable() bool {
+	return false
+}
+
+func (p *Presigning) ProcessType() common.ProcessType {
+	return common.PresigningProcess
+}
+
+// processPresignature stores the party state to the presignature pool
+// once the presigning rounds are finished.
+func (p *Presigning) processPresignature(ctx context.Context, stateChn chan string) error {
+	defer p.Cancel()
+	select {
+	case state := <-stateChn:
+		{
+			p.Log.Info().Msg("Successfully generated presignature")
+
+			p.presignatures.Store(Presignature{
+				ID:          p.SID,
+				Coordinator: p.coordinator,
+				Peers:       p.Peers,
+				KeyID:       KeyID(p.key),
+				State:       state,
+				CreatedAt:   time.Now(),
+			})
+			return nil
+		}
+	case <-ctx.Done():
+		{
+			return nil
+		}
+	}
+}
+
+// presigningParty delays messages of the last presigning round until the party
+// reaches it. Messages stored in advance would let the party advance past the last
+// presigning round without halting and sign the placeholder message with the presignature.
+type presigningParty struct {
+	tss.StatefulParty
+
+	lock    sync.Mutex
+	pending []tss.ParsedMessage
+}
+
+func (p *presigningParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool, sessionID *big.Int) (bool, *tss.Error) {
+	sessionID = tss.ExpandSessionID(sessionID, len(tss.S256().Params().N.Bytes()))
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast, sessionID)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch msg.Content().(type) {
+	case *signing.PreSignRound1Message, *signing.PreSignRound2Message:
+	case *signing.PreSignRound3Message:
+		if p.round() < presignRounds {
+			p.pending = append(p.pending, msg)
+			return true, nil
+		}
+	default:
+		return false, p.WrapError(fmt.Errorf("unexpected presigning message %T", msg.Content()), from)
+	}
+
+	ok, tssErr := p.Update(msg)
+	if !ok || p.round() < presignRounds {
+		return ok, tssErr
+	}
+
+	pending := p.pending
+	p.pending = nil
+	for _, msg := range pending {
+		ok, tssErr = p.Update(msg)
+		if !ok {
+			return ok, tssErr
+		}
+	}
+	return true, nil
+}
+
+func (p *presigningParty) round() int {
+	round := p.Round()
+	if round == nil {
+		return 0
+	}
+	return round.RoundNumber()
+}
@@ -16,12 +16,14 @@ import (
 	"github.com/binance-chain/tss-lib/ecdsa/signing"
 	"github.com/binance-chain/tss-lib/tss"
 	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
 	"github.com/sourcegraph/conc/pool"
 	"golang.org/x/exp/slices"
 
+	"github.com/sprintertech/sprinter-signing/audit"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sprintertech/sprinter-signing/keyshare"
 	errors "github.com/sprintertech/sprinter-signing/tss"
@@ -36,6 +38,11 @@ type SaveDataFetcher interface {
 	UnlockKeyshare()
 }
 
+// AuditLogger records digests signed by the MPC key
+type AuditLogger interface {
+	Append(entry audit.Entry) error
+}
+
 type EcdsaSignature struct {
 	Signature []byte
 	ID        string
@@ -48,6 +55,19 @@ type Signing struct {
 	msg            *big.Int
 	resultChn      chan interface{}
 	subscriptionID comm.SubscriptionID
+
+	auditLog  AuditLogger
+	request   audit.Request
+	startedAt time.Time
+
+	presignatures *PresignaturePool
+	presignature  *Presignature
+}
+
+// startParams are sent by the coordinator when the signing process uses a presignature
+type startParams struct {
+	Peers        []peer.ID `json:"peers"`
+	Presignature string    `json:"presignature"`
 }
 
 func NewSigning(
@@ -103,9 +123,10 @@ func (s *Signing) Run(
 
 	s.coordinator = coordinator
 	s.resultChn = resultChn
+	s.startedAt = time.Now()
 	ctx, s.Cancel = context.WithCancel(ctx)
 
-	peerSubset, err := s.unmarshallStartParams(params)
+	peerSubset, presignatureID, err := s.unmarshallStartParams(params)
 	if err != nil {
 		return err
 	}
@@ -123,20 +144,30 @@ func (s *Signing) Run(
 		return err
 	}
 
+	var presignature Presignature
+	if presignatureID != "" {
+		presignature, err = s.takePresignature(presignatureID)
+		if err != nil {
+			return err
+		}
+	}
+
 	sigChn := make(chan tssCommon.SignatureData)
 	outChn := make(chan tss.Message)
 	kdd := big.NewInt(0)
-	s.Party, err = signing.NewLocalParty(
+	party, err := signing.NewLocalStatefulParty(
 		s.msg,
 		tssParams,
 		s.key.Key,
 		kdd,
 		outChn,
 		sigChn,
+		nil,
 		new(big.Int).SetBytes([]byte(s.SID)))
 	if err != nil {
 		return err
 	}
+	s.Party = party
 
 	msgChn := make(chan *comm.WrappedMessage)
 	s.subscriptionID = s.Communication.Subscribe(s.SessionID(), comm.TssKeySignMsg, msgChn)
@@ -147,9 +178,23 @@ func (s *Signing) Run(
 	p.Go(func(ctx context.Context) error { return s.processEndMessage(ctx, sigChn) })
 	p.Go(func(ctx context.Context) error { return s.monitorSigning(ctx) })
 
-	s.Log.Info().Msgf("Started signing process for message %s", s.msg.Text(16))
+	if presignatureID == "" {
+		s.Log.Info().Msgf("Started signing process for message %s", s.msg.Text(16))
 
-	tssError := s.Party.Start()
+		tssError := party.Start()
+		if tssError != nil {
+			return tssError
+		}
+		return p.Wait()
+	}
+
+	s.Log.Info().Msgf("Started signing process for message %s with presignature %s", s.msg.Text(16), presignatureID)
+
+	state, err := presignature.stateForMessage(s.msg)
+	if err != nil {
+		return err
+	}
+	tssError := party.Restart(presignRounds+1, state)
 	if tssError != nil {
 		return tssError
 	}
@@ -157,16 +202,50 @@ func (s *Signing) Run(
 	return p.Wait()
 }
 
+// Audit records the digest with the signing request to the audit log once it is signed
+func (s *Signing) Audit(auditLog AuditLogger, request audit.Request) {
+	s.auditLog = auditLog
+	s.request = request
+}
+
+// UsePresignatures enables signing with presignatures from the pool. Only the online
+// signing round is run if the coordinator has a presignature for the ready peers.
+func (s *Signing) UsePresignatures(presignatures *PresignaturePool) {
+	s.presignatures = presignatures
+}
+
 // Stop ends all subscriptions created when starting the tss process.
+// Stopped signing processes can be started again with a different coordinator.
 func (s *Signing) Stop() {
 	s.Log.Info().Msgf("Stopping tss process.")
 	s.Communication.UnSubscribe(s.subscriptionID)
 	s.Cancel()
+
+	s.Mux.Lock()
+	s.Started = false
+	s.presignature = nil
+	s.Mux.Unlock()
 }
 
-// Ready returns true if threshold+1 parties are ready to start the signing process.
+// Ready returns true if threshold+1 parties are ready to start the signing process
+// or if there is a presignature that can be used by the ready parties. The presignature
+// is reserved for this process so it can not be used by any other process.
 func (s *Signing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
-	readyPeers = s.readyParticipants(readyPeers)
+	readyPeers = readyParticipants(s.key, readyPeers)
+	if s.presignatures != nil {
+		s.Mux.Lock()
+		defer s.Mux.Unlock()
+
+		if s.presignature != nil {
+			return true, nil
+		}
+		presignature, ok := s.presignatures.Reserve(s.Host.ID(), readyPeers, KeyID(s.key))
+		if ok {
+			s.presignature = &presignature
+			return true, nil
+		}
+	}
+
 	return len(readyPeers) == s.key.Threshold+1, nil
 }
 
@@ -177,33 +256,68 @@ func (s *Signing) ValidCoordinators() []peer.ID {
 
 // StartParams returns peer subset for this tss process. It is calculated
 // by sorting hashes of peer IDs and session ID and chosing ready peers alphabetically
-// until threshold is satisfied.
-func (s *Signing) StartParams(readyPeers []peer.ID) []byte {
-	readyPeers = s.readyParticipants(readyPeers)
-	peers := []peer.ID{}
-	peers = append(peers, readyPeers...)
-
-	sortedPeers := util.SortPeersForSession(peers, s.SessionID())
-	peerSubset := []peer.ID{}
-	for _, peer := range sortedPeers {
-		peerSubset = append(peerSubset, peer.ID)
-		if len(peerSubset) == s.key.Threshold+1 {
-			break
-		}
+// until threshold is satisfied. Deprioritized peers are only chosen if there are
+// not enough other ready peers.
+// Peers that generated the reserved presignature are sent with the presignature ID instead.
+func (s *Signing) StartParams(readyPeers []peer.ID, deprioritizedPeers []peer.ID) []byte {
+	s.Mux.Lock()
+	presignature := s.presignature
+	s.Mux.Unlock()
+	if s.presignatures != nil {
+		s.presignatures.TrackUsage(presignature != nil)
+	}
+	if presignature != nil {
+		paramBytes, _ := json.Marshal(startParams{
+			Peers:        presignature.Peers,
+			Presignature: presignature.ID,
+		})
+		return paramBytes
 	}
 
+	peerSubset := selectPeers(readyParticipants(s.key, readyPeers), deprioritizedPeers, s.SessionID(), s.key.Threshold+1)
 	paramBytes, _ := json.Marshal(peerSubset)
 	return paramBytes
 }
 
-func (s *Signing) unmarshallStartParams(paramBytes []byte) ([]peer.ID, error) {
+// unmarshallStartParams returns the peer subset and the presignature ID if the
+// coordinator selected a presignature for the process
+func (s *Signing) unmarshallStartParams(paramBytes []byte) ([]peer.ID, string, error) {
 	var peerSubset []peer.ID
 	err := json.Unmarshal(paramBytes, &peerSubset)
+	if err == nil {
+		return peerSubset, "", nil
+	}
+
+	var params startParams
+	err = json.Unmarshal(paramBytes, &params)
 	if err != nil {
-		return []peer.ID{}, err
+		return []peer.ID{}, "", err
 	}
+	return params.Peers, params.Presignature, nil
+}
+
+// takePresignature removes the presignature from the pool so it is never used twice.
+// The coordinator uses the presignature it reserved when the process was ready.
+func (s *Signing) takePresignature(id string) (Presignature, error) {
+	s.Mux.Lock()
+	defer s.Mux.Unlock()
 
-	return peerSubset, nil
+	if s.presignature != nil && s.presignature.ID == id {
+		presignature := *s.presignature
+		s.presignature = nil
+		return presignature, nil
+	}
+	if s.presignatures == nil {
+		return Presignature{}, fmt.Errorf("presignatures not enabled")
+	}
+	presignature, err := s.presignatures.Take(id, KeyID(s.key))
+	if err != nil {
+		return Presignature{}, err
+	}
+	if !slices.Equal(presignature.Peers, s.Peers) {
+		return Presignature{}, fmt.Errorf("presignature %s generated by different peers", id)
+	}
+	return presignature, nil
 }
 
 // processEndMessage routes signature to result channel.
@@ -222,6 +336,7 @@ func (s *Signing) processEndMessage(ctx context.Context, endChn chan tssCommon.S
 				es = append(es[:], sig.SignatureRecovery...)
 				es[len(es)-1] += 27 // Transform V from 0/1 to 27/28
 
+				s.audit()
 				s.resultChn <- EcdsaSignature{
 					Signature: es,
 					ID:        s.SID,
@@ -242,10 +357,10 @@ func (s *Signing) processEndMessage(ctx context.Context, endChn chan tssCommon.S
 }
 
 // readyParticipants returns all ready peers that contain a valid key share
-func (s *Signing) readyParticipants(readyPeers []peer.ID) []peer.ID {
+func readyParticipants(key keyshare.ECDSAKeyshare, readyPeers []peer.ID) []peer.ID {
 	readyParticipants := make([]peer.ID, 0)
 	for _, peer := range readyPeers {
-		if !slices.Contains(s.key.Peers, peer) {
+		if !slices.Contains(key.Peers, peer) {
 			continue
 		}
 
@@ -255,10 +370,38 @@ func (s *Signing) readyParticipants(readyPeers []peer.ID) []peer.ID {
 	return readyParticipants
 }
 
+// selectPeers sorts peers by hashes of peer IDs and session ID with deprioritized
+// peers last and returns the first size peers
+func selectPeers(peers []peer.ID, deprioritizedPeers []peer.ID, sessionID string, size int) []peer.ID {
+	sortedPeers := []peer.ID{}
+	lowPriorityPeers := []peer.ID{}
+	for _, peer := range util.SortPeersForSession(peers, sessionID) {
+		if slices.Contains(deprioritizedPeers, peer.ID) {
+			lowPriorityPeers = append(lowPriorityPeers, peer.ID)
+			continue
+		}
+		sortedPeers = append(sortedPeers, peer.ID)
+	}
+	sortedPeers = append(sortedPeers, lowPriorityPeers...)
+
+	peerSubset := []peer.ID{}
+	for _, peer := range sortedPeers {
+		if len(peerSubset) == size {
+			break
+		}
+		peerSubset = append(peerSubset, peer)
+	}
+	return peerSubset
+}
+
 func (s *Signing) Retryable() bool {
 	return true
 }
 
+func (s *Signing) ProcessType() common.ProcessType {
+	return common.SigningProcess
+}
+
 // monitorSigning checks if the process is stuck and waiting for peers and sends an error
 // if it is
 func (s *Signing) monitorSigning(ctx context.Context) error {
@@ -271,10 +414,11 @@ func (s *Signing) monitorSigning(ctx context.Context) error {
 		case <-ticker.C:
 			{
 				if len(waitingFor) != 0 && reflect.DeepEqual(s.Party.WaitingFor(), waitingFor) {
-					err := &comm.CommunicationError{
-						Err: fmt.Errorf("waiting for peers %s", waitingFor),
+					peers, err := common.PeersFromParties(waitingFor)
+					if err != nil {
+						return err
 					}
-					return err
+					return &errors.StallError{Peers: peers}
 				}
 
 				waitingFor = s.Party.WaitingFor()
@@ -287,6 +431,25 @@ func (s *Signing) monitorSigning(ctx context.Context) error {
 	}
 }
 
+// audit records the signed digest to the audit log if auditing is enabled
+func (s *Signing) audit() {
+	if s.auditLog == nil {
+		return
+	}
+
+	err := s.auditLog.Append(audit.Entry{
+		Digest:    hexutil.Encode(ethCommon.LeftPadBytes(s.msg.Bytes(), 32)),
+		SessionID: s.SID,
+		Request:   s.request,
+		Peers:     s.Peers,
+		StartedAt: s.startedAt,
+		SignedAt:  time.Now(),
+	})
+	if err != nil {
+		s.Log.Error().Err(err).Msgf("Failed recording signed digest to audit log")
+	}
+}
+
 func (s *Signing) distributeSignature(sig []byte) error {
 	if s.coordinator {
 		return nil
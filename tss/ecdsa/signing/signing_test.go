@@ -5,21 +5,28 @@ package signing_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/sprintertech/sprinter-signing/comm"
 	"github.com/sprintertech/sprinter-signing/comm/elector"
 	"github.com/sprintertech/sprinter-signing/keyshare"
 	"github.com/sprintertech/sprinter-signing/tss"
+	"github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
+	mock_tss "github.com/sprintertech/sprinter-signing/tss/ecdsa/common/mock"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/keygen"
 	"github.com/sprintertech/sprinter-signing/tss/ecdsa/signing"
 	tsstest "github.com/sprintertech/sprinter-signing/tss/test"
+	"github.com/sprintertech/sprinter-signing/tss/util"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 )
 
 type SigningTestSuite struct {
@@ -134,6 +141,114 @@ func (s *SigningTestSuite) Test_ValidSigningProcess_ManualCoordinator() {
 	s.Nil(err)
 }
 
+func (s *SigningTestSuite) Test_ValidSigningProcess_CoordinatorFailover() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	// the coordinator never starts the process so it does not respond to pings
+	offlineCoordinator := s.Hosts[2].ID()
+	for i, host := range s.Hosts[:2] {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+
+		msgBytes := []byte("Message")
+		msg := big.NewInt(0)
+		msg.SetBytes(msgBytes)
+		signing, err := signing.NewSigning(msg, "signing3", "signing3", host, &communication, fetcher)
+		if err != nil {
+			panic(err)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinator := tss.NewCoordinator(host, &communication, s.MockMetrics, electorFactory)
+		coordinator.Electors[common.SigningProcess] = elector.Bully
+		coordinators = append(coordinators, coordinator)
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+	s.MockMetrics.EXPECT().TrackProcessRetry(string(common.SigningProcess), 1).Times(len(coordinators))
+
+	resultChn := make(chan interface{}, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn, offlineCoordinator)
+		})
+	}
+
+	sig1 := <-resultChn
+	sig2 := <-resultChn
+	s.NotNil(sig1)
+	s.Equal(sig1, sig2)
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+}
+
+func (s *SigningTestSuite) Test_ValidBatchSigningProcess() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := [][]tss.TssProcess{}
+
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+
+		batch := []tss.TssProcess{}
+		for j := 0; j < 2; j++ {
+			msg := new(big.Int).SetBytes([]byte(fmt.Sprintf("Message%d", j)))
+			sessionID := fmt.Sprintf("signing%d", j)
+			signing, err := signing.NewSigning(msg, sessionID, sessionID, host, &communication, fetcher)
+			if err != nil {
+				panic(err)
+			}
+			batch = append(batch, signing)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, s.MockMetrics, electorFactory))
+		processes = append(processes, batch)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, processes[i], resultChn, peer.ID(""))
+		})
+	}
+
+	sigs := make(map[string]interface{})
+	for i := 0; i < 4; i++ {
+		sig := (<-resultChn).(signing.EcdsaSignature)
+		sigs[sig.ID] = sig
+	}
+	s.Len(sigs, 2)
+	s.Contains(sigs, "signing0")
+	s.Contains(sigs, "signing1")
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+}
+
 func (s *SigningTestSuite) Test_SigningTimeout() {
 	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
 	coordinators := []*tss.Coordinator{}
@@ -161,6 +276,7 @@ func (s *SigningTestSuite) Test_SigningTimeout() {
 		processes = append(processes, signing)
 	}
 	tsstest.SetupCommunication(communicationMap)
+	s.MockMetrics.EXPECT().TrackProcessRetry(string(common.SigningProcess), gomock.Any()).Times(len(coordinators) * coordinators[0].MaxRetries)
 
 	resultChn := make(chan interface{})
 	pool := pool.New().WithContext(context.Background())
@@ -208,3 +324,231 @@ func (s *SigningTestSuite) Test_PendingProcessExists() {
 	err := pool.Wait()
 	s.NotNil(err)
 }
+
+func (s *SigningTestSuite) Test_CancelPendingProcess() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+
+		msgBytes := []byte("Message")
+		msg := big.NewInt(0)
+		msg.SetBytes(msgBytes)
+		signing, err := signing.NewSigning(msg, "signing4", "signing4", host, &communication, fetcher)
+		if err != nil {
+			panic(err)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, s.MockMetrics, electorFactory))
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	// only a single party executes the process so it is never started
+	errChn := make(chan error)
+	go func() {
+		errChn <- coordinators[0].Execute(context.Background(), []tss.TssProcess{processes[0]}, make(chan interface{}), s.Hosts[0].ID())
+	}()
+	time.Sleep(time.Millisecond * 100)
+
+	pending := coordinators[0].PendingProcesses()
+	s.Len(pending, 1)
+	s.Equal("signing4", pending[0].SessionID)
+	s.Equal(s.Hosts[0].ID(), pending[0].Coordinator)
+
+	err := coordinators[0].CancelProcess("signing4")
+	s.Nil(err)
+	s.ErrorIs(<-errChn, tss.ErrProcessCancelled)
+	s.Len(coordinators[0].PendingProcesses(), 0)
+	s.NotNil(coordinators[0].CancelProcess("signing4"))
+}
+
+func (s *SigningTestSuite) Test_Leave_RejectsProcessesCoordinatedByPeers() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+
+		msg := new(big.Int).SetBytes([]byte("Message"))
+		signing, err := signing.NewSigning(msg, "signing5", "signing5", host, &communication, fetcher)
+		if err != nil {
+			panic(err)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, s.MockMetrics, electorFactory))
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	s.False(coordinators[0].Draining())
+	coordinators[0].Leave()
+	s.True(coordinators[0].Draining())
+
+	err := coordinators[0].Execute(context.Background(), []tss.TssProcess{processes[0]}, make(chan interface{}), s.Hosts[1].ID())
+	s.ErrorIs(err, tss.ErrDraining)
+	s.Len(coordinators[0].PendingProcesses(), 0)
+}
+
+func (s *SigningTestSuite) Test_Drain_HandsOffPendingProcesses() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+
+		msg := new(big.Int).SetBytes([]byte("Message"))
+		signing, err := signing.NewSigning(msg, "signing6", "signing6", host, &communication, fetcher)
+		if err != nil {
+			panic(err)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, s.MockMetrics, electorFactory))
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	// only a single party executes the process so it is never finished
+	errChn := make(chan error)
+	go func() {
+		errChn <- coordinators[0].Execute(context.Background(), []tss.TssProcess{processes[0]}, make(chan interface{}), s.Hosts[0].ID())
+	}()
+	time.Sleep(time.Millisecond * 100)
+
+	coordinators[0].Leave()
+	s.Len(coordinators[0].PendingProcesses(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	err := coordinators[0].Drain(ctx)
+
+	s.NotNil(err)
+	s.ErrorIs(<-errChn, tss.ErrProcessCancelled)
+	s.Len(coordinators[0].PendingProcesses(), 0)
+	s.Nil(coordinators[0].Drain(context.Background()))
+}
+
+func (s *SigningTestSuite) Test_StartParams_DeprioritizedPeersNotSelected() {
+	fetcher := keyshare.NewECDSAKeyshareStore("../../test/keyshares/0.keyshare")
+	key, _ := fetcher.GetKeyshare()
+	signing, err := signing.NewSigning(big.NewInt(1), "signing4", "signing4", s.Hosts[0], &tsstest.TestCommunication{}, fetcher)
+	s.Nil(err)
+
+	sortedPeers := util.SortPeersForSession(key.Peers, "signing4").GetPeerIDs()
+	params := signing.StartParams(key.Peers, []peer.ID{sortedPeers[0]})
+
+	peerSubset := []peer.ID{}
+	err = json.Unmarshal(params, &peerSubset)
+	s.Nil(err)
+	s.Equal([]peer.ID{sortedPeers[1], sortedPeers[2]}, peerSubset)
+}
+
+func (s *SigningTestSuite) Test_StartParams_DeprioritizedPeersSelectedIfNotEnoughPeers() {
+	fetcher := keyshare.NewECDSAKeyshareStore("../../test/keyshares/0.keyshare")
+	key, _ := fetcher.GetKeyshare()
+	signing, err := signing.NewSigning(big.NewInt(1), "signing4", "signing4", s.Hosts[0], &tsstest.TestCommunication{}, fetcher)
+	s.Nil(err)
+
+	sortedPeers := util.SortPeersForSession(key.Peers, "signing4").GetPeerIDs()
+	params := signing.StartParams(key.Peers, sortedPeers[:2])
+
+	peerSubset := []peer.ID{}
+	err = json.Unmarshal(params, &peerSubset)
+	s.Nil(err)
+	s.Equal([]peer.ID{sortedPeers[2], sortedPeers[0]}, peerSubset)
+}
+
+func (s *SigningTestSuite) Test_ValidSigningProcess_Presignature() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	fetchers := []*keyshare.ECDSAKeyshareStore{}
+	presignatures := []*signing.PresignaturePool{}
+	processes := []tss.TssProcess{}
+
+	mockMetrics := mock_tss.NewMockPresignatureMetrics(s.GomockController)
+	mockMetrics.EXPECT().TrackPresignatures(gomock.Any()).AnyTimes()
+	mockMetrics.EXPECT().TrackPresignatureUsage(true)
+	coordinatorPeerID := s.Hosts[0].ID()
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+		fetchers = append(fetchers, fetcher)
+		presignatures = append(presignatures, signing.NewPresignaturePool(mockMetrics))
+
+		presigning, err := signing.NewPresigning("presign1", coordinatorPeerID, host, &communication, fetcher, presignatures[i])
+		if err != nil {
+			panic(err)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, s.MockMetrics, electorFactory))
+		processes = append(processes, presigning)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	pool := pool.New().WithErrors()
+	for i, coordinator := range coordinators {
+		pool.Go(func() error {
+			return coordinator.Execute(context.Background(), []tss.TssProcess{processes[i]}, make(chan interface{}, 1), coordinatorPeerID)
+		})
+	}
+	s.Nil(pool.Wait())
+	s.Equal(1, presignatures[0].Count(coordinatorPeerID))
+
+	msg := new(big.Int).SetBytes([]byte("Message"))
+	resultChn := make(chan interface{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i, coordinator := range coordinators {
+		// only peers that generated the presignature are online
+		if presignatures[i].Count(coordinatorPeerID) == 0 {
+			continue
+		}
+
+		signing, err := signing.NewSigning(msg, "signing1", "signing1", s.Hosts[i], communicationMap[s.Hosts[i].ID()], fetchers[i])
+		if err != nil {
+			panic(err)
+		}
+		signing.UsePresignatures(presignatures[i])
+
+		chn := make(chan interface{}, 1)
+		if s.Hosts[i].ID() == coordinatorPeerID {
+			chn = resultChn
+		}
+		go func() {
+			_ = coordinator.Execute(ctx, []tss.TssProcess{signing}, chn, coordinatorPeerID)
+		}()
+	}
+
+	sig := (<-resultChn).(signing.EcdsaSignature)
+	sig.Signature[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(ethCommon.LeftPadBytes(msg.Bytes(), 32), sig.Signature)
+	s.Nil(err)
+	key, _ := fetchers[0].GetKeyshare()
+	s.Equal(crypto.FromECDSAPub(key.Key.ECDSAPub.ToBtcecPubKey().ToECDSA()), crypto.FromECDSAPub(pub))
+	for _, presignatures := range presignatures {
+		s.Equal(0, presignatures.Count(coordinatorPeerID))
+	}
+}
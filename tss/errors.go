@@ -4,11 +4,19 @@
 package tss
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+var (
+	ErrProcessCancelled = errors.New("process cancelled")
+	ErrDraining         = errors.New("relayer is draining")
+	ErrProcessTimeout   = errors.New("process timed out")
+	ErrProcessRetried   = errors.New("process retried by coordinator")
+)
+
 type SubsetError struct {
 	Peer peer.ID
 }
@@ -16,3 +24,13 @@ type SubsetError struct {
 func (se *SubsetError) Error() string {
 	return fmt.Sprintf("party %s not in signing subset", se.Peer)
 }
+
+// StallError is returned when the tss process stopped progressing
+// while waiting for messages of the peers
+type StallError struct {
+	Peers []peer.ID
+}
+
+func (se *StallError) Error() string {
+	return fmt.Sprintf("process stalled waiting for peers %s", se.Peers)
+}
@@ -63,6 +63,35 @@ func UnmarshalStartMessage(msgBytes []byte) (*StartMessage, error) {
 	return msg, nil
 }
 
+// FailMessage is sent by the coordinator when the tss process fails. Participants
+// restart the process and wait for the coordinator if the process is retried.
+type FailMessage struct {
+	Retry bool `json:"retry"`
+}
+
+func MarshalFailMessage(retry bool) ([]byte, error) {
+	failMessage := &FailMessage{
+		Retry: retry,
+	}
+
+	msgBytes, err := json.Marshal(failMessage)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return msgBytes, nil
+}
+
+func UnmarshalFailMessage(msgBytes []byte) (*FailMessage, error) {
+	msg := &FailMessage{}
+	err := json.Unmarshal(msgBytes, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
 type SignatureMessage struct {
 	Signature []byte `json:"signature"`
 	ID        string `json:"id"`
@@ -91,3 +120,59 @@ func UnmarshalSignatureMessage(msgBytes []byte) (*SignatureMessage, error) {
 
 	return msg, nil
 }
+
+type SignatureRequestMessage struct {
+	ID string `json:"id"`
+}
+
+func MarshalSignatureRequestMessage(id string) ([]byte, error) {
+	requestMessage := &SignatureRequestMessage{
+		ID: id,
+	}
+
+	msgBytes, err := json.Marshal(requestMessage)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return msgBytes, nil
+}
+
+func UnmarshalSignatureRequestMessage(msgBytes []byte) (*SignatureRequestMessage, error) {
+	msg := &SignatureRequestMessage{}
+	err := json.Unmarshal(msgBytes, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+type SignatureResponseMessage struct {
+	Signature []byte `json:"signature"`
+	ID        string `json:"id"`
+}
+
+func MarshalSignatureResponseMessage(id string, signature []byte) ([]byte, error) {
+	responseMessage := &SignatureResponseMessage{
+		Signature: signature,
+		ID:        id,
+	}
+
+	msgBytes, err := json.Marshal(responseMessage)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return msgBytes, nil
+}
+
+func UnmarshalSignatureResponseMessage(msgBytes []byte) (*SignatureResponseMessage, error) {
+	msg := &SignatureResponseMessage{}
+	err := json.Unmarshal(msgBytes, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
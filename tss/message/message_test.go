@@ -53,6 +53,33 @@ func (s *StartMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
 	s.Equal(originalMsg, unmarshaledMsg)
 }
 
+type FailMessageTestSuite struct {
+	suite.Suite
+}
+
+func TestRunFailMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(FailMessageTestSuite))
+}
+
+func (s *FailMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
+	originalMsg := &message.FailMessage{
+		Retry: true,
+	}
+	msgBytes, err := message.MarshalFailMessage(originalMsg.Retry)
+	s.Nil(err)
+
+	unmarshaledMsg, err := message.UnmarshalFailMessage(msgBytes)
+	s.Nil(err)
+
+	s.Equal(originalMsg, unmarshaledMsg)
+}
+
+func (s *FailMessageTestSuite) Test_UnmarshalEmptyMessage_Fails() {
+	_, err := message.UnmarshalFailMessage([]byte{})
+
+	s.NotNil(err)
+}
+
 type SignatureMessageTestSuite struct {
 	suite.Suite
 }
@@ -74,3 +101,30 @@ func (s *SignatureMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
 
 	s.Equal(originalMsg, unmarshaledMsg)
 }
+
+func (s *SignatureMessageTestSuite) Test_UnmarshaledRequestMessageShouldBeEqual() {
+	originalMsg := &message.SignatureRequestMessage{
+		ID: "id",
+	}
+	msgBytes, err := message.MarshalSignatureRequestMessage(originalMsg.ID)
+	s.Nil(err)
+
+	unmarshaledMsg, err := message.UnmarshalSignatureRequestMessage(msgBytes)
+	s.Nil(err)
+
+	s.Equal(originalMsg, unmarshaledMsg)
+}
+
+func (s *SignatureMessageTestSuite) Test_UnmarshaledResponseMessageShouldBeEqual() {
+	originalMsg := &message.SignatureResponseMessage{
+		ID:        "id",
+		Signature: []byte("test"),
+	}
+	msgBytes, err := message.MarshalSignatureResponseMessage(originalMsg.ID, originalMsg.Signature)
+	s.Nil(err)
+
+	unmarshaledMsg, err := message.UnmarshalSignatureResponseMessage(msgBytes)
+	s.Nil(err)
+
+	s.Equal(originalMsg, unmarshaledMsg)
+}
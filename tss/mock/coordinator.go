@@ -15,6 +15,7 @@ import (
 	time "time"
 
 	peer "github.com/libp2p/go-libp2p/core/peer"
+	common "github.com/sprintertech/sprinter-signing/tss/ecdsa/common"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,6 +43,20 @@ func (m *MockTssProcess) EXPECT() *MockTssProcessMockRecorder {
 	return m.recorder
 }
 
+// ProcessType mocks base method.
+func (m *MockTssProcess) ProcessType() common.ProcessType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessType")
+	ret0, _ := ret[0].(common.ProcessType)
+	return ret0
+}
+
+// ProcessType indicates an expected call of ProcessType.
+func (mr *MockTssProcessMockRecorder) ProcessType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessType", reflect.TypeOf((*MockTssProcess)(nil).ProcessType))
+}
+
 // Ready mocks base method.
 func (m *MockTssProcess) Ready(readyPeers, excludedPeers []peer.ID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -100,17 +115,17 @@ func (mr *MockTssProcessMockRecorder) SessionID() *gomock.Call {
 }
 
 // StartParams mocks base method.
-func (m *MockTssProcess) StartParams(readyPeers []peer.ID) []byte {
+func (m *MockTssProcess) StartParams(readyPeers, deprioritizedPeers []peer.ID) []byte {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartParams", readyPeers)
+	ret := m.ctrl.Call(m, "StartParams", readyPeers, deprioritizedPeers)
 	ret0, _ := ret[0].([]byte)
 	return ret0
 }
 
 // StartParams indicates an expected call of StartParams.
-func (mr *MockTssProcessMockRecorder) StartParams(readyPeers any) *gomock.Call {
+func (mr *MockTssProcessMockRecorder) StartParams(readyPeers, deprioritizedPeers any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartParams", reflect.TypeOf((*MockTssProcess)(nil).StartParams), readyPeers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartParams", reflect.TypeOf((*MockTssProcess)(nil).StartParams), readyPeers, deprioritizedPeers)
 }
 
 // Stop mocks base method.
@@ -153,6 +168,20 @@ func (mr *MockTssProcessMockRecorder) ValidCoordinators() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidCoordinators", reflect.TypeOf((*MockTssProcess)(nil).ValidCoordinators))
 }
 
+// WaitingFor mocks base method.
+func (m *MockTssProcess) WaitingFor() []peer.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitingFor")
+	ret0, _ := ret[0].([]peer.ID)
+	return ret0
+}
+
+// WaitingFor indicates an expected call of WaitingFor.
+func (mr *MockTssProcessMockRecorder) WaitingFor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitingFor", reflect.TypeOf((*MockTssProcess)(nil).WaitingFor))
+}
+
 // MockMetrics is a mock of Metrics interface.
 type MockMetrics struct {
 	ctrl     *gomock.Controller
@@ -212,3 +241,27 @@ func (mr *MockMetricsMockRecorder) StartProcess(sessionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartProcess", reflect.TypeOf((*MockMetrics)(nil).StartProcess), sessionID)
 }
+
+// TrackPeerBlame mocks base method.
+func (m *MockMetrics) TrackPeerBlame(peerID, reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackPeerBlame", peerID, reason)
+}
+
+// TrackPeerBlame indicates an expected call of TrackPeerBlame.
+func (mr *MockMetricsMockRecorder) TrackPeerBlame(peerID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPeerBlame", reflect.TypeOf((*MockMetrics)(nil).TrackPeerBlame), peerID, reason)
+}
+
+// TrackProcessRetry mocks base method.
+func (m *MockMetrics) TrackProcessRetry(processType string, attempt int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackProcessRetry", processType, attempt)
+}
+
+// TrackProcessRetry indicates an expected call of TrackProcessRetry.
+func (mr *MockMetricsMockRecorder) TrackProcessRetry(processType, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackProcessRetry", reflect.TypeOf((*MockMetrics)(nil).TrackProcessRetry), processType, attempt)
+}
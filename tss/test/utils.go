@@ -43,6 +43,7 @@ func (s *CoordinatorTestSuite) SetupTest() {
 	s.MockMetrics = mock_tss.NewMockMetrics(s.GomockController)
 	s.MockMetrics.EXPECT().StartProcess(gomock.Any()).AnyTimes()
 	s.MockMetrics.EXPECT().RecordInitiateDuration(gomock.Any()).AnyTimes()
+	s.MockMetrics.EXPECT().TrackPeerBlame(gomock.Any(), gomock.Any()).AnyTimes()
 	s.PartyNumber = 3
 	s.Threshold = 1
 
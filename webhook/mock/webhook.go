@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webhook/webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./webhook/webhook.go -destination=./webhook/mock/webhook.go
+//
+
+// Package mock_webhook is a generated GoMock package.
+package mock_webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cache "github.com/sprintertech/sprinter-signing/cache"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatusSubscriber is a mock of StatusSubscriber interface.
+type MockStatusSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusSubscriberMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusSubscriberMockRecorder is the mock recorder for MockStatusSubscriber.
+type MockStatusSubscriberMockRecorder struct {
+	mock *MockStatusSubscriber
+}
+
+// NewMockStatusSubscriber creates a new mock instance.
+func NewMockStatusSubscriber(ctrl *gomock.Controller) *MockStatusSubscriber {
+	mock := &MockStatusSubscriber{ctrl: ctrl}
+	mock.recorder = &MockStatusSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusSubscriber) EXPECT() *MockStatusSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockStatusSubscriber) Subscribe(ctx context.Context, id string, after uint64, statusChn chan cache.RequestStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, id, after, statusChn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockStatusSubscriberMockRecorder) Subscribe(ctx, id, after, statusChn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStatusSubscriber)(nil).Subscribe), ctx, id, after, statusChn)
+}
+
+// MockSignatureFetcher is a mock of SignatureFetcher interface.
+type MockSignatureFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignatureFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockSignatureFetcherMockRecorder is the mock recorder for MockSignatureFetcher.
+type MockSignatureFetcherMockRecorder struct {
+	mock *MockSignatureFetcher
+}
+
+// NewMockSignatureFetcher creates a new mock instance.
+func NewMockSignatureFetcher(ctrl *gomock.Controller) *MockSignatureFetcher {
+	mock := &MockSignatureFetcher{ctrl: ctrl}
+	mock.recorder = &MockSignatureFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignatureFetcher) EXPECT() *MockSignatureFetcherMockRecorder {
+	return m.recorder
+}
+
+// Signature mocks base method.
+func (m *MockSignatureFetcher) Signature(id string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Signature", id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Signature indicates an expected call of Signature.
+func (mr *MockSignatureFetcherMockRecorder) Signature(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signature", reflect.TypeOf((*MockSignatureFetcher)(nil).Signature), id)
+}
@@ -0,0 +1,309 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/rs/zerolog/log"
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+)
+
+const (
+	WEBHOOK_TIMEOUT        = cache.STATUS_TTL
+	WEBHOOK_RETRY_WAIT_MIN = time.Second
+	WEBHOOK_RETRY_WAIT_MAX = time.Minute
+
+	TIMESTAMP_HEADER = "X-Timestamp"
+	SIGNATURE_HEADER = "X-Signature"
+)
+
+var (
+	// ErrPrivateHost is returned for callbacks to private, loopback or link-local addresses
+	ErrPrivateHost = errors.New("private host not allowed")
+)
+
+type StatusSubscriber interface {
+	Subscribe(ctx context.Context, id string, after uint64, statusChn chan cache.RequestStatus) error
+}
+
+type SignatureFetcher interface {
+	Signature(id string) ([]byte, error)
+}
+
+// Payload is the body sent to the callback URL once the signing request
+// is signed or fails
+type Payload struct {
+	ID        string             `json:"id"`
+	State     cache.RequestState `json:"state"`
+	Signature string             `json:"signature,omitempty"`
+	Reason    string             `json:"reason,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+type deadLetter struct {
+	URL       string    `json:"url"`
+	Payload   Payload   `json:"payload"`
+	Error     string    `json:"error"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Dispatcher delivers signing request outcomes to callback URLs. Callbacks are only
+// registered on the relayer that received the API request, so each callback is delivered
+// by a single relayer of the committee.
+type Dispatcher struct {
+	ctx               context.Context
+	statuses          StatusSubscriber
+	signatures        SignatureFetcher
+	client            *retryablehttp.Client
+	secret            []byte
+	allowPrivateHosts bool
+
+	watchersLock sync.Mutex
+	watchers     map[string]string
+
+	deadLetterLock sync.Mutex
+	deadLetter     io.Writer
+}
+
+func NewDispatcher(
+	ctx context.Context,
+	statuses StatusSubscriber,
+	signatures SignatureFetcher,
+	config relayer.WebhookConfig,
+	deadLetter io.Writer,
+) (*Dispatcher, error) {
+	if config.Secret == "" {
+		return nil, fmt.Errorf("webhook secret not set")
+	}
+
+	client := retryablehttp.NewClient()
+	client.RetryMax = config.Retries
+	client.RetryWaitMin = WEBHOOK_RETRY_WAIT_MIN
+	client.RetryWaitMax = WEBHOOK_RETRY_WAIT_MAX
+	client.Logger = nil
+	if !config.AllowPrivateHosts {
+		// resolved addresses are checked when connecting so host names
+		// can not be pointed to private addresses after validation
+		transport := client.HTTPClient.Transport.(*http.Transport)
+		transport.Proxy = nil
+		transport.DialContext = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   publicAddressControl,
+		}).DialContext
+	}
+
+	return &Dispatcher{
+		ctx:               ctx,
+		statuses:          statuses,
+		signatures:        signatures,
+		client:            client,
+		secret:            []byte(config.Secret),
+		allowPrivateHosts: config.AllowPrivateHosts,
+		watchers:          make(map[string]string),
+		deadLetter:        deadLetter,
+	}, nil
+}
+
+// Validate checks that the callback url is a http or https url that
+// does not point to a private, loopback or link-local address
+func (d *Dispatcher) Validate(callbackURL string) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("invalid")
+	}
+	if d.allowPrivateHosts {
+		return nil
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivateHost
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && !publicIP(ip) {
+		return ErrPrivateHost
+	}
+	return nil
+}
+
+// Register watches the signing request and posts its outcome to the callback url.
+// Failures are delivered as well, but the request is watched until it is signed
+// in case it is retried. Each request has a single watcher, so registering a
+// request that is already watched only replaces its callback url.
+func (d *Dispatcher) Register(id string, url string) {
+	d.watchersLock.Lock()
+	defer d.watchersLock.Unlock()
+
+	_, watching := d.watchers[id]
+	d.watchers[id] = url
+	if !watching {
+		go d.watch(id)
+	}
+}
+
+// callbackURL returns the latest callback url registered for the request
+func (d *Dispatcher) callbackURL(id string) string {
+	d.watchersLock.Lock()
+	defer d.watchersLock.Unlock()
+
+	return d.watchers[id]
+}
+
+func (d *Dispatcher) watch(id string) {
+	ctx, cancel := context.WithTimeout(d.ctx, WEBHOOK_TIMEOUT)
+	defer cancel()
+	defer func() {
+		d.watchersLock.Lock()
+		delete(d.watchers, id)
+		d.watchersLock.Unlock()
+	}()
+
+	statusChn := make(chan cache.RequestStatus, cache.STATUS_BUFFER_SIZE)
+	errChn := make(chan error, 1)
+	subscribe := func(after uint64) {
+		errChn <- d.statuses.Subscribe(ctx, id, after, statusChn)
+	}
+	go subscribe(0)
+
+	var after uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-errChn:
+			{
+				if !errors.Is(err, cache.ErrSlowSubscriber) {
+					return
+				}
+				go subscribe(after)
+			}
+		case status := <-statusChn:
+			{
+				after = status.Sequence
+				switch status.State {
+				case cache.SignedState:
+					{
+						sig, err := d.signatures.Signature(id)
+						if err != nil {
+							log.Warn().Str("id", id).Msgf("Missing signature for webhook: %s", err)
+							return
+						}
+
+						d.deliver(ctx, d.callbackURL(id), Payload{
+							ID:        id,
+							State:     status.State,
+							Signature: hex.EncodeToString(sig),
+							Timestamp: status.Timestamp,
+						})
+						return
+					}
+				case cache.FailedState:
+					{
+						d.deliver(ctx, d.callbackURL(id), Payload{
+							ID:        id,
+							State:     status.State,
+							Reason:    status.Reason,
+							Timestamp: status.Timestamp,
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, url string, payload Payload) {
+	err := d.post(ctx, url, payload)
+	if err == nil {
+		log.Debug().Str("id", payload.ID).Msgf("Delivered %s webhook to %s", payload.State, url)
+		return
+	}
+
+	log.Warn().Str("id", payload.ID).Msgf("Failed delivering webhook to %s: %s", url, err)
+	d.deadLetterLock.Lock()
+	defer d.deadLetterLock.Unlock()
+	err = json.NewEncoder(d.deadLetter).Encode(deadLetter{
+		URL:       url,
+		Payload:   payload,
+		Error:     err.Error(),
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		log.Err(err).Str("id", payload.ID).Msgf("Failed writing webhook dead letter")
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, url string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TIMESTAMP_HEADER, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SIGNATURE_HEADER, hex.EncodeToString(Sign(d.secret, timestamp, body)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// publicAddressControl rejects connections to addresses that are not public
+func publicAddressControl(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateHost, host)
+	}
+	return nil
+}
+
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// Sign returns the HMAC-SHA256 of "<timestamp>\n<body>" that receivers
+// can use to verify the webhook payload
+func Sign(secret []byte, timestamp int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(append([]byte(fmt.Sprintf("%d\n", timestamp)), body...))
+	return mac.Sum(nil)
+}
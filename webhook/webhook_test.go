@@ -0,0 +1,215 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sprintertech/sprinter-signing/cache"
+	"github.com/sprintertech/sprinter-signing/config/relayer"
+	"github.com/sprintertech/sprinter-signing/webhook"
+	mock_webhook "github.com/sprintertech/sprinter-signing/webhook/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+type DispatcherTestSuite struct {
+	suite.Suite
+
+	mockSignatures *mock_webhook.MockSignatureFetcher
+	statuses       *cache.StatusCache
+	deadLetter     *syncBuffer
+	payloads       chan webhook.Payload
+	server         *httptest.Server
+	dispatcher     *webhook.Dispatcher
+	cancel         context.CancelFunc
+}
+
+func TestRunDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherTestSuite))
+}
+
+func (s *DispatcherTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockSignatures = mock_webhook.NewMockSignatureFetcher(ctrl)
+	s.statuses = cache.NewStatusCache()
+	s.deadLetter = &syncBuffer{}
+	s.payloads = make(chan webhook.Payload, 2)
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.TIMESTAMP_HEADER), 10, 64)
+		if r.Header.Get(webhook.SIGNATURE_HEADER) != hex.EncodeToString(webhook.Sign([]byte("secret"), timestamp, body)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		payload := webhook.Payload{}
+		_ = json.Unmarshal(body, &payload)
+		s.payloads <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.dispatcher, _ = webhook.NewDispatcher(
+		ctx,
+		s.statuses,
+		s.mockSignatures,
+		relayer.WebhookConfig{
+			Secret:            "secret",
+			Retries:           0,
+			AllowPrivateHosts: true,
+		},
+		s.deadLetter,
+	)
+}
+
+func (s *DispatcherTestSuite) TearDownTest() {
+	s.cancel()
+	s.server.Close()
+}
+
+func (s *DispatcherTestSuite) Test_Register_SignedRequest() {
+	s.mockSignatures.EXPECT().Signature("1-1").Return([]byte{1, 2}, nil)
+
+	s.dispatcher.Register("1-1", s.server.URL)
+	s.statuses.Update("1-1", cache.SigningState)
+	s.statuses.Update("1-1", cache.SignedState)
+
+	payload := <-s.payloads
+	s.Equal("1-1", payload.ID)
+	s.Equal(cache.SignedState, payload.State)
+	s.Equal("0102", payload.Signature)
+}
+
+func (s *DispatcherTestSuite) Test_Register_FailedAndRetriedRequest() {
+	s.mockSignatures.EXPECT().Signature("1-1").Return([]byte{1, 2}, nil)
+
+	s.dispatcher.Register("1-1", s.server.URL)
+	s.statuses.Fail("1-1", fmt.Errorf("invalid deposit"))
+
+	payload := <-s.payloads
+	s.Equal(cache.FailedState, payload.State)
+	s.Equal("invalid deposit", payload.Reason)
+
+	s.statuses.Update("1-1", cache.SignedState)
+
+	payload = <-s.payloads
+	s.Equal(cache.SignedState, payload.State)
+	s.Equal("0102", payload.Signature)
+}
+
+func (s *DispatcherTestSuite) Test_Register_RetriedRegistrationDeliveredOnce() {
+	s.mockSignatures.EXPECT().Signature("1-1").Return([]byte{1, 2}, nil)
+
+	s.dispatcher.Register("1-1", s.server.URL)
+	s.dispatcher.Register("1-1", s.server.URL)
+	s.statuses.Update("1-1", cache.SignedState)
+
+	payload := <-s.payloads
+	s.Equal(cache.SignedState, payload.State)
+	select {
+	case payload := <-s.payloads:
+		s.Fail("duplicate webhook delivered", payload)
+	case <-time.After(time.Millisecond * 200):
+	}
+}
+
+func (s *DispatcherTestSuite) Test_Register_UndeliveredWebhookDeadLettered() {
+	s.dispatcher, _ = webhook.NewDispatcher(
+		context.Background(),
+		s.statuses,
+		s.mockSignatures,
+		relayer.WebhookConfig{
+			Secret:            "invalid",
+			Retries:           0,
+			AllowPrivateHosts: true,
+		},
+		s.deadLetter,
+	)
+
+	s.dispatcher.Register("1-1", s.server.URL)
+	s.statuses.Fail("1-1", fmt.Errorf("invalid deposit"))
+
+	s.Eventually(func() bool {
+		return s.deadLetter.String() != ""
+	}, time.Second, time.Millisecond*10)
+	s.Contains(s.deadLetter.String(), `"url":"`+s.server.URL+`"`)
+	s.Contains(s.deadLetter.String(), `"error":"unexpected status code 401"`)
+}
+
+func (s *DispatcherTestSuite) Test_NewDispatcher_MissingSecret() {
+	_, err := webhook.NewDispatcher(context.Background(), s.statuses, s.mockSignatures, relayer.WebhookConfig{}, s.deadLetter)
+
+	s.NotNil(err)
+}
+
+func (s *DispatcherTestSuite) Test_Validate_PrivateHosts() {
+	dispatcher, err := webhook.NewDispatcher(
+		context.Background(),
+		s.statuses,
+		s.mockSignatures,
+		relayer.WebhookConfig{
+			Secret: "secret",
+		},
+		s.deadLetter,
+	)
+	s.Nil(err)
+
+	s.Nil(dispatcher.Validate("https://solver.example.com/callback"))
+	s.NotNil(dispatcher.Validate("ftp://solver.example.com/callback"))
+	s.ErrorIs(dispatcher.Validate("http://127.0.0.1:8080/callback"), webhook.ErrPrivateHost)
+	s.ErrorIs(dispatcher.Validate("http://localhost/callback"), webhook.ErrPrivateHost)
+	s.ErrorIs(dispatcher.Validate("http://169.254.169.254/latest/meta-data"), webhook.ErrPrivateHost)
+	s.ErrorIs(dispatcher.Validate("http://10.0.0.1/callback"), webhook.ErrPrivateHost)
+	s.ErrorIs(dispatcher.Validate("http://[::1]/callback"), webhook.ErrPrivateHost)
+}
+
+func (s *DispatcherTestSuite) Test_Register_PrivateAddressNotDelivered() {
+	dispatcher, err := webhook.NewDispatcher(
+		context.Background(),
+		s.statuses,
+		s.mockSignatures,
+		relayer.WebhookConfig{
+			Secret: "secret",
+		},
+		s.deadLetter,
+	)
+	s.Nil(err)
+
+	dispatcher.Register("1-1", s.server.URL)
+	s.statuses.Fail("1-1", fmt.Errorf("invalid deposit"))
+
+	s.Eventually(func() bool {
+		return s.deadLetter.String() != ""
+	}, time.Second, time.Millisecond*10)
+	s.Contains(s.deadLetter.String(), webhook.ErrPrivateHost.Error())
+	s.Len(s.payloads, 0)
+}